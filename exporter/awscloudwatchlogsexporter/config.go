@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+)
+
+// Config defines configuration for the AWS CloudWatch Logs exporter.
+type Config struct {
+	config.ExporterSettings      `mapstructure:",squash"`
+	exporterhelper.QueueSettings `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings `mapstructure:"retry_on_failure"`
+	// AWSSessionSettings contains the common configuration options
+	// for creating AWS session to communicate with backend
+	awsutil.AWSSessionSettings `mapstructure:",squash"`
+
+	// LogGroupName is the name of CloudWatch log group the log records are sent to.
+	// Resource attributes can be referenced with their name between braces,
+	// e.g. "/aws/eks/{k8s.cluster.name}/{k8s.namespace.name}".
+	LogGroupName string `mapstructure:"log_group_name"`
+
+	// LogStreamName is the name of CloudWatch log stream the log records are sent to.
+	// It can reference resource attributes like LogGroupName.
+	LogStreamName string `mapstructure:"log_stream_name"`
+}
+
+// Validate checks that the log group and stream names are set.
+func (cfg *Config) Validate() error {
+	if cfg.LogGroupName == "" {
+		return errors.New("'log_group_name' must be set")
+	}
+	if cfg.LogStreamName == "" {
+		return errors.New("'log_stream_name' must be set")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Len(t, cfg.Exporters, 2)
+
+	sessionSettings := awsutil.CreateDefaultSessionConfig()
+	sessionSettings.Region = "us-west-2"
+	assert.Equal(t, &Config{
+		ExporterSettings:   config.NewExporterSettings(config.NewIDWithName(typeStr, "eks")),
+		QueueSettings:      exporterhelper.DefaultQueueSettings(),
+		RetrySettings:      exporterhelper.DefaultRetrySettings(),
+		AWSSessionSettings: sessionSettings,
+		LogGroupName:       "/aws/eks/{k8s.cluster.name}/{k8s.namespace.name}",
+		LogStreamName:      "{k8s.pod.name}",
+	}, cfg.Exporters[config.NewIDWithName(typeStr, "eks")])
+}
+
+func TestValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "'log_group_name' must be set")
+
+	cfg.LogGroupName = "group"
+	assert.EqualError(t, cfg.Validate(), "'log_stream_name' must be set")
+
+	cfg.LogStreamName = "stream"
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil"
+)
+
+// pusherIdleTimeout is the time after which the pusher of a log stream without new log records
+// is flushed and dropped, so that the pushers of short lived streams don't accumulate.
+const pusherIdleTimeout = 10 * time.Minute
+
+// maxBatchSpanMs is the maximum time between the log events of a PutLogEvents request.
+const maxBatchSpanMs = 24 * 3600 * 1000
+
+// logDestination is the log group and stream of log records.
+type logDestination struct {
+	group  string
+	stream string
+}
+
+// streamPusher is the pusher of a log stream. It is used by one request at a time so that the
+// log records of each PutLogEvents request are known.
+type streamPusher struct {
+	sync.Mutex
+	pusher awsemfexporter.Pusher
+	// users and lastUsed are guarded by the pushersLock of the exporter.
+	users    int
+	lastUsed time.Time
+}
+
+// recordEvent is the log event of the log record at index record of the logs.
+type recordEvent struct {
+	record int
+	event  *awsemfexporter.LogEvent
+}
+
+// logBatch follows the log events added to a pusher since its last flush. The exporter flushes
+// the pusher before the batch exceeds the PutLogEvents limits, so the pusher never flushes it itself.
+type logBatch struct {
+	records      []int
+	bytes        int
+	minTimestamp int64
+	maxTimestamp int64
+}
+
+func (b *logBatch) fits(event *awsemfexporter.LogEvent) bool {
+	if len(b.records) == 0 {
+		return true
+	}
+	timestamp := *event.InputLogEvent.Timestamp
+	return len(b.records) < awsemfexporter.MaxRequestEventCount &&
+		b.bytes+len(*event.InputLogEvent.Message)+awsemfexporter.PerEventHeaderBytes <= awsemfexporter.DefaultMaxEventPayloadBytes &&
+		timestamp-b.minTimestamp <= maxBatchSpanMs && b.maxTimestamp-timestamp <= maxBatchSpanMs
+}
+
+func (b *logBatch) add(e recordEvent) {
+	timestamp := *e.event.InputLogEvent.Timestamp
+	if len(b.records) == 0 || timestamp < b.minTimestamp {
+		b.minTimestamp = timestamp
+	}
+	if len(b.records) == 0 || timestamp > b.maxTimestamp {
+		b.maxTimestamp = timestamp
+	}
+	b.records = append(b.records, e.record)
+	b.bytes += len(*e.event.InputLogEvent.Message) + awsemfexporter.PerEventHeaderBytes
+}
+
+type cloudWatchLogsExporter struct {
+	config *Config
+	logger *zap.Logger
+	client awsemfexporter.LogClient
+
+	logGroupName  nameTemplate
+	logStreamName nameTemplate
+	retryCnt      int
+
+	// Each log stream keeps a separate pusher because each stream requires a separate sequence token.
+	pushersLock  sync.Mutex
+	pushers      map[logDestination]*streamPusher
+	lastEviction time.Time
+}
+
+func newCloudWatchLogsExporter(cfg *Config, params component.ExporterCreateParams) (*cloudWatchLogsExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	// create AWS session
+	awsConfig, session, err := awsutil.GetAWSConfigSession(params.Logger, &awsutil.Conn{}, &cfg.AWSSessionSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	// create CWLogs client with aws session config
+	client := awsemfexporter.NewCloudWatchLogsClient(params.Logger, awsConfig, params.BuildInfo, session)
+	return newExporterWithClient(cfg, client, *awsConfig.MaxRetries, params.Logger), nil
+}
+
+func newExporterWithClient(cfg *Config, client awsemfexporter.LogClient, retryCnt int, logger *zap.Logger) *cloudWatchLogsExporter {
+	return &cloudWatchLogsExporter{
+		config:        cfg,
+		logger:        logger,
+		client:        client,
+		logGroupName:  nameTemplate(cfg.LogGroupName),
+		logStreamName: nameTemplate(cfg.LogStreamName),
+		retryCnt:      retryCnt,
+		pushers:       map[logDestination]*streamPusher{},
+	}
+}
+
+func (e *cloudWatchLogsExporter) pushLogs(_ context.Context, ld pdata.Logs) error {
+	e.evictIdlePushers(time.Now())
+
+	dests, events := e.groupLogEvents(ld)
+	var errs []error
+	for i, dest := range dests {
+		failed, err := e.pushStream(dest, events[dest])
+		if err == nil {
+			continue
+		}
+		if err = wrapErrorIfBadRequest(err); consumererror.IsPermanent(err) {
+			errs = append(errs, err)
+			continue
+		}
+
+		// The log records of the requests that succeeded are not sent again.
+		remaining := map[int]bool{}
+		for _, record := range failed {
+			remaining[record] = true
+		}
+		for _, next := range dests[i+1:] {
+			for _, event := range events[next] {
+				remaining[event.record] = true
+			}
+		}
+		return consumererror.NewLogs(err, pdatautil.SubLogs(ld, remaining))
+	}
+	return consumererror.Combine(errs)
+}
+
+// groupLogEvents converts the log records to log events grouped by log stream, in the order in
+// which the streams first appear. Log records that CloudWatch Logs would reject are dropped.
+func (e *cloudWatchLogsExporter) groupLogEvents(ld pdata.Logs) ([]logDestination, map[logDestination][]recordEvent) {
+	var dests []logDestination
+	events := map[logDestination][]recordEvent{}
+	record := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		attrs := rl.Resource().Attributes()
+		dest := logDestination{
+			group:  e.logGroupName.expand(attrs),
+			stream: e.logStreamName.expand(attrs),
+		}
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k, record = k+1, record+1 {
+				event := logRecordToLogEvent(logs.At(k))
+				if err := event.Validate(e.logger); err != nil {
+					e.logger.Debug("Dropped log record", zap.String("LogGroupName", dest.group), zap.String("LogStreamName", dest.stream), zap.Error(err))
+					continue
+				}
+				if _, ok := events[dest]; !ok {
+					dests = append(dests, dest)
+				}
+				events[dest] = append(events[dest], recordEvent{record: record, event: event})
+			}
+		}
+	}
+	return dests, events
+}
+
+// pushStream sends the log events to their log stream. On failure, it returns the log records
+// that were not sent.
+func (e *cloudWatchLogsExporter) pushStream(dest logDestination, events []recordEvent) ([]int, error) {
+	sp := e.acquirePusher(dest)
+	defer e.releasePusher(sp)
+
+	var batch logBatch
+	for i, event := range events {
+		if !batch.fits(event.event) {
+			if err := sp.pusher.ForceFlush(); err != nil {
+				return append(batch.records, records(events[i:])...), err
+			}
+			batch = logBatch{}
+		}
+		if err := sp.pusher.AddLogEntry(event.event); err != nil {
+			// The event was validated already and the pusher never flushes by itself.
+			e.logger.Debug("Dropped log record", zap.String("LogGroupName", dest.group), zap.String("LogStreamName", dest.stream), zap.Error(err))
+			continue
+		}
+		batch.add(event)
+	}
+	if err := sp.pusher.ForceFlush(); err != nil {
+		return batch.records, err
+	}
+	return nil, nil
+}
+
+func records(events []recordEvent) []int {
+	records := make([]int, len(events))
+	for i, e := range events {
+		records[i] = e.record
+	}
+	return records
+}
+
+// acquirePusher returns the pusher of the log stream, locked until it is released.
+func (e *cloudWatchLogsExporter) acquirePusher(dest logDestination) *streamPusher {
+	e.pushersLock.Lock()
+	sp, ok := e.pushers[dest]
+	if !ok {
+		sp = &streamPusher{pusher: awsemfexporter.NewPusher(aws.String(dest.group), aws.String(dest.stream), e.retryCnt, e.client, e.logger)}
+		e.pushers[dest] = sp
+	}
+	sp.users++
+	e.pushersLock.Unlock()
+
+	sp.Lock()
+	return sp
+}
+
+func (e *cloudWatchLogsExporter) releasePusher(sp *streamPusher) {
+	sp.Unlock()
+
+	e.pushersLock.Lock()
+	defer e.pushersLock.Unlock()
+	sp.users--
+	sp.lastUsed = time.Now()
+}
+
+// evictIdlePushers flushes and drops the pushers unused for pusherIdleTimeout. It only looks for
+// them once every pusherIdleTimeout.
+func (e *cloudWatchLogsExporter) evictIdlePushers(now time.Time) {
+	var idle []awsemfexporter.Pusher
+	e.pushersLock.Lock()
+	if now.Sub(e.lastEviction) < pusherIdleTimeout {
+		e.pushersLock.Unlock()
+		return
+	}
+	e.lastEviction = now
+	for dest, sp := range e.pushers {
+		if sp.users == 0 && now.Sub(sp.lastUsed) >= pusherIdleTimeout {
+			idle = append(idle, sp.pusher)
+			delete(e.pushers, dest)
+		}
+	}
+	e.pushersLock.Unlock()
+
+	for _, pusher := range idle {
+		if err := pusher.ForceFlush(); err != nil {
+			e.logger.Warn("Failed to flush the log events of an idle log stream", zap.Error(err))
+		}
+	}
+}
+
+func (e *cloudWatchLogsExporter) listPushers() []awsemfexporter.Pusher {
+	e.pushersLock.Lock()
+	defer e.pushersLock.Unlock()
+
+	pushers := make([]awsemfexporter.Pusher, 0, len(e.pushers))
+	for _, sp := range e.pushers {
+		pushers = append(pushers, sp.pusher)
+	}
+	return pushers
+}
+
+// shutdown flushes the log events still buffered by the pushers.
+func (e *cloudWatchLogsExporter) shutdown(context.Context) error {
+	var errs []error
+	for _, pusher := range e.listPushers() {
+		if err := pusher.ForceFlush(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+// logRecordToLogEvent converts a log record to a CloudWatch Logs event with its body as message.
+// Log records without timestamp get the current time.
+func logRecordToLogEvent(lr pdata.LogRecord) *awsemfexporter.LogEvent {
+	return &awsemfexporter.LogEvent{
+		InputLogEvent: &cloudwatchlogs.InputLogEvent{
+			Timestamp: aws.Int64(int64(lr.Timestamp()) / int64(time.Millisecond)),
+			Message:   aws.String(tracetranslator.AttributeValueToString(lr.Body(), false)),
+		},
+		LogGeneratedTime: time.Now(),
+	}
+}
+
+func wrapErrorIfBadRequest(err error) error {
+	if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() < 500 {
+		return consumererror.Permanent(err)
+	}
+	return err
+}
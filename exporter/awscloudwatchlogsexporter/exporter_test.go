@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// mockLogClient records the log events put in each log stream. Once errAfter requests
+// succeeded, the requests to errGroup, or to any group if it is empty, fail with err.
+type mockLogClient struct {
+	mu       sync.Mutex
+	events   map[logDestination][]string
+	streams  []logDestination
+	requests int
+	err      error
+	errAfter int
+	errGroup string
+}
+
+func (c *mockLogClient) PutLogEvents(input *cloudwatchlogs.PutLogEventsInput, _ int) (*string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil && c.requests >= c.errAfter && (c.errGroup == "" || c.errGroup == *input.LogGroupName) {
+		return nil, c.err
+	}
+	c.requests++
+	if c.events == nil {
+		c.events = map[logDestination][]string{}
+	}
+	dest := logDestination{group: *input.LogGroupName, stream: *input.LogStreamName}
+	for _, event := range input.LogEvents {
+		c.events[dest] = append(c.events[dest], *event.Message)
+	}
+	return nil, nil
+}
+
+func (c *mockLogClient) CreateStream(logGroup, streamName *string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.streams = append(c.streams, logDestination{group: *logGroup, stream: *streamName})
+	return "", nil
+}
+
+func newTestLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	now := pdata.TimestampFromTime(time.Now())
+	for _, namespace := range []string{"default", "kube-system"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("k8s.cluster.name", "mycluster")
+		rl.Resource().Attributes().InsertString("k8s.namespace.name", namespace)
+		logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+		for _, body := range []string{"first " + namespace, "second " + namespace} {
+			lr := logs.AppendEmpty()
+			lr.SetTimestamp(now)
+			lr.Body().SetStringVal(body)
+		}
+	}
+	return ld
+}
+
+func TestPushLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogGroupName = "/aws/eks/{k8s.cluster.name}/{k8s.namespace.name}"
+	cfg.LogStreamName = "stream"
+	client := &mockLogClient{}
+	exp := newExporterWithClient(cfg, client, 1, zap.NewNop())
+
+	ld := newTestLogs()
+	// Log records without body or too old are dropped.
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	logs.AppendEmpty().SetTimestamp(pdata.TimestampFromTime(time.Now()))
+	old := logs.AppendEmpty()
+	old.SetTimestamp(pdata.TimestampFromTime(time.Now().Add(-15 * 24 * time.Hour)))
+	old.Body().SetStringVal("too old")
+	// Log records without timestamp get the current time.
+	logs.AppendEmpty().Body().SetStringVal("no timestamp")
+
+	require.NoError(t, exp.pushLogs(context.Background(), ld))
+
+	assert.Equal(t, map[logDestination][]string{
+		{group: "/aws/eks/mycluster/default", stream: "stream"}:     {"first default", "second default", "no timestamp"},
+		{group: "/aws/eks/mycluster/kube-system", stream: "stream"}: {"first kube-system", "second kube-system"},
+	}, client.events)
+	assert.ElementsMatch(t, []logDestination{
+		{group: "/aws/eks/mycluster/default", stream: "stream"},
+		{group: "/aws/eks/mycluster/kube-system", stream: "stream"},
+	}, client.streams)
+
+	// The pushers and their sequence tokens are kept across requests.
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs()))
+	assert.Len(t, client.streams, 2)
+	assert.Len(t, exp.listPushers(), 2)
+	assert.NoError(t, exp.shutdown(context.Background()))
+}
+
+func TestPushLogsErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantPermanent bool
+	}{
+		{
+			name:          "bad request",
+			err:           awserr.NewRequestFailure(awserr.New(cloudwatchlogs.ErrCodeInvalidParameterException, "invalid", nil), 400, "id"),
+			wantPermanent: true,
+		},
+		{
+			name:          "server error",
+			err:           awserr.NewRequestFailure(awserr.New(cloudwatchlogs.ErrCodeServiceUnavailableException, "unavailable", nil), 503, "id"),
+			wantPermanent: false,
+		},
+		{
+			name:          "other error",
+			err:           errors.New("connection refused"),
+			wantPermanent: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.LogGroupName = "group"
+			cfg.LogStreamName = "stream"
+			exp := newExporterWithClient(cfg, &mockLogClient{err: tt.err}, 1, zap.NewNop())
+
+			err := exp.pushLogs(context.Background(), newTestLogs())
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestPushLogsRetriesUnsentRecords(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogGroupName = "/aws/eks/{k8s.cluster.name}/{k8s.namespace.name}"
+	cfg.LogStreamName = "stream"
+	client := &mockLogClient{
+		err:      awserr.NewRequestFailure(awserr.New(cloudwatchlogs.ErrCodeServiceUnavailableException, "unavailable", nil), 503, "id"),
+		errGroup: "/aws/eks/mycluster/kube-system",
+	}
+	exp := newExporterWithClient(cfg, client, 1, zap.NewNop())
+
+	err := exp.pushLogs(context.Background(), newTestLogs())
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	require.IsType(t, consumererror.Logs{}, err)
+
+	// Only the log records of the stream that failed are sent again.
+	remaining := err.(consumererror.Logs).GetLogs()
+	require.Equal(t, 1, remaining.ResourceLogs().Len())
+	namespace, _ := remaining.ResourceLogs().At(0).Resource().Attributes().Get("k8s.namespace.name")
+	assert.Equal(t, "kube-system", namespace.StringVal())
+	assert.Equal(t, 2, remaining.LogRecordCount())
+	assert.Equal(t, map[logDestination][]string{
+		{group: "/aws/eks/mycluster/default", stream: "stream"}: {"first default", "second default"},
+	}, client.events)
+}
+
+func TestPushLogsRetriesUnsentBatches(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogGroupName = "group"
+	cfg.LogStreamName = "stream"
+	client := &mockLogClient{err: errors.New("connection refused"), errAfter: 1}
+	exp := newExporterWithClient(cfg, client, 1, zap.NewNop())
+
+	// 2 log records of 100KB fit in a request, the third one is sent in another request.
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, name := range []string{"a", "b", "c"} {
+		lr := logs.AppendEmpty()
+		lr.SetTimestamp(pdata.TimestampFromTime(time.Now()))
+		lr.Body().SetStringVal(strings.Repeat(name, 100*1024))
+	}
+
+	err := exp.pushLogs(context.Background(), ld)
+	require.Error(t, err)
+	require.IsType(t, consumererror.Logs{}, err)
+	remaining := err.(consumererror.Logs).GetLogs()
+	require.Equal(t, 1, remaining.LogRecordCount())
+	assert.Equal(t, strings.Repeat("c", 100*1024), remaining.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body().StringVal())
+	assert.Len(t, client.events[logDestination{group: "group", stream: "stream"}], 2)
+}
+
+func TestEvictIdlePushers(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogGroupName = "/aws/eks/{k8s.cluster.name}/{k8s.namespace.name}"
+	cfg.LogStreamName = "stream"
+	client := &mockLogClient{}
+	exp := newExporterWithClient(cfg, client, 1, zap.NewNop())
+
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs()))
+	require.Len(t, exp.listPushers(), 2)
+
+	// The pushers are kept while they are used.
+	exp.lastEviction = time.Time{}
+	exp.evictIdlePushers(time.Now().Add(pusherIdleTimeout / 2))
+	assert.Len(t, exp.listPushers(), 2)
+	exp.evictIdlePushers(time.Now().Add(2 * pusherIdleTimeout))
+	assert.Empty(t, exp.listPushers())
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "awscloudwatchlogs"
+)
+
+// NewFactory creates a factory for the AWS CloudWatch Logs exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithLogs(createLogsExporter))
+}
+
+// createDefaultConfig creates the default configuration for exporter.
+func createDefaultConfig() config.Exporter {
+	return &Config{
+		ExporterSettings:   config.NewExporterSettings(config.NewID(typeStr)),
+		QueueSettings:      exporterhelper.DefaultQueueSettings(),
+		RetrySettings:      exporterhelper.DefaultRetrySettings(),
+		AWSSessionSettings: awsutil.CreateDefaultSessionConfig(),
+	}
+}
+
+// createLogsExporter creates a logs exporter based on this config.
+func createLogsExporter(_ context.Context,
+	params component.ExporterCreateParams,
+	cfg config.Exporter) (component.LogsExporter, error) {
+
+	expCfg := cfg.(*Config)
+	exp, err := newCloudWatchLogsExporter(expCfg, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogsExporter(
+		expCfg,
+		params.Logger,
+		exp.pushLogs,
+		exporterhelper.WithQueue(expCfg.QueueSettings),
+		exporterhelper.WithRetry(expCfg.RetrySettings),
+		exporterhelper.WithShutdown(exp.shutdown),
+	)
+}
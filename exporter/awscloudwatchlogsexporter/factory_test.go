@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.uber.org/zap"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateLogsExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Region = "us-west-2"
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	exporter, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	assert.Error(t, err)
+	assert.Nil(t, exporter)
+
+	cfg.LogGroupName = "group"
+	cfg.LogStreamName = "stream"
+	exporter, err = factory.CreateLogsExporter(context.Background(), params, cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, exporter)
+}
+
+func TestCreateMetricsExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	exporter, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	assert.Error(t, err)
+	assert.Nil(t, exporter)
+}
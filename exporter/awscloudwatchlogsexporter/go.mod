@@ -0,0 +1,21 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awscloudwatchlogsexporter
+
+go 1.15
+
+require (
+	github.com/aws/aws-sdk-go v1.38.36
+	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter v0.0.0-00010101000000-000000000000
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil v0.0.0-00010101000000-000000000000
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.26.1-0.20210513162346-453d1d0dd603
+	go.uber.org/zap v1.16.0
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter => ./../awsemfexporter
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/metrics => ./../../internal/aws/metrics
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil => ./../../internal/aws/awsutil
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil => ./../../internal/pdatautil
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// undefinedAttribute replaces the attributes referenced by a name template
+// that the resource does not have, like in the awsemf exporter.
+const undefinedAttribute = "undefined"
+
+var attributePattern = regexp.MustCompile(`{([^{}]+)}`)
+
+// nameTemplate is a log group or stream name referencing resource attributes
+// with their name between braces.
+type nameTemplate string
+
+// expand replaces the attributes referenced by the template with their value in attrs.
+func (t nameTemplate) expand(attrs pdata.AttributeMap) string {
+	return attributePattern.ReplaceAllStringFunc(string(t), func(match string) string {
+		key := match[1 : len(match)-1]
+		if v, ok := attrs.Get(key); ok {
+			if value := tracetranslator.AttributeValueToString(v, false); value != "" {
+				return value
+			}
+		}
+		return undefinedAttribute
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestNameTemplateExpand(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("k8s.cluster.name", "mycluster")
+	attrs.InsertString("k8s.namespace.name", "default")
+	attrs.InsertInt("port", 8080)
+	attrs.InsertString("empty", "")
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{template: "/aws/eks/{k8s.cluster.name}/{k8s.namespace.name}", want: "/aws/eks/mycluster/default"},
+		{template: "static", want: "static"},
+		{template: "port-{port}", want: "port-8080"},
+		{template: "{missing}-{empty}", want: "undefined-undefined"},
+		{template: "{k8s.cluster.name}{k8s.cluster.name}", want: "myclustermycluster"},
+		{template: "{}", want: "{}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			assert.Equal(t, tt.want, nameTemplate(tt.template).expand(attrs))
+		})
+	}
+}
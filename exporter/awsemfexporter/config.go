@@ -64,6 +64,11 @@ type Config struct {
 	// TODO: we can support directing output to a file (in the future) while customer specifies a file path here.
 	OutputDestination string `mapstructure:"output_destination"`
 
+	// EmitHistogramBuckets is the option to export histograms as EMF Values and Counts arrays instead of
+	// statistic sets, so that CloudWatch can compute percentiles from them. Default is `false`.
+	// Histograms with more non-empty buckets than EMF allows in a single metric are split across several EMF logs.
+	EmitHistogramBuckets bool `mapstructure:"emit_histogram_buckets"`
+
 	// ResourceToTelemetrySettings is the option for converting resource attrihutes to telemetry attributes.
 	// "Enabled" - A boolean field to enable/disable this option. Default is `false`.
 	// If enabled, all the resource attributes will be converted to metric labels by default.
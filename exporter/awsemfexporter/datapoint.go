@@ -96,6 +96,7 @@ type DoubleDataPointSlice struct {
 // HistogramDataPointSlice is a wrapper for pdata.HistogramDataPointSlice
 type HistogramDataPointSlice struct {
 	instrumentationLibraryName string
+	emitBuckets                bool
 	pdata.HistogramDataPointSlice
 }
 
@@ -159,6 +160,16 @@ func (dps HistogramDataPointSlice) At(i int) DataPoint {
 	labels := createLabels(metric.LabelsMap(), dps.instrumentationLibraryName)
 	timestamp := unixNanoToMilliseconds(metric.Timestamp())
 
+	if dps.emitBuckets {
+		if histogram := buildCWMetricHistogram(metric); histogram != nil {
+			return DataPoint{
+				Value:       histogram,
+				Labels:      labels,
+				TimestampMs: timestamp,
+			}
+		}
+	}
+
 	return DataPoint{
 		Value: &CWMetricStats{
 			Count: metric.Count(),
@@ -169,6 +180,83 @@ func (dps HistogramDataPointSlice) At(i int) DataPoint {
 	}
 }
 
+// buildCWMetricHistogram represents every non-empty bucket of the histogram data point by a single value:
+// the midpoint of its bounds, or the nearest bound for the two unbounded buckets.
+// It returns nil when the data point has no non-empty bucket.
+func buildCWMetricHistogram(metric pdata.HistogramDataPoint) *CWMetricHistogram {
+	bounds := metric.ExplicitBounds()
+	histogram := &CWMetricHistogram{
+		Count: metric.Count(),
+		Sum:   metric.Sum(),
+	}
+	for i, count := range metric.BucketCounts() {
+		if count == 0 {
+			continue
+		}
+		var value float64
+		switch {
+		case len(bounds) == 0:
+			value = metric.Sum() / float64(count)
+		case i == 0:
+			value = bounds[0]
+		case i >= len(bounds):
+			value = bounds[len(bounds)-1]
+		default:
+			value = (bounds[i-1] + bounds[i]) / 2
+		}
+		histogram.Values = append(histogram.Values, value)
+		histogram.Counts = append(histogram.Counts, count)
+	}
+	if len(histogram.Values) == 0 {
+		return nil
+	}
+	histogram.Min = histogram.Values[0]
+	histogram.Max = histogram.Values[len(histogram.Values)-1]
+	return histogram
+}
+
+// split divides the histogram into histograms of at most maxValues values each.
+// Count and Min/Max of each part are taken from its own buckets, while Sum is shared out in proportion
+// to the estimated sum of each part so that the parts still add up to the original histogram.
+func (h *CWMetricHistogram) split(maxValues int) []*CWMetricHistogram {
+	if len(h.Values) <= maxValues {
+		return []*CWMetricHistogram{h}
+	}
+
+	var parts []*CWMetricHistogram
+	var estimates []float64
+	var totalEstimate float64
+	for start := 0; start < len(h.Values); start += maxValues {
+		end := start + maxValues
+		if end > len(h.Values) {
+			end = len(h.Values)
+		}
+		part := &CWMetricHistogram{
+			Values: h.Values[start:end],
+			Counts: h.Counts[start:end],
+			Min:    h.Values[start],
+			Max:    h.Values[end-1],
+		}
+		var estimate float64
+		for i, count := range part.Counts {
+			part.Count += count
+			estimate += part.Values[i] * float64(count)
+		}
+		parts = append(parts, part)
+		estimates = append(estimates, estimate)
+		totalEstimate += estimate
+	}
+
+	for i, part := range parts {
+		if totalEstimate != 0 {
+			part.Sum = h.Sum * estimates[i] / totalEstimate
+		} else if h.Count != 0 {
+			part.Sum = h.Sum * float64(part.Count) / float64(h.Count)
+		}
+	}
+	return parts
+}
+
 // At retrieves the SummaryDataPoint at the given index.
 func (dps SummaryDataPointSlice) At(i int) DataPoint {
 	metric := dps.SummaryDataPointSlice.At(i)
@@ -268,6 +356,7 @@ func getDataPoints(pmd *pdata.Metric, metadata CWMetricMetadata, logger *zap.Log
 		metric := pmd.Histogram()
 		dps = HistogramDataPointSlice{
 			metadata.InstrumentationLibraryName,
+			metadata.emitHistogramBuckets,
 			metric.DataPoints(),
 		}
 	case pdata.MetricDataTypeSummary:
@@ -388,6 +388,7 @@ func TestHistogramDataPointSliceAt(t *testing.T) {
 
 	dps := HistogramDataPointSlice{
 		instrLibName,
+		false,
 		testDPS,
 	}
 
@@ -407,6 +408,71 @@ func TestHistogramDataPointSliceAt(t *testing.T) {
 	assert.Equal(t, expectedDP, dp)
 }
 
+func TestHistogramDataPointSliceAtWithBuckets(t *testing.T) {
+	instrLibName := "cloudwatch-otel"
+	labels := map[string]string{"label1": "value1"}
+
+	testDPS := pdata.NewHistogramDataPointSlice()
+	testDP := testDPS.AppendEmpty()
+	testDP.SetCount(uint64(17))
+	testDP.SetSum(float64(17.13))
+	testDP.SetBucketCounts([]uint64{1, 0, 3, 6, 7})
+	testDP.SetExplicitBounds([]float64{1, 2, 3, 5})
+	testDP.LabelsMap().InitFromMap(labels)
+
+	dps := HistogramDataPointSlice{
+		instrLibName,
+		true,
+		testDPS,
+	}
+
+	expectedDP := DataPoint{
+		Value: &CWMetricHistogram{
+			Values: []float64{1, 2.5, 4, 5},
+			Counts: []uint64{1, 3, 6, 7},
+			Min:    1,
+			Max:    5,
+			Sum:    17.13,
+			Count:  17,
+		},
+		Labels: map[string]string{
+			oTellibDimensionKey: instrLibName,
+			"label1":            "value1",
+		},
+	}
+
+	assert.Equal(t, 1, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, expectedDP, dp)
+
+	// A histogram without any non-empty bucket falls back to a statistic set
+	testDP.SetCount(0)
+	testDP.SetSum(0)
+	testDP.SetBucketCounts([]uint64{0, 0, 0, 0, 0})
+	dp = dps.At(0)
+	assert.Equal(t, &CWMetricStats{}, dp.Value)
+}
+
+func TestCWMetricHistogramSplit(t *testing.T) {
+	histogram := &CWMetricHistogram{
+		Values: []float64{1, 2, 3, 4, 5},
+		Counts: []uint64{1, 1, 1, 1, 1},
+		Min:    1,
+		Max:    5,
+		Count:  5,
+		Sum:    30,
+	}
+
+	assert.Equal(t, []*CWMetricHistogram{histogram}, histogram.split(5))
+
+	parts := histogram.split(2)
+	assert.Equal(t, []*CWMetricHistogram{
+		{Values: []float64{1, 2}, Counts: []uint64{1, 1}, Min: 1, Max: 2, Count: 2, Sum: 6},
+		{Values: []float64{3, 4}, Counts: []uint64{1, 1}, Min: 3, Max: 4, Count: 2, Sum: 14},
+		{Values: []float64{5}, Counts: []uint64{1}, Min: 5, Max: 5, Count: 1, Sum: 10},
+	}, parts)
+}
+
 func TestSummaryDataPointSliceAt(t *testing.T) {
 	setupDataPointCache()
 
@@ -581,6 +647,7 @@ func TestGetDataPoints(t *testing.T) {
 			generateTestHistogram("foo"),
 			HistogramDataPointSlice{
 				metadata.InstrumentationLibraryName,
+				false,
 				pdata.HistogramDataPointSlice{},
 			},
 		},
@@ -21,6 +21,9 @@ import (
 	aws "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/metrics"
 )
 
+// maxEMFHistogramValues is the maximum number of entries CloudWatch accepts in the Values array of an EMF metric
+const maxEMFHistogramValues = 100
+
 // GroupedMetric defines set of metrics with same namespace, timestamp and labels
 type GroupedMetric struct {
 	Labels   map[string]string
@@ -49,33 +52,47 @@ func addToGroupedMetric(pmd *pdata.Metric, groupedMetrics map[interface{}]*Group
 	for i := 0; i < dps.Len(); i++ {
 		dp := dps.At(i)
 		labels := dp.Labels
-		metric := &MetricInfo{
-			Value: dp.Value,
-			Unit:  translateUnit(pmd, descriptor),
-		}
+		unit := translateUnit(pmd, descriptor)
 
 		if dp.TimestampMs > 0 {
 			metadata.TimestampMs = dp.TimestampMs
 		}
 
-		// Extra params to use when grouping metrics
-		groupKey := groupedMetricKey(metadata.GroupedMetricMetadata, labels)
-		if _, ok := groupedMetrics[groupKey]; ok {
-			// if metricName already exists in metrics map, print warning log
-			if _, ok := groupedMetrics[groupKey].Metrics[metricName]; ok {
-				logger.Warn(
-					"Duplicate metric found",
-					zap.String("Name", metricName),
-					zap.Any("Labels", labels),
-				)
-			} else {
-				groupedMetrics[groupKey].Metrics[metricName] = metric
+		// Histograms exceeding the EMF limits are split, each part going into its own batch of groups
+		values := []interface{}{dp.Value}
+		if histogram, ok := dp.Value.(*CWMetricHistogram); ok {
+			values = values[:0]
+			for _, part := range histogram.split(maxEMFHistogramValues) {
+				values = append(values, part)
+			}
+		}
+
+		for batchIndex, value := range values {
+			metadata.batchIndex = batchIndex
+			metric := &MetricInfo{
+				Value: value,
+				Unit:  unit,
 			}
-		} else {
-			groupedMetrics[groupKey] = &GroupedMetric{
-				Labels:   labels,
-				Metrics:  map[string]*MetricInfo{(metricName): metric},
-				Metadata: metadata,
+
+			// Extra params to use when grouping metrics
+			groupKey := groupedMetricKey(metadata.GroupedMetricMetadata, labels)
+			if _, ok := groupedMetrics[groupKey]; ok {
+				// if metricName already exists in metrics map, print warning log
+				if _, ok := groupedMetrics[groupKey].Metrics[metricName]; ok {
+					logger.Warn(
+						"Duplicate metric found",
+						zap.String("Name", metricName),
+						zap.Any("Labels", labels),
+					)
+				} else {
+					groupedMetrics[groupKey].Metrics[metricName] = metric
+				}
+			} else {
+				groupedMetrics[groupKey] = &GroupedMetric{
+					Labels:   labels,
+					Metrics:  map[string]*MetricInfo{(metricName): metric},
+					Metadata: metadata,
+				}
 			}
 		}
 	}
@@ -390,6 +390,47 @@ func TestAddToGroupedMetric(t *testing.T) {
 		assert.Equal(t, expectedLogs, logs.AllUntimed())
 	})
 
+	t.Run("Histogram buckets split across batches", func(t *testing.T) {
+		groupedMetrics := make(map[interface{}]*GroupedMetric)
+		md := pdata.NewMetrics()
+		rms := md.ResourceMetrics()
+		metric := rms.AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("foo")
+		metric.SetUnit("s")
+		metric.SetDataType(pdata.MetricDataTypeHistogram)
+		dp := metric.Histogram().DataPoints().AppendEmpty()
+		bounds := make([]float64, 2*maxEMFHistogramValues)
+		counts := make([]uint64, len(bounds)+1)
+		for i := range bounds {
+			bounds[i] = float64(i + 1)
+			counts[i] = 1
+		}
+		counts[len(bounds)] = 1
+		dp.SetExplicitBounds(bounds)
+		dp.SetBucketCounts(counts)
+		dp.SetCount(uint64(len(counts)))
+		dp.SetSum(100)
+
+		bucketsMetadata := metadata
+		bucketsMetadata.emitHistogramBuckets = true
+		addToGroupedMetric(&metric, groupedMetrics, bucketsMetadata, logger, nil)
+		assert.Equal(t, 3, len(groupedMetrics))
+
+		var totalCount uint64
+		var totalSum float64
+		for _, group := range groupedMetrics {
+			assert.Equal(t, 1, len(group.Metrics))
+			histogram := group.Metrics["foo"].Value.(*CWMetricHistogram)
+			assert.LessOrEqual(t, len(histogram.Values), maxEMFHistogramValues)
+			assert.Equal(t, len(histogram.Values), len(histogram.Counts))
+			assert.Equal(t, "Seconds", group.Metrics["foo"].Unit)
+			totalCount += histogram.Count
+			totalSum += histogram.Sum
+		}
+		assert.Equal(t, uint64(len(counts)), totalCount)
+		assert.InDelta(t, 100, totalSum, 1e-9)
+	})
+
 	t.Run("Unhandled metric type", func(t *testing.T) {
 		groupedMetrics := make(map[interface{}]*GroupedMetric)
 		md := pdata.NewMetrics()
@@ -72,11 +72,24 @@ type CWMetricStats struct {
 	Sum   float64
 }
 
+// CWMetricHistogram stores a histogram as the EMF Values and Counts arrays along with its statistics
+type CWMetricHistogram struct {
+	Values []float64
+	Counts []uint64
+	Max    float64
+	Min    float64
+	Count  uint64
+	Sum    float64
+}
+
 type GroupedMetricMetadata struct {
 	Namespace   string
 	TimestampMs int64
 	LogGroup    string
 	LogStream   string
+
+	// batchIndex separates the parts of histograms that had to be split to fit the EMF limits
+	batchIndex int
 }
 
 // CWMetricMetadata represents the metadata associated with a given CloudWatch metric
@@ -84,8 +97,9 @@ type CWMetricMetadata struct {
 	GroupedMetricMetadata
 	InstrumentationLibraryName string
 
-	receiver       string
-	metricDataType pdata.MetricDataType
+	receiver             string
+	metricDataType       pdata.MetricDataType
+	emitHistogramBuckets bool
 }
 
 type metricTranslator struct {
@@ -135,6 +149,7 @@ func (mt metricTranslator) translateOTelToGroupedMetric(rm *pdata.ResourceMetric
 				InstrumentationLibraryName: instrumentationLibName,
 				receiver:                   metricReceiver,
 				metricDataType:             metric.DataType(),
+				emitHistogramBuckets:       config.EmitHistogramBuckets,
 			}
 			addToGroupedMetric(&metric, groupedMetrics, metadata, config.logger, mt.metricDescriptor)
 		}
@@ -0,0 +1,41 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	semconventions "go.opentelemetry.io/collector/translator/conventions"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// makeEvents converts the events of the span which aren't exceptions, the
+// exceptions being recorded as the cause of the (sub)segment.
+func makeEvents(span pdata.Span) []awsxray.SpanEventData {
+	var converted []awsxray.SpanEventData
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		if event.Name() == semconventions.AttributeExceptionEventName {
+			continue
+		}
+		converted = append(converted, awsxray.SpanEventData{
+			Name:       awsxray.String(event.Name()),
+			Timestamp:  aws.Float64(timestampToFloatSeconds(event.Timestamp())),
+			Attributes: makeAttributesMetadata(event.Attributes()),
+		})
+	}
+	return converted
+}
@@ -0,0 +1,71 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	semconventions "go.opentelemetry.io/collector/translator/conventions"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+func TestMakeEvents(t *testing.T) {
+	span := pdata.NewSpan()
+	timestamp := pdata.TimestampFromTime(time.Unix(1611929698, 500000000))
+
+	event := span.Events().AppendEmpty()
+	event.SetName("cache miss")
+	event.SetTimestamp(timestamp)
+	event.Attributes().InsertString("cache.key", "users")
+	span.Events().AppendEmpty().SetName("exception")
+
+	events := makeEvents(span)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, awsxray.SpanEventData{
+		Name:       awsxray.String("cache miss"),
+		Timestamp:  aws.Float64(1611929698.5),
+		Attributes: map[string]interface{}{"cache.key": "users"},
+	}, events[0])
+}
+
+func TestSpanWithEvents(t *testing.T) {
+	span := constructServerSpan(newSegmentID(), "/orders", pdata.StatusCodeUnset, "OK", nil)
+	timeEvents := constructTimedEventsWithSentMessageEvent(span.StartTimestamp())
+	timeEvents.CopyTo(span.Events())
+
+	segment, _ := MakeSegment(span, constructDefaultResource(), nil, false)
+
+	events, ok := segment.Metadata[awsxray.AWSXraySpanEventsMetadataNamespace][awsxray.AWSXraySpanEventsMetadataKey]
+	require.True(t, ok)
+	require.Len(t, events, 1)
+	assert.Equal(t, "SENT", events.([]awsxray.SpanEventData)[0].Attributes[semconventions.AttributeMessageType])
+	// The resource is still stored in the default namespace.
+	assert.NotEmpty(t, segment.Metadata["default"])
+}
+
+func TestSpanWithoutEvents(t *testing.T) {
+	span := constructClientSpan(newSegmentID(), "orders", pdata.StatusCodeUnset, "OK", nil)
+
+	segment, _ := MakeSegment(span, pdata.NewResource(), nil, false)
+
+	assert.Nil(t, segment.Metadata)
+}
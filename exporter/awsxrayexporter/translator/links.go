@@ -0,0 +1,61 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// makeLinks converts the links of the span. Links to traces which X-Ray doesn't
+// accept anymore, see convertToAmazonTraceID, are dropped.
+func makeLinks(span pdata.Span) []awsxray.SpanLinkData {
+	links := span.Links()
+	if links.Len() == 0 {
+		return nil
+	}
+
+	converted := make([]awsxray.SpanLinkData, 0, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		traceID, err := convertToAmazonTraceID(link.TraceID())
+		if err != nil {
+			continue
+		}
+		converted = append(converted, awsxray.SpanLinkData{
+			TraceID:    awsxray.String(traceID),
+			SpanID:     awsxray.String(link.SpanID().HexString()),
+			Attributes: makeAttributesMetadata(link.Attributes()),
+		})
+	}
+	return converted
+}
+
+// makeAttributesMetadata converts the attributes of a link or an event into
+// metadata values.
+func makeAttributesMetadata(attributes pdata.AttributeMap) map[string]interface{} {
+	if attributes.Len() == 0 {
+		return nil
+	}
+	converted := make(map[string]interface{}, attributes.Len())
+	attributes.Range(func(key string, value pdata.AttributeValue) bool {
+		if metaVal := metadataValue(value); metaVal != nil {
+			converted[key] = metaVal
+		}
+		return true
+	})
+	return converted
+}
@@ -0,0 +1,70 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestMakeLinks(t *testing.T) {
+	span := pdata.NewSpan()
+	traceID := newTraceID()
+	spanID := newSegmentID()
+
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(traceID)
+	link.SetSpanID(spanID)
+	link.Attributes().InsertString("messaging.message_id", "a1b2c3")
+	link.Attributes().InsertInt("count", 2)
+
+	// X-Ray doesn't accept trace ids older than 30 days.
+	expired := [16]byte{}
+	span.Links().AppendEmpty().SetTraceID(pdata.NewTraceID(expired))
+
+	links := makeLinks(span)
+
+	require.Len(t, links, 1)
+	expectedTraceID, _ := convertToAmazonTraceID(traceID)
+	assert.Equal(t, expectedTraceID, *links[0].TraceID)
+	assert.Equal(t, spanID.HexString(), *links[0].SpanID)
+	assert.Equal(t, map[string]interface{}{
+		"messaging.message_id": "a1b2c3",
+		"count":                int64(2),
+	}, links[0].Attributes)
+}
+
+func TestMakeLinksNoLinks(t *testing.T) {
+	assert.Nil(t, makeLinks(pdata.NewSpan()))
+}
+
+func TestSpanWithLinks(t *testing.T) {
+	span := constructServerSpan(newSegmentID(), "/orders", pdata.StatusCodeUnset, "OK", nil)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(newTraceID())
+	link.SetSpanID(newSegmentID())
+
+	segment, _ := MakeSegment(span, constructDefaultResource(), nil, false)
+
+	require.Len(t, segment.Links, 1)
+	assert.Nil(t, segment.Links[0].Attributes)
+
+	jsonStr, err := MakeSegmentDocumentString(span, constructDefaultResource(), nil, false)
+	assert.NoError(t, err)
+	assert.Contains(t, jsonStr, `"links":[{"trace_id":"`+*segment.Links[0].TraceID+`","id":"`+*segment.Links[0].SpanID+`"}]`)
+}
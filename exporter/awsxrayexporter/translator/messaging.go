@@ -0,0 +1,139 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	semconventions "go.opentelemetry.io/collector/translator/conventions"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+const (
+	// rpcSystemAWSAPI is the rpc.system of the calls made by the AWS SDKs.
+	rpcSystemAWSAPI = "aws-api"
+
+	awsServiceSQS = "SQS"
+	awsServiceSNS = "SNS"
+)
+
+// awsMessagingServices maps the messaging.system of AWS messaging services,
+// lower cased, to the name of the service in X-Ray.
+var awsMessagingServices = map[string]string{
+	"aws_sqs":   awsServiceSQS,
+	"amazonsqs": awsServiceSQS,
+	"aws.sqs":   awsServiceSQS,
+	"aws_sns":   awsServiceSNS,
+	"amazonsns": awsServiceSNS,
+	"aws.sns":   awsServiceSNS,
+}
+
+// makeMessaging maps the RPC and messaging semantic conventions of a call to a
+// remote system to the attributes of the X-Ray `aws` field, and returns the name
+// and the namespace of the remote subsegment, both empty if the span isn't such
+// a call.
+func makeMessaging(span pdata.Span, attributes map[string]string) (map[string]string, string, string) {
+	if rpcSystem := attributes[semconventions.AttributeRPCSystem]; rpcSystem == rpcSystemAWSAPI {
+		return makeAWSAPI(attributes)
+	}
+
+	system, ok := attributes[semconventions.AttributeMessagingSystem]
+	if !ok {
+		return attributes, "", ""
+	}
+	switch span.Kind() {
+	case pdata.SpanKindPRODUCER, pdata.SpanKindCONSUMER, pdata.SpanKindCLIENT:
+	default:
+		return attributes, "", ""
+	}
+
+	service, ok := awsMessagingServices[strings.ToLower(system)]
+	if !ok {
+		// Other messaging systems, e.g. Kafka, are named after the destination
+		// the message is sent to or received from.
+		name := attributes[semconventions.AttributeMessagingDestination]
+		if name == "" {
+			name = system
+		}
+		return attributes, name, "remote"
+	}
+
+	filtered := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		switch key {
+		case semconventions.AttributeMessagingSystem:
+		case semconventions.AttributeMessagingURL:
+			if service == awsServiceSQS {
+				setIfMissing(filtered, attributes, awsxray.AWSQueueURLAttribute, value)
+			} else {
+				filtered[key] = value
+			}
+		default:
+			filtered[key] = value
+		}
+	}
+	if operation := messagingOperation(service, span.Kind()); operation != "" {
+		setIfMissing(filtered, attributes, awsxray.AWSOperationAttribute, operation)
+	}
+	return filtered, service, "aws"
+}
+
+// makeAWSAPI handles the calls made by the AWS SDKs, the rpc.service and
+// rpc.method being the X-Ray service and operation.
+func makeAWSAPI(attributes map[string]string) (map[string]string, string, string) {
+	var (
+		filtered = make(map[string]string, len(attributes))
+		service  string
+	)
+	for key, value := range attributes {
+		switch key {
+		case semconventions.AttributeRPCSystem:
+		case semconventions.AttributeRPCService:
+			service = value
+		case semconventions.AttributeRPCMethod:
+			setIfMissing(filtered, attributes, awsxray.AWSOperationAttribute, value)
+		default:
+			filtered[key] = value
+		}
+	}
+	if service == "" {
+		return attributes, "", ""
+	}
+	return filtered, service, "aws"
+}
+
+// messagingOperation returns the X-Ray operation of a span sending or receiving
+// messages through an AWS messaging service.
+func messagingOperation(service string, kind pdata.SpanKind) string {
+	switch {
+	case service == awsServiceSQS && kind == pdata.SpanKindPRODUCER:
+		return "SendMessage"
+	case service == awsServiceSQS && kind == pdata.SpanKindCONSUMER:
+		return "ReceiveMessage"
+	case service == awsServiceSNS && kind == pdata.SpanKindPRODUCER:
+		return "Publish"
+	}
+	return ""
+}
+
+// setIfMissing sets the key of filtered unless it is already set in the
+// original attributes, which take precedence over derived values.
+func setIfMissing(filtered, attributes map[string]string, key, value string) {
+	if _, ok := attributes[key]; !ok {
+		filtered[key] = value
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	semconventions "go.opentelemetry.io/collector/translator/conventions"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+func TestMakeMessagingSQSProducer(t *testing.T) {
+	attributes := make(map[string]string)
+	attributes[semconventions.AttributeMessagingSystem] = "AmazonSQS"
+	attributes[semconventions.AttributeMessagingDestination] = "orders"
+	attributes[semconventions.AttributeMessagingURL] = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	span := pdata.NewSpan()
+	span.SetKind(pdata.SpanKindPRODUCER)
+
+	filtered, name, namespace := makeMessaging(span, attributes)
+
+	assert.Equal(t, "SQS", name)
+	assert.Equal(t, "aws", namespace)
+	assert.Equal(t, "https://sqs.us-east-1.amazonaws.com/123456789012/orders", filtered[awsxray.AWSQueueURLAttribute])
+	assert.Equal(t, "SendMessage", filtered[awsxray.AWSOperationAttribute])
+	assert.Equal(t, "orders", filtered[semconventions.AttributeMessagingDestination])
+	assert.NotContains(t, filtered, semconventions.AttributeMessagingSystem)
+	assert.NotContains(t, filtered, semconventions.AttributeMessagingURL)
+}
+
+func TestMakeMessagingSNSKeepsOperation(t *testing.T) {
+	attributes := make(map[string]string)
+	attributes[semconventions.AttributeMessagingSystem] = "aws_sns"
+	attributes[semconventions.AttributeMessagingURL] = "arn:aws:sns:us-east-1:123456789012:topic"
+	attributes[awsxray.AWSOperationAttribute] = "PublishBatch"
+	span := pdata.NewSpan()
+	span.SetKind(pdata.SpanKindPRODUCER)
+
+	filtered, name, namespace := makeMessaging(span, attributes)
+
+	assert.Equal(t, "SNS", name)
+	assert.Equal(t, "aws", namespace)
+	assert.Equal(t, "PublishBatch", filtered[awsxray.AWSOperationAttribute])
+	assert.Equal(t, "arn:aws:sns:us-east-1:123456789012:topic", filtered[semconventions.AttributeMessagingURL])
+	assert.NotContains(t, filtered, awsxray.AWSQueueURLAttribute)
+}
+
+func TestMakeMessagingKafka(t *testing.T) {
+	attributes := make(map[string]string)
+	attributes[semconventions.AttributeMessagingSystem] = "kafka"
+	attributes[semconventions.AttributeMessagingDestination] = "my-topic"
+	span := pdata.NewSpan()
+	span.SetKind(pdata.SpanKindCONSUMER)
+
+	filtered, name, namespace := makeMessaging(span, attributes)
+
+	assert.Equal(t, "my-topic", name)
+	assert.Equal(t, "remote", namespace)
+	assert.Equal(t, attributes, filtered)
+
+	delete(attributes, semconventions.AttributeMessagingDestination)
+	_, name, _ = makeMessaging(span, attributes)
+	assert.Equal(t, "kafka", name)
+}
+
+func TestMakeMessagingNotRemote(t *testing.T) {
+	attributes := make(map[string]string)
+	attributes[semconventions.AttributeMessagingSystem] = "kafka"
+	span := pdata.NewSpan()
+	span.SetKind(pdata.SpanKindINTERNAL)
+
+	filtered, name, namespace := makeMessaging(span, attributes)
+
+	assert.Equal(t, "", name)
+	assert.Equal(t, "", namespace)
+	assert.Equal(t, attributes, filtered)
+}
+
+func TestMakeMessagingAWSAPI(t *testing.T) {
+	attributes := make(map[string]string)
+	attributes[semconventions.AttributeRPCSystem] = "aws-api"
+	attributes[semconventions.AttributeRPCService] = "DynamoDB"
+	attributes[semconventions.AttributeRPCMethod] = "GetItem"
+	attributes[awsxray.AWSTableNameAttribute] = "otel-dev-Testing"
+	span := pdata.NewSpan()
+	span.SetKind(pdata.SpanKindCLIENT)
+
+	filtered, name, namespace := makeMessaging(span, attributes)
+
+	assert.Equal(t, "DynamoDB", name)
+	assert.Equal(t, "aws", namespace)
+	assert.Equal(t, map[string]string{
+		awsxray.AWSOperationAttribute: "GetItem",
+		awsxray.AWSTableNameAttribute: "otel-dev-Testing",
+	}, filtered)
+}
+
+func TestProducerSpanWithSQS(t *testing.T) {
+	attributes := make(map[string]interface{})
+	attributes[semconventions.AttributeMessagingSystem] = "AmazonSQS"
+	attributes[semconventions.AttributeMessagingURL] = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	span := constructClientSpan(newSegmentID(), "orders send", pdata.StatusCodeUnset, "OK", attributes)
+	span.SetKind(pdata.SpanKindPRODUCER)
+
+	segment, _ := MakeSegment(span, constructDefaultResource(), nil, false)
+
+	assert.Equal(t, "SQS", *segment.Name)
+	assert.Equal(t, "aws", *segment.Namespace)
+	assert.Equal(t, "subsegment", *segment.Type)
+	assert.Equal(t, "SendMessage", *segment.AWS.Operation)
+	assert.Equal(t, "https://sqs.us-east-1.amazonaws.com/123456789012/orders", *segment.AWS.QueueURL)
+}
+
+func TestConsumerSegmentWithSQS(t *testing.T) {
+	attributes := make(map[string]interface{})
+	attributes[semconventions.AttributeMessagingSystem] = "AmazonSQS"
+	span := constructClientSpan(pdata.InvalidSpanID(), "orders process", pdata.StatusCodeUnset, "OK", attributes)
+	span.SetKind(pdata.SpanKindCONSUMER)
+
+	segment, _ := MakeSegment(span, constructDefaultResource(), nil, false)
+
+	// The consumer span starts a new trace so it is a segment, which can't have a namespace.
+	assert.Equal(t, "orders process", *segment.Name)
+	assert.Nil(t, segment.Namespace)
+	assert.Nil(t, segment.Type)
+}
@@ -102,10 +102,12 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		httpfiltered, http                     = makeHTTP(span)
 		isError, isFault, causefiltered, cause = makeCause(span, httpfiltered, resource)
 		origin                                 = determineAwsOrigin(resource)
-		awsfiltered, aws                       = makeAws(causefiltered, resource)
+		remotefiltered, remoteName, remoteNS   = makeMessaging(span, causefiltered)
+		awsfiltered, aws                       = makeAws(remotefiltered, resource)
 		service                                = makeService(resource)
 		sqlfiltered, sql                       = makeSQL(awsfiltered)
 		user, annotations, metadata            = makeXRayAttributes(sqlfiltered, resource, storeResource, indexedAttrs, indexAllAttrs)
+		links                                  = makeLinks(span)
 		name                                   string
 		namespace                              string
 	)
@@ -129,6 +131,13 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		}
 	}
 
+	if name == "" && segmentType == "subsegment" {
+		// Calls to AWS services and messaging systems described by the RPC and
+		// messaging conventions are remote subsegments.
+		name = remoteName
+		namespace = remoteNS
+	}
+
 	if name == "" {
 		if dbInstance, ok := attributes.Get(semconventions.AttributeDBName); ok {
 			// For database queries, the segment name convention is <db name>@<db host>
@@ -176,6 +185,15 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		namespace = "remote"
 	}
 
+	if events := makeEvents(span); len(events) > 0 {
+		if metadata == nil {
+			metadata = map[string]map[string]interface{}{}
+		}
+		metadata[awsxray.AWSXraySpanEventsMetadataNamespace] = map[string]interface{}{
+			awsxray.AWSXraySpanEventsMetadataKey: events,
+		}
+	}
+
 	return &awsxray.Segment{
 		ID:          awsxray.String(span.SpanID().HexString()),
 		TraceID:     awsxray.String(traceID),
@@ -195,6 +213,7 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		SQL:         sql,
 		Annotations: annotations,
 		Metadata:    metadata,
+		Links:       links,
 		Type:        awsxray.String(segmentType),
 	}, nil
 }
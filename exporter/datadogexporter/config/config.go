@@ -35,6 +35,9 @@ var (
 const (
 	// DefaultSite is the default site of the Datadog intake to send data to
 	DefaultSite = "datadoghq.com"
+
+	// MaxLogsBatchSize is the maximum number of logs accepted by the Datadog logs intake in a payload
+	MaxLogsBatchSize = 1000
 )
 
 // APIConfig defines the API configuration options
@@ -106,6 +109,18 @@ type TracesConfig struct {
 	IgnoreResources []string `mapstructure:"ignore_resources"`
 }
 
+// LogsConfig defines the logs exporter specific configuration options
+type LogsConfig struct {
+	// TCPAddr.Endpoint is the host of the Datadog logs intake server to send logs to.
+	// It can also be set through the `DD_LOGS_URL` environment variable.
+	// If unset, the value is obtained from the Site.
+	confignet.TCPAddr `mapstructure:",squash"`
+
+	// BatchSize is the maximum number of logs sent in a single payload.
+	// The default and maximum value is 1000.
+	BatchSize int `mapstructure:"batch_size"`
+}
+
 // TagsConfig defines the tag-related configuration
 // It is embedded in the configuration
 type TagsConfig struct {
@@ -166,6 +181,9 @@ type Config struct {
 	// Traces defines the Traces exporter specific configuration
 	Traces TracesConfig `mapstructure:"traces"`
 
+	// Logs defines the Logs exporter specific configuration
+	Logs LogsConfig `mapstructure:"logs"`
+
 	// SendMetadata defines whether to send host metadata
 	// This is undocumented and only used for unit testing.
 	//
@@ -231,6 +249,10 @@ func (c *Config) Sanitize() error {
 		c.Traces.TCPAddr.Endpoint = fmt.Sprintf("https://trace.agent.%s", c.API.Site)
 	}
 
+	if c.Logs.TCPAddr.Endpoint == "" {
+		c.Logs.TCPAddr.Endpoint = fmt.Sprintf("https://http-intake.logs.%s", c.API.Site)
+	}
+
 	return nil
 }
 
@@ -243,5 +265,9 @@ func (c *Config) Validate() error {
 			}
 		}
 	}
+
+	if c.Logs.BatchSize < 0 || c.Logs.BatchSize > MaxLogsBatchSize {
+		return fmt.Errorf("logs.batch_size must be between 0 and %d", MaxLogsBatchSize)
+	}
 	return nil
 }
@@ -111,6 +111,26 @@ func TestDefaultSite(t *testing.T) {
 	assert.Equal(t, cfg.API.Site, DefaultSite)
 }
 
+// TestLogsURLFromSite tests that the logs URL is set from the Site
+// when not set manually.
+func TestLogsURLFromSite(t *testing.T) {
+	cfg := Config{
+		API: APIConfig{Key: "notnull", Site: "datadoghq.eu"},
+	}
+
+	err := cfg.Sanitize()
+	require.NoError(t, err)
+	assert.Equal(t, "https://http-intake.logs.datadoghq.eu", cfg.Logs.Endpoint)
+}
+
+func TestInvalidLogsBatchSize(t *testing.T) {
+	cfg := Config{Logs: LogsConfig{BatchSize: MaxLogsBatchSize + 1}}
+	assert.EqualError(t, cfg.Validate(), "logs.batch_size must be between 0 and 1000")
+
+	cfg.Logs.BatchSize = MaxLogsBatchSize
+	assert.NoError(t, cfg.Validate())
+}
+
 func TestAPIKeyUnset(t *testing.T) {
 	cfg := Config{}
 	err := cfg.Sanitize()
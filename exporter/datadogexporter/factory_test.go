@@ -67,6 +67,13 @@ func TestCreateDefaultConfig(t *testing.T) {
 			IgnoreResources: []string{},
 		},
 
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "$DD_LOGS_URL",
+			},
+			BatchSize: 1000,
+		},
+
 		TagsConfig: ddconfig.TagsConfig{
 			Hostname:   "$DD_HOST",
 			Env:        "$DD_ENV",
@@ -130,6 +137,13 @@ func TestLoadConfig(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.eu",
+			},
+			BatchSize: 1000,
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -169,6 +183,13 @@ func TestLoadConfig(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.com",
+			},
+			BatchSize: 1000,
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -191,6 +212,7 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 	assert.NoError(t, os.Setenv("DD_TAGS", "envexample:tag envexample2:tag"))
 	assert.NoError(t, os.Setenv("DD_URL", "https://api.datadoghq.com"))
 	assert.NoError(t, os.Setenv("DD_APM_URL", "https://trace.agent.datadoghq.com"))
+	assert.NoError(t, os.Setenv("DD_LOGS_URL", "https://http-intake.logs.datadoghq.com"))
 
 	defer func() {
 		assert.NoError(t, os.Unsetenv("DD_API_KEY"))
@@ -202,6 +224,7 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 		assert.NoError(t, os.Unsetenv("DD_TAGS"))
 		assert.NoError(t, os.Unsetenv("DD_URL"))
 		assert.NoError(t, os.Unsetenv("DD_APM_URL"))
+		assert.NoError(t, os.Unsetenv("DD_LOGS_URL"))
 	}()
 
 	factories, err := componenttest.NopFactories()
@@ -251,6 +274,13 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.test",
+			},
+			BatchSize: 1000,
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -294,6 +324,13 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.com",
+			},
+			BatchSize: 1000,
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -364,6 +401,37 @@ func TestCreateAPITracesExporter(t *testing.T) {
 	assert.NotNil(t, exp)
 }
 
+func TestCreateAPILogsExporter(t *testing.T) {
+	server := testutils.DatadogServerMock()
+	defer server.Close()
+
+	logger := zap.NewNop()
+
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	c := (cfg.Exporters[config.NewIDWithName(typeStr, "api")]).(*ddconfig.Config)
+	c.Logs.TCPAddr.Endpoint = server.URL
+	c.SendMetadata = false
+
+	ctx := context.Background()
+	exp, err := factory.CreateLogsExporter(
+		ctx,
+		component.ExporterCreateParams{Logger: logger},
+		cfg.Exporters[config.NewIDWithName(typeStr, "api")],
+	)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, exp)
+}
+
 func TestOnlyMetadata(t *testing.T) {
 	server := testutils.DatadogServerMock()
 	defer server.Close()
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/utils"
+)
+
+const (
+	logsTimeout time.Duration = 10 * time.Second
+
+	// Limits of the Datadog logs intake
+	// https://docs.datadoghq.com/api/latest/logs/#send-logs
+	maxLogsPayloadSize = 5 * 1024 * 1024
+	maxLogSize         = 1024 * 1024
+)
+
+type logsExporter struct {
+	params component.ExporterCreateParams
+	cfg    *config.Config
+	ctx    context.Context
+	client *http.Client
+	url    string
+}
+
+func newLogsExporter(ctx context.Context, params component.ExporterCreateParams, cfg *config.Config) *logsExporter {
+	return &logsExporter{
+		params: params,
+		cfg:    cfg,
+		ctx:    ctx,
+		client: utils.NewHTTPClient(logsTimeout),
+		url:    cfg.Logs.TCPAddr.Endpoint + "/v1/input",
+	}
+}
+
+func (exp *logsExporter) pushLogsData(ctx context.Context, ld pdata.Logs) error {
+	// Start host metadata with resource attributes from
+	// the first payload.
+	if exp.cfg.SendMetadata {
+		once := exp.cfg.OnceMetadata()
+		once.Do(func() {
+			attrs := pdata.NewAttributeMap()
+			if ld.ResourceLogs().Len() > 0 {
+				attrs = ld.ResourceLogs().At(0).Resource().Attributes()
+			}
+			go metadata.Pusher(exp.ctx, exp.params, exp.cfg, attrs)
+		})
+	}
+
+	fallbackHost := metadata.GetHost(exp.params.Logger, exp.cfg)
+	logs := convertToDatadogLogs(ld, fallbackHost, exp.cfg)
+
+	batchSize := exp.cfg.Logs.BatchSize
+	if batchSize == 0 {
+		batchSize = config.MaxLogsBatchSize
+	}
+	payloads, dropped := batchLogs(logs, batchSize, maxLogsPayloadSize, maxLogSize)
+	if len(dropped) > 0 {
+		// These logs can't be sent, they are not part of the errors of the request
+		exp.params.Logger.Warn("Dropped logs that can't be sent", zap.Int("dropped_logs", len(dropped)), zap.Errors("errors", dropped))
+	}
+
+	var errs []error
+	for i, payload := range payloads {
+		err := exp.sendLogs(ctx, payload.data)
+		if err == nil {
+			continue
+		}
+		if consumererror.IsPermanent(err) {
+			exp.params.Logger.Warn("Dropped logs rejected by the intake", zap.Int("dropped_logs", len(payload.indexes)), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		// The payloads sent before were accepted, only the logs of the next ones are sent again
+		return consumererror.NewLogs(err, payloadsLogs(ld, payloads[i:]))
+	}
+	return consumererror.Combine(errs)
+}
+
+// sendLogs sends a JSON array of logs to the logs intake, compressed with gzip
+func (exp *logsExporter) sendLogs(ctx context.Context, payload []byte) error {
+	var body bytes.Buffer
+	writer := gzip.NewWriter(&body)
+	if _, err := writer.Write(payload); err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to compress logs payload: %w", err))
+	}
+	if err := writer.Close(); err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to compress logs payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", exp.url, &body)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	utils.SetDDHeaders(req.Header, exp.params.BuildInfo, exp.cfg.API.Key)
+	utils.SetExtraHeaders(req.Header, utils.JSONHeaders)
+
+	resp, err := exp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		err := fmt.Errorf("request to %s responded with %s", exp.url, resp.Status)
+		// 5xx errors, timeouts and throttling are retriable
+		if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests {
+			return err
+		}
+		return consumererror.Permanent(err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	otelconfig "go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+)
+
+func newTestLogsConfig(endpoint string) *config.Config {
+	return &config.Config{
+		ExporterSettings: otelconfig.NewExporterSettings(otelconfig.NewID(typeStr)),
+		API: config.APIConfig{
+			Key: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		},
+		TagsConfig: config.TagsConfig{
+			Hostname: "test-host",
+			Env:      "test_env",
+		},
+		Logs: config.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: endpoint,
+			},
+			BatchSize: 2,
+		},
+	}
+}
+
+func TestPushLogsData(t *testing.T) {
+	var got [][]datadogLog
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/input", req.URL.Path)
+		assert.Equal(t, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", req.Header.Get("DD-Api-Key"))
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+
+		reader, err := gzip.NewReader(req.Body)
+		require.NoError(t, err)
+		var logs []datadogLog
+		require.NoError(t, json.NewDecoder(reader).Decode(&logs))
+		got = append(got, logs)
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exp := newLogsExporter(context.Background(), params, newTestLogsConfig(server.URL))
+
+	ld := newTestLogs()
+	lrs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	lrs.At(0).CopyTo(lrs.AppendEmpty())
+	lrs.At(0).CopyTo(lrs.AppendEmpty())
+	require.NoError(t, exp.pushLogsData(context.Background(), ld))
+
+	// 3 logs in batches of 2
+	require.Len(t, got, 2)
+	assert.Len(t, got[0], 2)
+	assert.Len(t, got[1], 1)
+	assert.Equal(t, "hello world", got[1][0]["message"])
+	assert.Equal(t, "custom-hostname", got[1][0]["hostname"])
+	assert.Equal(t, "2", got[1][0]["dd.trace_id"])
+}
+
+func TestPushLogsDataErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		wantPermanent bool
+	}{
+		{name: "server error", statusCode: http.StatusServiceUnavailable, wantPermanent: false},
+		{name: "throttled", statusCode: http.StatusTooManyRequests, wantPermanent: false},
+		{name: "bad request", statusCode: http.StatusBadRequest, wantPermanent: true},
+		{name: "forbidden", statusCode: http.StatusForbidden, wantPermanent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			params := component.ExporterCreateParams{Logger: zap.NewNop()}
+			exp := newLogsExporter(context.Background(), params, newTestLogsConfig(server.URL))
+
+			err := exp.pushLogsData(context.Background(), newTestLogs())
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestPushLogsDataRetriesUnsentLogs(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		if requests > 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exp := newLogsExporter(context.Background(), params, newTestLogsConfig(server.URL))
+
+	ld := newTestLogs()
+	lrs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	for _, name := range []string{"second", "too large", "third"} {
+		lr := lrs.AppendEmpty()
+		lr.SetName(name)
+	}
+	lrs.At(2).Body().SetStringVal(strings.Repeat("a", maxLogSize))
+
+	err := exp.pushLogsData(context.Background(), ld)
+	require.Error(t, err)
+	// The log too large is dropped, it doesn't make the error permanent
+	assert.False(t, consumererror.IsPermanent(err))
+	require.IsType(t, consumererror.Logs{}, err)
+	remaining := err.(consumererror.Logs).GetLogs()
+	// Only the logs of the payload that failed are sent again
+	require.Equal(t, 1, remaining.LogRecordCount())
+	assert.Equal(t, "third", remaining.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
+	assert.Equal(t, 2, requests)
+}
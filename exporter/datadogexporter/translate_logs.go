@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/attributes"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil"
+)
+
+// Reserved attributes of the Datadog logs intake
+// https://docs.datadoghq.com/logs/log_collection/?tab=http#reserved-attributes
+const (
+	logMessage   = "message"
+	logSource    = "ddsource"
+	logTags      = "ddtags"
+	logHostname  = "hostname"
+	logService   = "service"
+	logStatus    = "status"
+	logTimestamp = "timestamp"
+	logTraceID   = "dd.trace_id"
+	logSpanID    = "dd.span_id"
+
+	// otelSource is the source of the logs sent by the exporter
+	otelSource = "otel"
+)
+
+// datadogLog is a log entry of the Datadog logs intake, with the reserved
+// attributes and the attributes of the log record at the top level
+type datadogLog map[string]interface{}
+
+// convertToDatadogLogs converts logs to Datadog log entries
+func convertToDatadogLogs(ld pdata.Logs, fallbackHost string, cfg *config.Config) []datadogLog {
+	var logs []datadogLog
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		res := rl.Resource()
+		host, ok := metadata.HostnameFromAttributes(res.Attributes())
+		if !ok {
+			host = fallbackHost
+		}
+		service := cfg.Service
+		if serviceName, ok := res.Attributes().Get(conventions.AttributeServiceName); ok && serviceName.StringVal() != "" {
+			service = serviceName.StringVal()
+		}
+		tags := strings.Join(logTagsFromResource(res, cfg), ",")
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			lrs := ills.At(j).Logs()
+			for k := 0; k < lrs.Len(); k++ {
+				logs = append(logs, logRecordToDatadogLog(lrs.At(k), host, service, tags))
+			}
+		}
+	}
+	return logs
+}
+
+// logTagsFromResource returns the tags of the logs of a resource, from the resource
+// attributes and the configured tags. The configured env is only used when the
+// resource has no deployment environment.
+func logTagsFromResource(res pdata.Resource, cfg *config.Config) []string {
+	tags := attributes.TagsFromAttributes(res.Attributes())
+	if _, ok := res.Attributes().Get(conventions.AttributeDeploymentEnvironment); !ok && cfg.Env != "" && cfg.Env != "none" {
+		tags = append(tags, fmt.Sprintf("env:%s", cfg.Env))
+	}
+
+	configTags := cfg.Tags
+	if len(configTags) == 0 {
+		configTags = strings.Split(cfg.EnvVarTags, " ")
+	}
+	for _, tag := range configTags {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func logRecordToDatadogLog(lr pdata.LogRecord, host, service, tags string) datadogLog {
+	log := make(datadogLog, lr.Attributes().Len()+9)
+	lr.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		log[k] = tracetranslator.AttributeValueToString(v, false)
+		return true
+	})
+
+	log[logMessage] = tracetranslator.AttributeValueToString(lr.Body(), false)
+	if _, ok := log[logSource]; !ok {
+		log[logSource] = otelSource
+	}
+	if tags != "" {
+		log[logTags] = tags
+	}
+	log[logHostname] = host
+	if service != "" {
+		log[logService] = service
+	}
+	if status := logStatusFromSeverity(lr); status != "" {
+		log[logStatus] = status
+	}
+	if lr.Timestamp() != 0 {
+		// The intake expects milliseconds since the epoch
+		log[logTimestamp] = int64(lr.Timestamp()) / 1e6
+	}
+
+	// Trace and span IDs in the format of the Datadog tracers, to correlate logs and traces
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		log[logTraceID] = strconv.FormatUint(decodeAPMTraceID(traceID.Bytes()), 10)
+	}
+	if spanID := lr.SpanID(); !spanID.IsEmpty() {
+		log[logSpanID] = strconv.FormatUint(decodeAPMSpanID(spanID.Bytes()), 10)
+	}
+	return log
+}
+
+// logStatusFromSeverity returns the Datadog status of a log record, from its
+// severity text or else its severity number
+func logStatusFromSeverity(lr pdata.LogRecord) string {
+	if lr.SeverityText() != "" {
+		return lr.SeverityText()
+	}
+
+	switch {
+	case lr.SeverityNumber() == pdata.SeverityNumberUNDEFINED:
+		return ""
+	case lr.SeverityNumber() <= pdata.SeverityNumberDEBUG4:
+		return "debug"
+	case lr.SeverityNumber() <= pdata.SeverityNumberINFO4:
+		return "info"
+	case lr.SeverityNumber() <= pdata.SeverityNumberWARN4:
+		return "warning"
+	case lr.SeverityNumber() <= pdata.SeverityNumberERROR4:
+		return "error"
+	default:
+		return "critical"
+	}
+}
+
+// logsPayload is a JSON array of logs, with the indexes of its logs in the converted logs
+type logsPayload struct {
+	data    []byte
+	indexes []int
+}
+
+// batchLogs encodes the logs into JSON arrays of at most batchSize logs and maxSize bytes.
+// Logs larger than maxLogSize are dropped and returned as errors.
+func batchLogs(logs []datadogLog, batchSize int, maxSize int, maxLogSize int) (payloads []logsPayload, errs []error) {
+	var payload logsPayload
+	flush := func() {
+		if len(payload.indexes) > 0 {
+			payload.data = append(payload.data, ']')
+			payloads = append(payloads, payload)
+		}
+		payload = logsPayload{}
+	}
+
+	for i, log := range logs {
+		encoded, err := json.Marshal(log)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to encode log: %w", err))
+			continue
+		}
+		if len(encoded) > maxLogSize {
+			errs = append(errs, fmt.Errorf("dropped log of %d bytes, larger than the maximum of %d bytes", len(encoded), maxLogSize))
+			continue
+		}
+
+		// The brackets and separating comma of the JSON array
+		count := len(payload.indexes)
+		if count > 0 && (count == batchSize || len(payload.data)+len(encoded)+2 > maxSize) {
+			flush()
+		}
+		if len(payload.indexes) == 0 {
+			payload.data = append(payload.data, '[')
+		} else {
+			payload.data = append(payload.data, ',')
+		}
+		payload.data = append(payload.data, encoded...)
+		payload.indexes = append(payload.indexes, i)
+	}
+	flush()
+	return payloads, errs
+}
+
+// payloadsLogs returns the log records of the payloads, the logs being converted
+// from ld by convertToDatadogLogs
+func payloadsLogs(ld pdata.Logs, payloads []logsPayload) pdata.Logs {
+	records := map[int]bool{}
+	for _, payload := range payloads {
+		for _, i := range payload.indexes {
+			records[i] = true
+		}
+	}
+	return pdatautil.SubLogs(ld, records)
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+)
+
+func newTestLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("datadog.host.name", "custom-hostname")
+	rl.Resource().Attributes().InsertString(conventions.AttributeServiceName, "myservice")
+	rl.Resource().Attributes().InsertString(conventions.AttributeDeploymentEnvironment, "prod")
+
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Body().SetStringVal("hello world")
+	lr.SetTimestamp(pdata.TimestampFromTime(time.Unix(1600000000, 123000000)))
+	lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	lr.SetTraceID(pdata.NewTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}))
+	lr.SetSpanID(pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 3}))
+	lr.Attributes().InsertString("http.method", "GET")
+	lr.Attributes().InsertInt("http.status_code", 200)
+	return ld
+}
+
+func TestConvertToDatadogLogs(t *testing.T) {
+	cfg := &config.Config{
+		TagsConfig: config.TagsConfig{
+			Env:     "test_env",
+			Service: "default-service",
+			Tags:    []string{"key:val"},
+		},
+	}
+
+	logs := convertToDatadogLogs(newTestLogs(), "fallback-host", cfg)
+	require.Len(t, logs, 1)
+	assert.Equal(t, datadogLog{
+		"message":          "hello world",
+		"ddsource":         "otel",
+		"ddtags":           "service:myservice,env:prod,key:val",
+		"hostname":         "custom-hostname",
+		"service":          "myservice",
+		"status":           "warning",
+		"timestamp":        int64(1600000000123),
+		"dd.trace_id":      "2",
+		"dd.span_id":       "3",
+		"http.method":      "GET",
+		"http.status_code": "200",
+	}, logs[0])
+}
+
+func TestConvertToDatadogLogsDefaults(t *testing.T) {
+	cfg := &config.Config{
+		TagsConfig: config.TagsConfig{
+			Env:     "test_env",
+			Service: "default-service",
+		},
+	}
+
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Body().SetStringVal("hello world")
+	lr.SetSeverityText("Info")
+	lr.Attributes().InsertString("ddsource", "nginx")
+
+	logs := convertToDatadogLogs(ld, "fallback-host", cfg)
+	require.Len(t, logs, 1)
+	assert.Equal(t, datadogLog{
+		"message":  "hello world",
+		"ddsource": "nginx",
+		"ddtags":   "env:test_env",
+		"hostname": "fallback-host",
+		"service":  "default-service",
+		"status":   "Info",
+	}, logs[0])
+}
+
+func TestLogStatusFromSeverity(t *testing.T) {
+	tests := []struct {
+		severity pdata.SeverityNumber
+		want     string
+	}{
+		{severity: pdata.SeverityNumberUNDEFINED, want: ""},
+		{severity: pdata.SeverityNumberTRACE, want: "debug"},
+		{severity: pdata.SeverityNumberDEBUG4, want: "debug"},
+		{severity: pdata.SeverityNumberINFO, want: "info"},
+		{severity: pdata.SeverityNumberWARN2, want: "warning"},
+		{severity: pdata.SeverityNumberERROR, want: "error"},
+		{severity: pdata.SeverityNumberFATAL, want: "critical"},
+	}
+	for _, tt := range tests {
+		lr := pdata.NewLogRecord()
+		lr.SetSeverityNumber(tt.severity)
+		assert.Equal(t, tt.want, logStatusFromSeverity(lr), tt.severity.String())
+	}
+}
+
+func TestBatchLogs(t *testing.T) {
+	logs := make([]datadogLog, 5)
+	for i := range logs {
+		logs[i] = datadogLog{"message": "0123456789"}
+	}
+	logSize := len(`{"message":"0123456789"}`)
+
+	// Limited by the number of logs
+	payloads, errs := batchLogs(logs, 2, maxLogsPayloadSize, maxLogSize)
+	assert.Empty(t, errs)
+	require.Len(t, payloads, 3)
+	for i, want := range []int{2, 2, 1} {
+		var batch []datadogLog
+		require.NoError(t, json.Unmarshal(payloads[i].data, &batch))
+		assert.Len(t, batch, want)
+	}
+
+	// Limited by the size of the payload
+	payloads, errs = batchLogs(logs, 1000, 3*logSize+4, maxLogSize)
+	assert.Empty(t, errs)
+	require.Len(t, payloads, 2)
+	assert.Len(t, payloads[0].data, 3*logSize+4)
+	assert.Equal(t, []int{3, 4}, payloads[1].indexes)
+
+	// Logs larger than the maximum log size are dropped
+	logs = append(logs, datadogLog{"message": "01234567890123456789"})
+	payloads, errs = batchLogs(logs, 1000, maxLogsPayloadSize, logSize)
+	assert.Len(t, errs, 1)
+	require.Len(t, payloads, 1)
+	var batch []datadogLog
+	require.NoError(t, json.Unmarshal(payloads[0].data, &batch))
+	assert.Len(t, batch, 5)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, payloads[0].indexes)
+}
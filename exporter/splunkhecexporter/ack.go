@@ -0,0 +1,171 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+// hecRequestChannelHeader is the header of the channel of the HEC indexer acknowledgement.
+const hecRequestChannelHeader = "X-Splunk-Request-Channel"
+
+var errNoAckID = errors.New("no ackId in the HEC response, indexer acknowledgement must be enabled on the HEC token")
+
+// newAckChannel returns a random UUID identifying the exporter to Splunk HEC.
+func newAckChannel() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	// Version 4 and variant bits of a random UUID.
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// readAckID returns the ack ID of the response to events sent to Splunk HEC.
+func readAckID(body io.Reader) (uint64, error) {
+	var resp struct {
+		AckID *uint64 `json:"ackId"`
+	}
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return 0, fmt.Errorf("failed to read the HEC response: %w", err)
+	}
+	if resp.AckID == nil {
+		return 0, errNoAckID
+	}
+	return *resp.AckID, nil
+}
+
+// pendingAck is the ack ID of a request sent with the token, whose events are not known
+// to be indexed yet.
+type pendingAck struct {
+	token string
+	id    uint64
+}
+
+// waitForAcks polls the ack endpoint until Splunk confirms the events of all the acks are indexed,
+// querying the ack IDs of each token at once. If they are not all acknowledged before the configured
+// timeout, it fails with the index of the first ack not acknowledged, so the events from there on
+// are sent again.
+func (c *client) waitForAcks(ctx context.Context, acks []pendingAck) (int, error) {
+	if len(acks) == 0 {
+		return 0, nil
+	}
+
+	acked := make([]bool, len(acks))
+	firstPending := func() int {
+		for i := range acks {
+			if !acked[i] {
+				return i
+			}
+		}
+		return len(acks)
+	}
+
+	timeout := time.NewTimer(c.config.IndexerAck.Timeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(c.config.IndexerAck.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return firstPending(), ctx.Err()
+		case <-timeout.C:
+			i := firstPending()
+			return i, fmt.Errorf("events with ack ID %d were not acknowledged after %s", acks[i].id, c.config.IndexerAck.Timeout)
+		case <-ticker.C:
+		}
+
+		// Indexes of the acks not acknowledged yet, by token.
+		var tokens []string
+		pending := map[string][]int{}
+		for i, ack := range acks {
+			if acked[i] {
+				continue
+			}
+			if _, ok := pending[ack.token]; !ok {
+				tokens = append(tokens, ack.token)
+			}
+			pending[ack.token] = append(pending[ack.token], i)
+		}
+
+		for _, token := range tokens {
+			ackIDs := make([]uint64, len(pending[token]))
+			for j, i := range pending[token] {
+				ackIDs[j] = acks[i].id
+			}
+			status, err := c.queryAcks(ctx, token, ackIDs)
+			if err != nil {
+				// The status is polled again until the timeout.
+				c.logger.Debug("Failed to query the HEC indexer acknowledgement", zap.Uint64s("ack_ids", ackIDs), zap.Error(err))
+				continue
+			}
+			for _, i := range pending[token] {
+				acked[i] = status[strconv.FormatUint(acks[i].id, 10)]
+			}
+		}
+		if firstPending() == len(acks) {
+			return 0, nil
+		}
+	}
+}
+
+// queryAcks returns the indexing status of the events of the ack IDs, keyed by ack ID.
+func (c *client) queryAcks(ctx context.Context, token string, ackIDs []uint64) (map[string]bool, error) {
+	body, err := json.Marshal(map[string][]uint64{"acks": ackIDs})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.ackURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req, token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err = splunk.HandleHTTPCode(resp); err != nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil, err
+	}
+
+	var ackResp struct {
+		Acks map[string]bool `json:"acks"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&ackResp); err != nil {
+		return nil, fmt.Errorf("failed to read the HEC ack response: %w", err)
+	}
+	return ackResp.Acks, nil
+}
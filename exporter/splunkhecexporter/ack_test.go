@@ -0,0 +1,217 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+// ackServer is a fake Splunk HEC with indexer acknowledgement, acknowledging the events
+// after acknowledgeAfter queries of the ack endpoint.
+type ackServer struct {
+	t                *testing.T
+	eventsResponse   string
+	acknowledgeAfter int32
+	queries          int32
+	channels         chan string
+}
+
+func (s *ackServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.channels <- r.Header.Get(hecRequestChannelHeader)
+	switch r.URL.Path {
+	case "/services/collector/ack":
+		var req struct {
+			Acks []uint64 `json:"acks"`
+		}
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(s.t, []uint64{7}, req.Acks)
+		acked := atomic.AddInt32(&s.queries, 1) > s.acknowledgeAfter
+		json.NewEncoder(w).Encode(map[string]map[string]bool{"acks": {"7": acked}})
+	default:
+		ioutil.ReadAll(r.Body)
+		w.Write([]byte(s.eventsResponse))
+	}
+}
+
+func newAckClient(t *testing.T, server *httptest.Server, timeout time.Duration) *client {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.IndexerAck.Enabled = true
+	cfg.IndexerAck.PollInterval = 10 * time.Millisecond
+	cfg.IndexerAck.Timeout = timeout
+
+	u, err := url.Parse(server.URL + "/services/collector")
+	require.NoError(t, err)
+	return &client{
+		config:     cfg,
+		url:        u,
+		ackURL:     getAckURL(u),
+		ackChannel: "test-channel",
+		client:     server.Client(),
+		logger:     zap.NewNop(),
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+	}
+}
+
+func TestIndexerAck(t *testing.T) {
+	tests := []struct {
+		name             string
+		eventsResponse   string
+		acknowledgeAfter int32
+		timeout          time.Duration
+		wantErr          string
+		wantPermanent    bool
+	}{
+		{
+			name:             "acknowledged",
+			eventsResponse:   `{"text":"Success","code":0,"ackId":7}`,
+			acknowledgeAfter: 2,
+			timeout:          10 * time.Second,
+		},
+		{
+			name:             "not acknowledged before the timeout",
+			eventsResponse:   `{"text":"Success","code":0,"ackId":7}`,
+			acknowledgeAfter: 1 << 30,
+			timeout:          100 * time.Millisecond,
+			wantErr:          "events with ack ID 7 were not acknowledged after 100ms",
+		},
+		{
+			name:           "ack disabled on the token",
+			eventsResponse: `{"text":"Success","code":0}`,
+			timeout:        10 * time.Second,
+			wantErr:        errNoAckID.Error(),
+			wantPermanent:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &ackServer{
+				t:                t,
+				eventsResponse:   tt.eventsResponse,
+				acknowledgeAfter: tt.acknowledgeAfter,
+				channels:         make(chan string, 1000),
+			}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			c := newAckClient(t, server, tt.timeout)
+			err := c.pushTraceData(context.Background(), createTraceData(1))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.acknowledgeAfter+1, atomic.LoadInt32(&handler.queries))
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+			}
+
+			close(handler.channels)
+			for channel := range handler.channels {
+				assert.Equal(t, "test-channel", channel)
+			}
+		})
+	}
+}
+
+func TestReadAckID(t *testing.T) {
+	ackID, err := readAckID(strings.NewReader(`{"text":"Success","code":0,"ackId":12}`))
+	require.NoError(t, err)
+	assert.EqualValues(t, 12, ackID)
+
+	_, err = readAckID(strings.NewReader(`{"text":"Success","code":0}`))
+	assert.Equal(t, errNoAckID, err)
+
+	_, err = readAckID(bytes.NewBufferString(`not json`))
+	assert.Error(t, err)
+}
+
+func TestNewAckChannel(t *testing.T) {
+	channel, err := newAckChannel()
+	require.NoError(t, err)
+	assert.Regexp(t, "^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$", channel)
+
+	other, err := newAckChannel()
+	require.NoError(t, err)
+	assert.NotEqual(t, channel, other)
+}
+
+func TestIndexerAckOfSeveralRequests(t *testing.T) {
+	var mu sync.Mutex
+	var events int
+	var queries [][]uint64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/services/collector/ack" {
+			var req struct {
+				Acks []uint64 `json:"acks"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			queries = append(queries, req.Acks)
+			// Only the events of the first request are indexed.
+			json.NewEncoder(w).Encode(map[string]map[string]bool{"acks": {"0": true, "1": false}})
+			return
+		}
+		ioutil.ReadAll(r.Body)
+		fmt.Fprintf(w, `{"text":"Success","code":0,"ackId":%d}`, events)
+		events++
+	}))
+	defer server.Close()
+
+	md := createMetricsData(1)
+	md.ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().AppendEmpty())
+	md.ResourceMetrics().At(1).Resource().Attributes().InsertString(splunk.IndexLabel, "index2")
+
+	c := newAckClient(t, server, 100*time.Millisecond)
+	err := c.pushMetricsData(context.Background(), md)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "events with ack ID 1 were not acknowledged after 100ms")
+	require.IsType(t, consumererror.Metrics{}, err)
+	remaining := err.(consumererror.Metrics).GetMetrics()
+	require.Equal(t, 1, remaining.ResourceMetrics().Len())
+	index, _ := remaining.ResourceMetrics().At(0).Resource().Attributes().Get(splunk.IndexLabel)
+	assert.Equal(t, "index2", index.StringVal())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, events)
+	require.NotEmpty(t, queries)
+	// Both ack IDs are queried at once, then only the one not acknowledged.
+	assert.Equal(t, []uint64{0, 1}, queries[0])
+	for _, query := range queries[1:] {
+		assert.Equal(t, []uint64{1}, query)
+	}
+}
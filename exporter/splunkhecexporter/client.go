@@ -43,6 +43,52 @@ type client struct {
 	zippers sync.Pool
 	wg      sync.WaitGroup
 	headers map[string]string
+	// ackURL and ackChannel are used for the indexer acknowledgement.
+	ackURL     *url.URL
+	ackChannel string
+}
+
+// batchKey identifies the events that can be sent in the same request. An empty token
+// stands for the token of the configuration.
+type batchKey struct {
+	token      string
+	index      string
+	source     string
+	sourceType string
+}
+
+// batchKey returns the key of the events of the resource, the attributes of the record
+// taking precedence over the resource attributes.
+func (c *client) batchKey(res pdata.Resource, attrs ...pdata.AttributeMap) batchKey {
+	key := batchKey{token: c.token(res), index: c.config.Index, source: c.config.Source, sourceType: c.config.SourceType}
+	hecMetadata := c.config.OtelAttrsToHec.withDefaults()
+	for _, attrs := range append([]pdata.AttributeMap{res.Attributes()}, attrs...) {
+		if v, ok := attrs.Get(hecMetadata.Index); ok {
+			key.index = v.StringVal()
+		}
+		if v, ok := attrs.Get(hecMetadata.Source); ok {
+			key.source = v.StringVal()
+		}
+		if v, ok := attrs.Get(hecMetadata.SourceType); ok {
+			key.sourceType = v.StringVal()
+		}
+	}
+	return key
+}
+
+// groupResources groups the indexes of the n resources by batchKey, in the order in which
+// the keys first appear.
+func (c *client) groupResources(n int, resource func(int) pdata.Resource) ([]batchKey, map[batchKey][]int) {
+	var keys []batchKey
+	indexes := map[batchKey][]int{}
+	for i := 0; i < n; i++ {
+		key := c.batchKey(resource(i))
+		if _, ok := indexes[key]; !ok {
+			keys = append(keys, key)
+		}
+		indexes[key] = append(indexes[key], i)
+	}
+	return keys, indexes
 }
 
 // Minimum number of bytes to compress. 1500 is the MTU of an ethernet frame.
@@ -55,38 +101,87 @@ func (c *client) pushMetricsData(
 	c.wg.Add(1)
 	defer c.wg.Done()
 
-	splunkDataPoints, _ := metricDataToSplunk(c.logger, md, c.config)
-	if len(splunkDataPoints) == 0 {
-		return nil
-	}
-
-	body, compressed, err := encodeBodyEvents(&c.zippers, splunkDataPoints, c.config.DisableCompression)
-	if err != nil {
-		return consumererror.Permanent(err)
+	keys, groups := c.splitMetrics(md)
+	// Groups that failed permanently, which aren't sent again.
+	permanentGroups := map[int]bool{}
+	// Remaining metrics from the i-th group on.
+	remaining := func(i int) pdata.Metrics {
+		rest := pdata.NewMetrics()
+		for j := i; j < len(keys); j++ {
+			if !permanentGroups[j] {
+				groups[keys[j]].ResourceMetrics().MoveAndAppendTo(rest.ResourceMetrics())
+			}
+		}
+		return rest
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.url.String(), body)
-	if err != nil {
-		return consumererror.Permanent(err)
+	var acks []pendingAck
+	// Index of the group of each ack.
+	var ackGroups []int
+	var permanentErrors []error
+	var sendErr error
+	var failed int
+	for i, key := range keys {
+		splunkDataPoints, _ := metricDataToSplunk(c.logger, groups[key], c.config)
+		if len(splunkDataPoints) == 0 {
+			continue
+		}
+		ack, err := c.sendSplunkEvents(ctx, key.token, splunkDataPoints)
+		if err != nil {
+			if consumererror.IsPermanent(err) {
+				permanentErrors = append(permanentErrors, err)
+				permanentGroups[i] = true
+				continue
+			}
+			// The groups sent before were accepted, only the next ones are sent again.
+			sendErr, failed = err, i
+			break
+		}
+		if ack != nil {
+			acks = append(acks, *ack)
+			ackGroups = append(ackGroups, i)
+		}
 	}
 
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
+	if i, err := c.waitForAcks(ctx, acks); err != nil {
+		return consumererror.NewMetrics(retryError(err, permanentErrors), remaining(ackGroups[i]))
 	}
-
-	if compressed {
-		req.Header.Set("Content-Encoding", "gzip")
+	if sendErr != nil {
+		return consumererror.NewMetrics(retryError(sendErr, permanentErrors), remaining(failed))
 	}
+	return consumererror.Combine(permanentErrors)
+}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
+// retryError returns the error of the data to send again along with the errors of the data
+// dropped for good. It isn't permanent so that the remaining data is still sent again.
+func retryError(err error, permanentErrors []error) error {
+	if len(permanentErrors) == 0 {
 		return err
 	}
+	return fmt.Errorf("%w; dropped: %v", err, consumererror.Combine(permanentErrors))
+}
 
-	io.Copy(ioutil.Discard, resp.Body)
-	resp.Body.Close()
+// splitMetrics groups the resource metrics by batchKey, in the order in which the keys first appear.
+// The metrics are returned as they are when all the resources have the same key.
+func (c *client) splitMetrics(md pdata.Metrics) ([]batchKey, map[batchKey]pdata.Metrics) {
+	rms := md.ResourceMetrics()
+	keys, indexes := c.groupResources(rms.Len(), func(i int) pdata.Resource { return rms.At(i).Resource() })
+	if len(keys) <= 1 {
+		if len(keys) == 0 {
+			keys = append(keys, batchKey{})
+		}
+		return keys, map[batchKey]pdata.Metrics{keys[0]: md}
+	}
 
-	return splunk.HandleHTTPCode(resp)
+	groups := make(map[batchKey]pdata.Metrics, len(keys))
+	for _, key := range keys {
+		group := pdata.NewMetrics()
+		for _, i := range indexes[key] {
+			rms.At(i).CopyTo(group.ResourceMetrics().AppendEmpty())
+		}
+		groups[key] = group
+	}
+	return keys, groups
 }
 
 func (c *client) pushTraceData(
@@ -96,21 +191,101 @@ func (c *client) pushTraceData(
 	c.wg.Add(1)
 	defer c.wg.Done()
 
-	splunkEvents, _ := traceDataToSplunk(c.logger, td, c.config)
-	if len(splunkEvents) == 0 {
-		return nil
+	keys, groups := c.splitTraces(td)
+	// Groups that failed permanently, which aren't sent again.
+	permanentGroups := map[int]bool{}
+	// Remaining traces from the i-th group on.
+	remaining := func(i int) pdata.Traces {
+		rest := pdata.NewTraces()
+		for j := i; j < len(keys); j++ {
+			if !permanentGroups[j] {
+				groups[keys[j]].ResourceSpans().MoveAndAppendTo(rest.ResourceSpans())
+			}
+		}
+		return rest
 	}
 
-	return c.sendSplunkEvents(ctx, splunkEvents)
+	var acks []pendingAck
+	// Index of the group of each ack.
+	var ackGroups []int
+	var permanentErrors []error
+	var sendErr error
+	var failed int
+	for i, key := range keys {
+		splunkEvents, _ := traceDataToSplunk(c.logger, groups[key], c.config)
+		if len(splunkEvents) == 0 {
+			continue
+		}
+		ack, err := c.sendSplunkEvents(ctx, key.token, splunkEvents)
+		if err != nil {
+			if consumererror.IsPermanent(err) {
+				permanentErrors = append(permanentErrors, err)
+				permanentGroups[i] = true
+				continue
+			}
+			// The groups sent before were accepted, only the next ones are sent again.
+			sendErr, failed = err, i
+			break
+		}
+		if ack != nil {
+			acks = append(acks, *ack)
+			ackGroups = append(ackGroups, i)
+		}
+	}
+
+	if i, err := c.waitForAcks(ctx, acks); err != nil {
+		return consumererror.NewTraces(retryError(err, permanentErrors), remaining(ackGroups[i]))
+	}
+	if sendErr != nil {
+		return consumererror.NewTraces(retryError(sendErr, permanentErrors), remaining(failed))
+	}
+	return consumererror.Combine(permanentErrors)
+}
+
+// splitTraces groups the resource spans by batchKey, in the order in which the keys first appear.
+// The traces are returned as they are when all the resources have the same key.
+func (c *client) splitTraces(td pdata.Traces) ([]batchKey, map[batchKey]pdata.Traces) {
+	rss := td.ResourceSpans()
+	keys, indexes := c.groupResources(rss.Len(), func(i int) pdata.Resource { return rss.At(i).Resource() })
+	if len(keys) <= 1 {
+		if len(keys) == 0 {
+			keys = append(keys, batchKey{})
+		}
+		return keys, map[batchKey]pdata.Traces{keys[0]: td}
+	}
+
+	groups := make(map[batchKey]pdata.Traces, len(keys))
+	for _, key := range keys {
+		group := pdata.NewTraces()
+		for _, i := range indexes[key] {
+			rss.At(i).CopyTo(group.ResourceSpans().AppendEmpty())
+		}
+		groups[key] = group
+	}
+	return keys, groups
 }
 
-func (c *client) sendSplunkEvents(ctx context.Context, splunkEvents []*splunk.Event) error {
+// sendSplunkEvents sends the events in a single request. The returned ack, nil when the
+// indexer acknowledgement is disabled, must be waited for with waitForAcks.
+func (c *client) sendSplunkEvents(ctx context.Context, token string, splunkEvents []*splunk.Event) (*pendingAck, error) {
 	body, compressed, err := encodeBodyEvents(&c.zippers, splunkEvents, c.config.DisableCompression)
 	if err != nil {
-		return consumererror.Permanent(err)
+		return nil, consumererror.Permanent(err)
 	}
 
-	return c.postEvents(ctx, body, compressed)
+	return c.postEvents(ctx, token, body, compressed)
+}
+
+// token returns the token to send the data of the resource with, empty for the token
+// of the configuration.
+func (c *client) token(res pdata.Resource) string {
+	if !c.config.AccessTokenPassthrough {
+		return ""
+	}
+	if token, ok := res.Attributes().Get(splunk.HecTokenLabel); ok {
+		return token.StringVal()
+	}
+	return ""
 }
 
 func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
@@ -124,7 +299,7 @@ func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
 	gzipWriter.Reset(gzipBuffer)
 
 	// Callback when each batch is to be sent.
-	send := func(ctx context.Context, buf *bytes.Buffer) (err error) {
+	send := func(ctx context.Context, token string, buf *bytes.Buffer) (ack *pendingAck, err error) {
 		shouldCompress := buf.Len() >= minCompressionLen && !c.config.DisableCompression
 
 		if shouldCompress {
@@ -132,26 +307,82 @@ func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
 			gzipWriter.Reset(gzipBuffer)
 
 			if _, err = io.Copy(gzipWriter, buf); err != nil {
-				return fmt.Errorf("failed copying buffer to gzip writer: %v", err)
+				return nil, fmt.Errorf("failed copying buffer to gzip writer: %v", err)
 			}
 
 			if err = gzipWriter.Close(); err != nil {
-				return fmt.Errorf("failed flushing compressed data to gzip writer: %v", err)
+				return nil, fmt.Errorf("failed flushing compressed data to gzip writer: %v", err)
 			}
 
-			return c.postEvents(ctx, gzipBuffer, shouldCompress)
+			return c.postEvents(ctx, token, gzipBuffer, shouldCompress)
 		}
 
-		return c.postEvents(ctx, buf, shouldCompress)
+		return c.postEvents(ctx, token, buf, shouldCompress)
+	}
+
+	keys, groups := c.splitLogs(ld)
+	// Groups that failed permanently, which aren't sent again.
+	permanentGroups := map[int]bool{}
+	// Remaining logs from the log record of the i-th group on.
+	remaining := func(i int, from *logIndex) pdata.Logs {
+		group := groups[keys[i]]
+		rest := *subLogs(&group, from)
+		for j := i + 1; j < len(keys); j++ {
+			if !permanentGroups[j] {
+				groups[keys[j]].ResourceLogs().MoveAndAppendTo(rest.ResourceLogs())
+			}
+		}
+		return rest
+	}
+
+	var acks []pendingAck
+	// Group and first log record of the batch of each ack.
+	var ackGroups []int
+	var ackFronts []*logIndex
+	var permanentErrors []error
+	var sendErr error
+	var failed int
+	var failedFront *logIndex
+	for i, key := range keys {
+		err := c.pushLogDataInBatches(ctx, groups[key], func(ctx context.Context, buf *bytes.Buffer, front *logIndex) error {
+			ack, err := send(ctx, key.token, buf)
+			if err != nil {
+				failedFront = front
+				return err
+			}
+			if ack != nil {
+				acks = append(acks, *ack)
+				ackGroups = append(ackGroups, i)
+				ackFronts = append(ackFronts, front)
+			}
+			return nil
+		})
+		if err == nil {
+			continue
+		}
+		if _, ok := err.(consumererror.Logs); ok {
+			// The batches sent before were accepted, only the next ones are sent again.
+			sendErr, failed = err, i
+			break
+		}
+		permanentErrors = append(permanentErrors, err)
+		permanentGroups[i] = true
 	}
 
-	return c.pushLogDataInBatches(ctx, ld, send)
+	if i, err := c.waitForAcks(ctx, acks); err != nil {
+		return consumererror.NewLogs(retryError(err, permanentErrors), remaining(ackGroups[i], ackFronts[i]))
+	}
+	if sendErr != nil {
+		return consumererror.NewLogs(retryError(sendErr, permanentErrors), remaining(failed, failedFront))
+	}
+	return consumererror.Combine(permanentErrors)
 }
 
 // pushLogDataInBatches sends batches of Splunk events in JSON format.
 // The batch content length is restricted to MaxContentLengthLogs.
 // ld log records are parsed to Splunk events.
-func (c *client) pushLogDataInBatches(ctx context.Context, ld pdata.Logs, send func(context.Context, *bytes.Buffer) error) error {
+// send is called with the index of the first log record of each batch.
+func (c *client) pushLogDataInBatches(ctx context.Context, ld pdata.Logs, send func(context.Context, *bytes.Buffer, *logIndex) error) error {
 	// Length of retained bytes in buffer after truncation.
 	var bufLen int
 	// Buffer capacity.
@@ -214,8 +445,8 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld pdata.Logs, send f
 				// Truncating buffer at tracked length below capacity and sending.
 				buf.Truncate(bufLen)
 				if buf.Len() > 0 {
-					if err := send(ctx, buf); err != nil {
-						return consumererror.NewLogs(err, *subLogs(&ld, bufFront))
+					if err := send(ctx, buf, bufFront); err != nil {
+						return consumererror.NewLogs(retryError(err, permanentErrors), *subLogs(&ld, bufFront))
 					}
 				}
 				buf.Reset()
@@ -224,28 +455,98 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld pdata.Logs, send f
 				tmpBuf.WriteTo(buf)
 
 				bufFront, bufLen = nil, buf.Len()
+				if bufLen > 0 {
+					// The event carried over is the first one of the next batch.
+					bufFront = &logIndex{resource: i, library: j, record: k}
+				}
 			}
 		}
 	}
 
 	if buf.Len() > 0 {
-		if err := send(ctx, buf); err != nil {
-			return consumererror.NewLogs(err, *subLogs(&ld, bufFront))
+		if err := send(ctx, buf, bufFront); err != nil {
+			return consumererror.NewLogs(retryError(err, permanentErrors), *subLogs(&ld, bufFront))
 		}
 	}
 
 	return consumererror.Combine(permanentErrors)
 }
 
-func (c *client) postEvents(ctx context.Context, events io.Reader, compressed bool) error {
+// splitLogs groups the log records by batchKey, in the order in which the keys first appear.
+// The logs are returned as they are when all the log records have the same key.
+func (c *client) splitLogs(ld pdata.Logs) ([]batchKey, map[batchKey]pdata.Logs) {
+	var keys []batchKey
+	var recordKeys []batchKey
+	seen := map[batchKey]bool{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		res := rls.At(i).Resource()
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				key := c.batchKey(res, logs.At(k).Attributes())
+				if !seen[key] {
+					seen[key] = true
+					keys = append(keys, key)
+				}
+				recordKeys = append(recordKeys, key)
+			}
+		}
+	}
+
+	if len(keys) <= 1 {
+		if len(keys) == 0 {
+			keys = append(keys, batchKey{})
+		}
+		return keys, map[batchKey]pdata.Logs{keys[0]: ld}
+	}
+
+	groups := make(map[batchKey]pdata.Logs, len(keys))
+	for _, key := range keys {
+		groups[key] = pdata.NewLogs()
+	}
+	n := 0
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		// Destination resource and library of each group, created on their first record.
+		dstRls := map[batchKey]pdata.ResourceLogs{}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			dstIlls := map[batchKey]pdata.InstrumentationLibraryLogs{}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				key := recordKeys[n]
+				n++
+				dstIll, ok := dstIlls[key]
+				if !ok {
+					dstRl, ok := dstRls[key]
+					if !ok {
+						dstRl = groups[key].ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(dstRl.Resource())
+						dstRls[key] = dstRl
+					}
+					dstIll = dstRl.InstrumentationLibraryLogs().AppendEmpty()
+					ill.InstrumentationLibrary().CopyTo(dstIll.InstrumentationLibrary())
+					dstIlls[key] = dstIll
+				}
+				logs.At(k).CopyTo(dstIll.Logs().AppendEmpty())
+			}
+		}
+	}
+	return keys, groups
+}
+
+// postEvents sends the events in a single request. The returned ack, nil when the
+// indexer acknowledgement is disabled, must be waited for with waitForAcks.
+func (c *client) postEvents(ctx context.Context, token string, events io.Reader, compressed bool) (*pendingAck, error) {
 	req, err := http.NewRequestWithContext(ctx, "POST", c.url.String(), events)
 	if err != nil {
-		return consumererror.Permanent(err)
+		return nil, consumererror.Permanent(err)
 	}
 
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
+	c.setHeaders(req, token)
 
 	if compressed {
 		req.Header.Set("Content-Encoding", "gzip")
@@ -253,15 +554,36 @@ func (c *client) postEvents(ctx context.Context, events io.Reader, compressed bo
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	err = splunk.HandleHTTPCode(resp)
+	if err != nil || !c.config.IndexerAck.Enabled {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil, err
+	}
 
-	io.Copy(ioutil.Discard, resp.Body)
+	ackID, err := readAckID(resp.Body)
+	if err != nil {
+		// The events were accepted so sending them again would duplicate them.
+		return nil, consumererror.Permanent(err)
+	}
+	return &pendingAck{token: token, id: ackID}, nil
+}
 
-	return err
+// setHeaders sets the headers of a request to Splunk HEC sent with the token,
+// empty for the token of the configuration.
+func (c *client) setHeaders(req *http.Request, token string) {
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", splunk.HECTokenHeader+" "+token)
+	}
+	if c.config.IndexerAck.Enabled {
+		req.Header.Set(hecRequestChannelHeader, c.ackChannel)
+	}
 }
 
 // subLogs returns a subset of `ld` starting from index `from` to the end.
@@ -23,6 +23,7 @@ import (
 	"math"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
@@ -590,7 +591,7 @@ func TestInvalidJsonClient(t *testing.T) {
 		}},
 		config: &Config{},
 	}
-	err := c.sendSplunkEvents(context.Background(), evs)
+	_, err := c.sendSplunkEvents(context.Background(), "", evs)
 	assert.EqualError(t, err, "Permanent error: json: unsupported value: +Inf")
 }
 
@@ -602,7 +603,7 @@ func TestInvalidURLClient(t *testing.T) {
 		}},
 		config: &Config{},
 	}
-	err := c.sendSplunkEvents(context.Background(), []*splunk.Event{})
+	_, err := c.sendSplunkEvents(context.Background(), "", []*splunk.Event{})
 	assert.EqualError(t, err, "Permanent error: parse \"//in%20va%20lid\": invalid URL escape \"%20\"")
 }
 
@@ -823,6 +824,180 @@ func TestSubLogs(t *testing.T) {
 	assert.Equal(t, "1_1_2", got.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 }
 
+func Test_pushLogData_SplitsByMetadataAndToken(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		auth := r.Header.Get("Authorization")
+		requests[auth] = append(requests[auth], string(body))
+	}))
+	defer server.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Token = "1234"
+	cfg.AccessTokenPassthrough = true
+	u, err := url.Parse(server.URL + "/services/collector")
+	require.NoError(t, err)
+	c := client{
+		config:  cfg,
+		url:     u,
+		client:  server.Client(),
+		logger:  zap.NewNop(),
+		headers: map[string]string{"Authorization": splunk.HECTokenHeader + " " + cfg.Token},
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+	}
+
+	logs := pdata.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	logRecords := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	logRecords.AppendEmpty().Body().SetStringVal("index1")
+	logRecords.At(0).Attributes().InsertString(splunk.IndexLabel, "index1")
+	logRecords.AppendEmpty().Body().SetStringVal("index2")
+	logRecords.At(1).Attributes().InsertString(splunk.IndexLabel, "index2")
+	rl = logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString(splunk.HecTokenLabel, "5678")
+	rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty().Body().SetStringVal("token")
+
+	require.NoError(t, c.pushLogData(context.Background(), logs))
+
+	require.Len(t, requests, 2)
+	require.Len(t, requests["Splunk 1234"], 2)
+	assert.Contains(t, requests["Splunk 1234"][0], `"index":"index1"`)
+	assert.NotContains(t, requests["Splunk 1234"][0], `"index2"`)
+	assert.Contains(t, requests["Splunk 1234"][1], `"index":"index2"`)
+	require.Len(t, requests["Splunk 5678"], 1)
+	assert.Contains(t, requests["Splunk 5678"][0], `"event":"token"`)
+	assert.NotContains(t, requests["Splunk 5678"][0], splunk.HecTokenLabel)
+}
+
+func Test_pushTraceData_RetriesUnsentGroups(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, string(body))
+		if len(requests) > 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.DisableCompression = true
+	u, err := url.Parse(server.URL + "/services/collector")
+	require.NoError(t, err)
+	c := client{
+		config: cfg,
+		url:    u,
+		client: server.Client(),
+		logger: zap.NewNop(),
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+	}
+
+	td := createTraceData(1)
+	createTraceData(1).ResourceSpans().At(0).CopyTo(td.ResourceSpans().AppendEmpty())
+	td.ResourceSpans().At(1).Resource().Attributes().InsertString(splunk.IndexLabel, "index2")
+
+	err = c.pushTraceData(context.Background(), td)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	require.IsType(t, consumererror.Traces{}, err)
+	// Only the group that failed is sent again.
+	remaining := err.(consumererror.Traces).GetTraces()
+	require.Equal(t, 1, remaining.ResourceSpans().Len())
+	index, _ := remaining.ResourceSpans().At(0).Resource().Attributes().Get(splunk.IndexLabel)
+	assert.Equal(t, "index2", index.StringVal())
+	require.Len(t, requests, 2)
+	assert.NotContains(t, requests[0], "index2")
+	assert.Contains(t, requests[1], `"index":"index2"`)
+}
+
+func Test_pushMetricsData_DoesNotRetryPermanentFailures(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, string(body))
+		switch len(requests) {
+		case 2:
+			w.WriteHeader(http.StatusBadRequest)
+		case 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.DisableCompression = true
+	u, err := url.Parse(server.URL + "/services/collector")
+	require.NoError(t, err)
+	c := client{
+		config: cfg,
+		url:    u,
+		client: server.Client(),
+		logger: zap.NewNop(),
+		zippers: sync.Pool{New: func() interface{} {
+			return gzip.NewWriter(nil)
+		}},
+	}
+
+	md := createMetricsData(1)
+	for _, index := range []string{"index2", "index3"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		md.ResourceMetrics().At(0).CopyTo(rm)
+		rm.Resource().Attributes().InsertString(splunk.IndexLabel, index)
+	}
+
+	err = c.pushMetricsData(context.Background(), md)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	// The error of the group dropped for good is kept.
+	assert.Contains(t, err.Error(), "400")
+	require.IsType(t, consumererror.Metrics{}, err)
+	// Only the group that failed temporarily is sent again.
+	remaining := err.(consumererror.Metrics).GetMetrics()
+	require.Equal(t, 1, remaining.ResourceMetrics().Len())
+	index, _ := remaining.ResourceMetrics().At(0).Resource().Attributes().Get(splunk.IndexLabel)
+	assert.Equal(t, "index3", index.StringVal())
+	require.Len(t, requests, 3)
+}
+
+func TestSplitLogsSingleKey(t *testing.T) {
+	c := client{config: NewFactory().CreateDefaultConfig().(*Config)}
+	logs := createLogData(2, 2, 3)
+
+	keys, groups := c.splitLogs(logs)
+	require.Len(t, keys, 1)
+	assert.Equal(t, logs, groups[keys[0]])
+}
+
+func TestSplitMetrics(t *testing.T) {
+	c := client{config: NewFactory().CreateDefaultConfig().(*Config)}
+	md := createMetricsData(1)
+
+	keys, groups := c.splitMetrics(md)
+	require.Len(t, keys, 1)
+	assert.Equal(t, md, groups[keys[0]])
+
+	md.ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().AppendEmpty())
+	md.ResourceMetrics().At(1).Resource().Attributes().InsertString(splunk.SourcetypeLabel, "other")
+	md.ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().AppendEmpty())
+
+	keys, groups = c.splitMetrics(md)
+	require.Len(t, keys, 2)
+	assert.Equal(t, batchKey{sourceType: "other"}, keys[1])
+	assert.Equal(t, 2, groups[keys[0]].ResourceMetrics().Len())
+	assert.Equal(t, 1, groups[keys[1]].ResourceMetrics().Len())
+}
+
 // validateCompressedEqual validates that GZipped `got` contains `expected` string
 func validateCompressedEqual(t *testing.T, expected string, got []byte) {
 	z, err := gzip.NewReader(bytes.NewReader(got))
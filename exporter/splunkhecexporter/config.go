@@ -19,15 +19,21 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
 const (
 	// hecPath is the default HEC path on the Splunk instance.
 	hecPath                   = "services/collector"
+	hecAckPath                = "services/collector/ack"
 	maxContentLengthLogsLimit = 2 * 1024 * 1024
 )
 
@@ -71,6 +77,59 @@ type Config struct {
 
 	// App version is used to track telemetry information for Splunk App's using HEC by App version. Defaults to the current OpenTelemetry Collector Contrib build version.
 	SplunkAppVersion string `mapstructure:"splunk_app_version"`
+
+	// AccessTokenPassthrough sends the data with the token of its "com.splunk.hec.access_token" resource attribute, if set, instead of Token.
+	splunk.AccessTokenPassthroughConfig `mapstructure:",squash"`
+
+	// OtelAttrsToHec defines the attributes the HEC metadata of the events are taken from.
+	OtelAttrsToHec OtelAttrsToHec `mapstructure:"otel_attrs_to_hec_metadata"`
+
+	// IndexerAck configures the use of HEC indexer acknowledgement.
+	IndexerAck IndexerAckConfig `mapstructure:"indexer_ack"`
+}
+
+// OtelAttrsToHec defines the resource or log record attributes the HEC metadata of the events
+// are taken from, overriding the Source, SourceType and Index of the configuration.
+// Events with different metadata are sent in different requests.
+type OtelAttrsToHec struct {
+	// Source is the attribute of the source of the events. Defaults to "service.name".
+	Source string `mapstructure:"source"`
+	// SourceType is the attribute of the source type of the events. Defaults to "com.splunk.sourcetype".
+	SourceType string `mapstructure:"sourcetype"`
+	// Index is the attribute of the index of the events. Defaults to "com.splunk.index".
+	Index string `mapstructure:"index"`
+	// Host is the attribute of the host of the events. Defaults to "host.name".
+	Host string `mapstructure:"host"`
+}
+
+// withDefaults returns the mapping with the default attribute of each unset field.
+func (o OtelAttrsToHec) withDefaults() OtelAttrsToHec {
+	if o.Source == "" {
+		o.Source = conventions.AttributeServiceName
+	}
+	if o.SourceType == "" {
+		o.SourceType = splunk.SourcetypeLabel
+	}
+	if o.Index == "" {
+		o.Index = splunk.IndexLabel
+	}
+	if o.Host == "" {
+		o.Host = conventions.AttributeHostName
+	}
+	return o
+}
+
+// IndexerAckConfig defines the configuration of the HEC indexer acknowledgement.
+type IndexerAckConfig struct {
+	// Enabled makes the exporter wait for Splunk to confirm the events are indexed
+	// before reporting them as sent. Indexer acknowledgement must be enabled on the HEC token.
+	Enabled bool `mapstructure:"enabled"`
+	// Channel identifies the exporter to Splunk HEC. Defaults to a random UUID.
+	Channel string `mapstructure:"channel"`
+	// PollInterval is the interval between requests for the acknowledgement status. Defaults to 1s.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// Timeout is the time to wait for the acknowledgement before sending the events again. Defaults to 30s.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -84,8 +143,9 @@ func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
 	}
 
 	return &exporterOptions{
-		url:   url,
-		token: cfg.Token,
+		url:    url,
+		ackURL: getAckURL(url),
+		token:  cfg.Token,
 	}, nil
 }
 
@@ -102,6 +162,15 @@ func (cfg *Config) validateConfig() error {
 		return fmt.Errorf(`requires "max_content_length_logs" <= %d`, maxContentLengthLogsLimit)
 	}
 
+	if cfg.IndexerAck.Enabled {
+		if cfg.IndexerAck.PollInterval <= 0 {
+			return errors.New(`requires "indexer_ack.poll_interval" > 0`)
+		}
+		if cfg.IndexerAck.Timeout <= 0 {
+			return errors.New(`requires "indexer_ack.timeout" > 0`)
+		}
+	}
+
 	return nil
 }
 
@@ -117,3 +186,16 @@ func (cfg *Config) getURL() (out *url.URL, err error) {
 
 	return
 }
+
+// getAckURL returns the URL of the HEC ack endpoint next to the HEC endpoint of the events URL,
+// keeping any prefix of its path.
+func getAckURL(eventsURL *url.URL) *url.URL {
+	out := *eventsURL
+	prefix := "/"
+	if i := strings.LastIndex(out.Path, hecPath); i >= 0 {
+		prefix = out.Path[:i]
+	}
+	out.Path = path.Join(prefix, hecAckPath)
+	out.RawQuery = ""
+	return &out
+}
@@ -30,6 +30,8 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -86,6 +88,21 @@ func TestLoadConfig(t *testing.T) {
 			Insecure:           true,
 			InsecureSkipVerify: false,
 		},
+		AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
+			AccessTokenPassthrough: true,
+		},
+		OtelAttrsToHec: OtelAttrsToHec{
+			Source:     "mysource",
+			SourceType: "mysourcetype",
+			Index:      "myindex",
+			Host:       "myhost",
+		},
+		IndexerAck: IndexerAckConfig{
+			Enabled:      true,
+			Channel:      "00000000-0000-0000-0000-000000000001",
+			PollInterval: 5 * time.Second,
+			Timeout:      time.Minute,
+		},
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -139,6 +156,11 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 					Host:   "example.com:8000",
 					Path:   "services/collector",
 				},
+				ackURL: &url.URL{
+					Scheme: "https",
+					Host:   "example.com:8000",
+					Path:   "services/collector/ack",
+				},
 			},
 			wantErr: false,
 		},
@@ -177,3 +199,37 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAckURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{endpoint: "https://splunk:8088/services/collector", want: "https://splunk:8088/services/collector/ack"},
+		{endpoint: "https://splunk:8088/services/collector/event", want: "https://splunk:8088/services/collector/ack"},
+		{endpoint: "https://splunk:8088/prefix/services/collector/raw?channel=x", want: "https://splunk:8088/prefix/services/collector/ack"},
+		{endpoint: "https://splunk:8088/custom", want: "https://splunk:8088/services/collector/ack"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			u, err := url.Parse(tt.endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, getAckURL(u).String())
+		})
+	}
+}
+
+func TestValidateIndexerAck(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "https://splunk:8088/services/collector"
+	cfg.Token = "1234"
+	cfg.IndexerAck.Enabled = true
+	require.NoError(t, cfg.validateConfig())
+
+	cfg.IndexerAck.PollInterval = 0
+	assert.EqualError(t, cfg.validateConfig(), `requires "indexer_ack.poll_interval" > 0`)
+
+	cfg.IndexerAck.PollInterval = time.Second
+	cfg.IndexerAck.Timeout = 0
+	assert.EqualError(t, cfg.validateConfig(), `requires "indexer_ack.timeout" > 0`)
+}
@@ -49,8 +49,9 @@ type splunkExporter struct {
 }
 
 type exporterOptions struct {
-	url   *url.URL
-	token string
+	url    *url.URL
+	ackURL *url.URL
+	token  string
 }
 
 // createExporter returns a new Splunk exporter.
@@ -96,8 +97,16 @@ func buildClient(options *exporterOptions, config *Config, logger *zap.Logger) (
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve TLS config for Splunk HEC Exporter: %w", err)
 	}
+	ackChannel := config.IndexerAck.Channel
+	if config.IndexerAck.Enabled && ackChannel == "" {
+		if ackChannel, err = newAckChannel(); err != nil {
+			return nil, fmt.Errorf("could not generate the HEC indexer acknowledgement channel: %w", err)
+		}
+	}
 	return &client{
-		url: options.url,
+		url:        options.url,
+		ackURL:     options.ackURL,
+		ackChannel: ackChannel,
 		client: &http.Client{
 			Timeout: config.Timeout,
 			Transport: &http.Transport{
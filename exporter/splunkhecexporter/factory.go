@@ -29,6 +29,9 @@ const (
 	typeStr            = "splunk_hec"
 	defaultMaxIdleCons = 100
 	defaultHTTPTimeout = 10 * time.Second
+
+	defaultAckPollInterval = time.Second
+	defaultAckTimeout      = 30 * time.Second
 )
 
 // NewFactory creates a factory for Splunk HEC exporter.
@@ -52,6 +55,10 @@ func createDefaultConfig() config.Exporter {
 		DisableCompression:   false,
 		MaxConnections:       defaultMaxIdleCons,
 		MaxContentLengthLogs: maxContentLengthLogsLimit,
+		IndexerAck: IndexerAckConfig{
+			PollInterval: defaultAckPollInterval,
+			Timeout:      defaultAckTimeout,
+		},
 	}
 }
 
@@ -18,7 +18,6 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
-	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
@@ -47,40 +46,32 @@ func mapLogRecordToSplunkEvent(res pdata.Resource, lr pdata.LogRecord, config *C
 	if lr.Name() != "" {
 		fields[splunk.NameLabel] = lr.Name()
 	}
-	res.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+	hecMetadata := config.OtelAttrsToHec.withDefaults()
+	// Log record attributes take precedence over the resource attributes.
+	mapAttribute := func(k string, v pdata.AttributeValue) bool {
 		switch k {
-		case conventions.AttributeHostName:
+		case hecMetadata.Host:
 			host = v.StringVal()
 			fields[k] = v.StringVal()
-		case conventions.AttributeServiceName:
+		case hecMetadata.Source:
 			source = v.StringVal()
 			fields[k] = v.StringVal()
-		case splunk.SourcetypeLabel:
+		case hecMetadata.SourceType:
 			sourcetype = v.StringVal()
-		case splunk.IndexLabel:
+		case hecMetadata.Index:
 			index = v.StringVal()
+		case splunk.HecTokenLabel:
+			// The token is never sent as a field when it is used to send the data.
+			if !config.AccessTokenPassthrough {
+				fields[k] = convertAttributeValue(v, logger)
+			}
 		default:
 			fields[k] = convertAttributeValue(v, logger)
 		}
 		return true
-	})
-	lr.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
-		switch k {
-		case conventions.AttributeHostName:
-			host = v.StringVal()
-			fields[k] = v.StringVal()
-		case conventions.AttributeServiceName:
-			source = v.StringVal()
-			fields[k] = v.StringVal()
-		case splunk.SourcetypeLabel:
-			sourcetype = v.StringVal()
-		case splunk.IndexLabel:
-			index = v.StringVal()
-		default:
-			fields[k] = convertAttributeValue(v, logger)
-		}
-		return true
-	})
+	}
+	res.Attributes().Range(mapAttribute)
+	lr.Attributes().Range(mapAttribute)
 
 	eventValue := convertAttributeValue(lr.Body(), logger)
 	return &splunk.Event{
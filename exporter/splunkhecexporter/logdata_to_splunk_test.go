@@ -326,6 +326,67 @@ func Test_mapLogRecordToSplunkEvent(t *testing.T) {
 				}
 			}(),
 		},
+		{
+			name: "custom hec metadata attributes",
+			logRecordFn: func() pdata.LogRecord {
+				logRecord := pdata.NewLogRecord()
+				logRecord.Body().SetStringVal("mylog")
+				logRecord.Attributes().InsertString("team.index", "team-index")
+				logRecord.Attributes().InsertString(splunk.IndexLabel, "not-the-index")
+				logRecord.SetTimestamp(ts)
+				return logRecord
+			},
+			logResourceFn: func() pdata.Resource {
+				resource := pdata.NewResource()
+				resource.Attributes().InsertString("team.source", "team-source")
+				resource.Attributes().InsertString("team.sourcetype", "team-sourcetype")
+				resource.Attributes().InsertString("k8s.node.name", "mynode")
+				return resource
+			},
+			configDataFn: func() *Config {
+				return &Config{
+					OtelAttrsToHec: OtelAttrsToHec{
+						Source:     "team.source",
+						SourceType: "team.sourcetype",
+						Index:      "team.index",
+						Host:       "k8s.node.name",
+					},
+				}
+			},
+			wantSplunkEvents: func() []*splunk.Event {
+				event := commonLogSplunkEvent("mylog", ts, map[string]interface{}{
+					"team.source": "team-source", "k8s.node.name": "mynode", splunk.IndexLabel: "not-the-index",
+				}, "mynode", "team-source", "team-sourcetype")
+				event.Index = "team-index"
+				return []*splunk.Event{
+					event,
+				}
+			}(),
+		},
+		{
+			name: "token passthrough",
+			logRecordFn: func() pdata.LogRecord {
+				logRecord := pdata.NewLogRecord()
+				logRecord.Body().SetStringVal("mylog")
+				logRecord.SetTimestamp(ts)
+				return logRecord
+			},
+			logResourceFn: func() pdata.Resource {
+				resource := pdata.NewResource()
+				resource.Attributes().InsertString(splunk.HecTokenLabel, "mytoken")
+				return resource
+			},
+			configDataFn: func() *Config {
+				return &Config{
+					AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
+						AccessTokenPassthrough: true,
+					},
+				}
+			},
+			wantSplunkEvents: []*splunk.Event{
+				commonLogSplunkEvent("mylog", ts, map[string]interface{}{}, "unknown", "", ""),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
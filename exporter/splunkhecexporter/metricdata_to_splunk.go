@@ -19,7 +19,6 @@ import (
 	"strconv"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
-	"go.opentelemetry.io/collector/translator/conventions"
 	tracetranslator "go.opentelemetry.io/collector/translator/trace"
 	"go.uber.org/zap"
 
@@ -50,27 +49,27 @@ func metricDataToSplunk(logger *zap.Logger, data pdata.Metrics, config *Config)
 		source := config.Source
 		sourceType := config.SourceType
 		index := config.Index
+		hecMetadata := config.OtelAttrsToHec.withDefaults()
 		commonFields := map[string]interface{}{}
 		resource := rm.Resource()
 		attributes := resource.Attributes()
-		if conventionHost, isSet := attributes.Get(conventions.AttributeHostName); isSet {
+		if conventionHost, isSet := attributes.Get(hecMetadata.Host); isSet {
 			host = conventionHost.StringVal()
 		}
-		if sourceSet, isSet := attributes.Get(conventions.AttributeServiceName); isSet {
+		if sourceSet, isSet := attributes.Get(hecMetadata.Source); isSet {
 			source = sourceSet.StringVal()
 		}
-		if sourcetypeSet, isSet := attributes.Get(splunk.SourcetypeLabel); isSet {
+		if sourcetypeSet, isSet := attributes.Get(hecMetadata.SourceType); isSet {
 			sourceType = sourcetypeSet.StringVal()
 		}
-		if indexSet, isSet := attributes.Get(splunk.IndexLabel); isSet {
+		if indexSet, isSet := attributes.Get(hecMetadata.Index); isSet {
 			index = indexSet.StringVal()
 		}
 		attributes.Range(func(k string, v pdata.AttributeValue) bool {
-			commonFields[k] = tracetranslator.AttributeValueToString(v, false)
-			return true
-		})
-
-		rm.Resource().Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+			// The token is never sent as a field when it is used to send the data.
+			if k == splunk.HecTokenLabel && config.AccessTokenPassthrough {
+				return true
+			}
 			commonFields[k] = tracetranslator.AttributeValueToString(v, false)
 			return true
 		})
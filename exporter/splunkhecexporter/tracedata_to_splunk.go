@@ -16,7 +16,6 @@ package splunkhecexporter
 
 import (
 	"go.opentelemetry.io/collector/consumer/pdata"
-	"go.opentelemetry.io/collector/translator/conventions"
 	tracetranslator "go.opentelemetry.io/collector/translator/trace"
 	"go.uber.org/zap"
 
@@ -69,33 +68,27 @@ func traceDataToSplunk(logger *zap.Logger, data pdata.Traces, config *Config) ([
 		source := config.Source
 		sourceType := config.SourceType
 		index := config.Index
+		hecMetadata := config.OtelAttrsToHec.withDefaults()
 		commonFields := map[string]interface{}{}
 		resource := rs.Resource()
 		attributes := resource.Attributes()
-		if conventionHost, isSet := attributes.Get(conventions.AttributeHostName); isSet {
+		if conventionHost, isSet := attributes.Get(hecMetadata.Host); isSet {
 			host = conventionHost.StringVal()
 		}
-		if sourceSet, isSet := attributes.Get(conventions.AttributeServiceName); isSet {
+		if sourceSet, isSet := attributes.Get(hecMetadata.Source); isSet {
 			source = sourceSet.StringVal()
 		}
-		if sourcetypeSet, isSet := attributes.Get(splunk.SourcetypeLabel); isSet {
+		if sourcetypeSet, isSet := attributes.Get(hecMetadata.SourceType); isSet {
 			sourceType = sourcetypeSet.StringVal()
 		}
-		if indexSet, isSet := attributes.Get(splunk.IndexLabel); isSet {
+		if indexSet, isSet := attributes.Get(hecMetadata.Index); isSet {
 			index = indexSet.StringVal()
 		}
 		attributes.Range(func(k string, v pdata.AttributeValue) bool {
-			commonFields[k] = tracetranslator.AttributeValueToString(v, false)
-			return true
-		})
-
-		if sourceSet, isSet := rs.Resource().Attributes().Get(conventions.AttributeServiceName); isSet {
-			source = sourceSet.StringVal()
-		}
-		if sourcetypeSet, isSet := rs.Resource().Attributes().Get(splunk.SourcetypeLabel); isSet {
-			sourceType = sourcetypeSet.StringVal()
-		}
-		rs.Resource().Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+			// The token is never sent as a field when it is used to send the data.
+			if k == splunk.HecTokenLabel && config.AccessTokenPassthrough {
+				return true
+			}
 			commonFields[k] = tracetranslator.AttributeValueToString(v, false)
 			return true
 		})
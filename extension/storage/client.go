@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+// ErrMultipleStorageExtensions is returned by GetClient when no storage extension
+// is selected and more than one is configured.
+var ErrMultipleStorageExtensions = errors.New("multiple storage extensions found, select one with the storage setting")
+
+// GetClient returns a client of the storage extension with the given ID for the
+// specified component.
+// When storageID is nil the only configured storage extension is used. If no
+// storage extension is configured a nop client is returned.
+func GetClient(
+	ctx context.Context,
+	host component.Host,
+	storageID *config.ComponentID,
+	kind component.Kind,
+	componentID config.ComponentID,
+) (Client, error) {
+	extension, err := getExtension(host, storageID)
+	if err != nil {
+		return nil, err
+	}
+	if extension == nil {
+		return NewNopClient(), nil
+	}
+	return extension.GetClient(ctx, kind, componentID)
+}
+
+func getExtension(host component.Host, storageID *config.ComponentID) (Extension, error) {
+	if storageID != nil {
+		ext, ok := host.GetExtensions()[*storageID]
+		if !ok {
+			return nil, fmt.Errorf("storage extension %q not found", storageID.String())
+		}
+		se, ok := ext.(Extension)
+		if !ok {
+			return nil, fmt.Errorf("extension %q is not a storage extension", storageID.String())
+		}
+		return se, nil
+	}
+
+	var storageExtension Extension
+	for _, ext := range host.GetExtensions() {
+		if se, ok := ext.(Extension); ok {
+			if storageExtension != nil {
+				return nil, ErrMultipleStorageExtensions
+			}
+			storageExtension = se
+		}
+	}
+	return storageExtension, nil
+}
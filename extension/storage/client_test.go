@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+)
+
+type testHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h testHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+// testExtension returns a client remembering which extension created it.
+type testExtension struct {
+	component.Component
+	name string
+}
+
+type testClient struct {
+	Client
+	extension string
+}
+
+func (e *testExtension) GetClient(context.Context, component.Kind, config.ComponentID) (Client, error) {
+	return &testClient{Client: NewNopClient(), extension: e.name}, nil
+}
+
+func newTestHost(names ...string) testHost {
+	h := testHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{},
+	}
+	for _, name := range names {
+		h.extensions[config.NewIDWithName("test_storage", name)] = &testExtension{Component: componenthelper.New(), name: name}
+	}
+	return h
+}
+
+func TestGetClient(t *testing.T) {
+	fast := config.NewIDWithName("test_storage", "fast")
+	missing := config.NewIDWithName("test_storage", "missing")
+	notStorage := config.NewID("not_storage")
+
+	tests := []struct {
+		name      string
+		host      testHost
+		storageID *config.ComponentID
+		want      string
+		wantNop   bool
+		wantErr   string
+	}{
+		{
+			name:    "no extension",
+			host:    newTestHost(),
+			wantNop: true,
+		},
+		{
+			name: "single extension",
+			host: newTestHost("fast"),
+			want: "fast",
+		},
+		{
+			name:    "multiple extensions",
+			host:    newTestHost("fast", "durable"),
+			wantErr: ErrMultipleStorageExtensions.Error(),
+		},
+		{
+			name:      "selected extension",
+			host:      newTestHost("fast", "durable"),
+			storageID: &fast,
+			want:      "fast",
+		},
+		{
+			name:      "selected extension not found",
+			host:      newTestHost("fast"),
+			storageID: &missing,
+			wantErr:   `storage extension "test_storage/missing" not found`,
+		},
+		{
+			name: "selected extension not a storage extension",
+			host: func() testHost {
+				h := newTestHost("fast")
+				h.extensions[notStorage] = componenthelper.New()
+				return h
+			}(),
+			storageID: &notStorage,
+			wantErr:   `extension "not_storage" is not a storage extension`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := GetClient(context.Background(), tt.host, tt.storageID, component.KindReceiver, config.NewID("receiver"))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNop {
+				assert.Equal(t, NewNopClient(), client)
+				return
+			}
+			require.IsType(t, &testClient{}, client)
+			assert.Equal(t, tt.want, client.(*testClient).extension)
+		})
+	}
+}
+
+func TestNopClient(t *testing.T) {
+	ctx := context.Background()
+	client := NewNopClient()
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	get := GetOperation("key")
+	require.NoError(t, client.Batch(ctx, SetOperation("key", []byte("value")), get, DeleteOperation("key")))
+	require.Nil(t, get.Value)
+
+	keys, err := client.List(ctx, "")
+	require.NoError(t, err)
+	require.Nil(t, keys)
+	require.NoError(t, client.Close(ctx))
+}
@@ -15,11 +15,15 @@
 package filestorage
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"go.etcd.io/bbolt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
 )
 
 var defaultBucket = []byte(`default`)
@@ -93,7 +97,69 @@ func (c *fileStorageClient) Delete(_ context.Context, key string) error {
 	return c.db.Update(delete)
 }
 
+// Batch will run the specified operations in order, in a single transaction
+func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	batch := func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if bucket == nil {
+			return errors.New("storage not initialized")
+		}
+
+		var err error
+		for _, op := range ops {
+			switch op.Type {
+			case storage.Get:
+				// The value returned by bbolt is only valid during the transaction
+				op.Value = copyValue(bucket.Get([]byte(op.Key)))
+			case storage.Set:
+				err = bucket.Put([]byte(op.Key), op.Value)
+			case storage.Delete:
+				err = bucket.Delete([]byte(op.Key))
+			default:
+				return fmt.Errorf("unknown operation type %d", op.Type)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return c.db.Update(batch)
+}
+
+// List will return the keys starting with the specified prefix, sorted
+func (c *fileStorageClient) List(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	list := func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if bucket == nil {
+			return errors.New("storage not initialized")
+		}
+		cursor := bucket.Cursor()
+		p := []byte(prefix)
+		for k, _ := cursor.Seek(p); k != nil && bytes.HasPrefix(k, p); k, _ = cursor.Next() {
+			keys = append(keys, string(k))
+		}
+		return nil
+	}
+
+	if err := c.db.View(list); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
 // Close will close the database
-func (c *fileStorageClient) close() error {
+func (c *fileStorageClient) Close(_ context.Context) error {
 	return c.db.Close()
 }
+
+func copyValue(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	result := make([]byte, len(value))
+	copy(result, value)
+	return result
+}
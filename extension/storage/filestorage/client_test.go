@@ -16,6 +16,7 @@ package filestorage
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -24,6 +25,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/bbolt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
 )
 
 func TestClientOperations(t *testing.T) {
@@ -61,6 +64,92 @@ func TestClientOperations(t *testing.T) {
 	require.Nil(t, value)
 }
 
+func TestClientBatchOperations(t *testing.T) {
+	tempDir := newTempDir(t)
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(dbFile, time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "deleted", []byte("old")))
+
+	// Operations are run in order
+	getBefore := storage.GetOperation("key")
+	getAfter := storage.GetOperation("key")
+	getDeleted := storage.GetOperation("deleted")
+	err = client.Batch(ctx,
+		getBefore,
+		storage.SetOperation("key", []byte("value")),
+		getAfter,
+		storage.DeleteOperation("deleted"),
+		getDeleted,
+	)
+	require.NoError(t, err)
+	require.Nil(t, getBefore.Value)
+	require.Equal(t, []byte("value"), getAfter.Value)
+	require.Nil(t, getDeleted.Value)
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	// A failing operation rolls back the whole batch
+	err = client.Batch(ctx,
+		storage.SetOperation("key", []byte("other")),
+		storage.SetOperation("", []byte("empty keys are not allowed")),
+	)
+	require.Error(t, err)
+
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+}
+
+func TestClientList(t *testing.T) {
+	tempDir := newTempDir(t)
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(dbFile, time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = client.Batch(ctx,
+		storage.SetOperation("file.b", []byte("2")),
+		storage.SetOperation("file.a", []byte("1")),
+		storage.SetOperation("files", []byte("3")),
+		storage.SetOperation("queue.1", []byte("4")),
+	)
+	require.NoError(t, err)
+
+	keys, err := client.List(ctx, "file.")
+	require.NoError(t, err)
+	require.Equal(t, []string{"file.a", "file.b"}, keys)
+
+	keys, err = client.List(ctx, "missing")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	keys, err = client.List(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"file.a", "file.b", "files", "queue.1"}, keys)
+}
+
+func TestClientClose(t *testing.T) {
+	tempDir := newTempDir(t)
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(dbFile, time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.Close(ctx))
+	// Closing twice is allowed
+	require.NoError(t, client.Close(ctx))
+
+	require.Error(t, client.Set(ctx, "key", []byte("value")))
+}
+
 func TestNewClientTransactionErrors(t *testing.T) {
 	timeout := 100 * time.Millisecond
 
@@ -106,6 +195,29 @@ func TestNewClientTransactionErrors(t *testing.T) {
 				require.Equal(t, "storage not initialized", err.Error())
 			},
 		},
+		{
+			name: "batch",
+			setup: func(tx *bbolt.Tx) error {
+				return tx.DeleteBucket(defaultBucket)
+			},
+			validate: func(t *testing.T, c *fileStorageClient) {
+				err := c.Batch(context.Background(), storage.SetOperation(testKey, testValue))
+				require.Error(t, err)
+				require.Equal(t, "storage not initialized", err.Error())
+			},
+		},
+		{
+			name: "list",
+			setup: func(tx *bbolt.Tx) error {
+				return tx.DeleteBucket(defaultBucket)
+			},
+			validate: func(t *testing.T, c *fileStorageClient) {
+				keys, err := c.List(context.Background(), testKey)
+				require.Error(t, err)
+				require.Equal(t, "storage not initialized", err.Error())
+				require.Nil(t, keys)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -186,6 +298,25 @@ func BenchmarkClientDelete(b *testing.B) {
 	}
 }
 
+func BenchmarkClientBatch(b *testing.B) {
+	tempDir := newTempDir(b)
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(dbFile, time.Second)
+	require.NoError(b, err)
+
+	ctx := context.Background()
+	testValue := []byte("testValue")
+	ops := make([]storage.Operation, 100)
+	for i := range ops {
+		ops[i] = storage.SetOperation(fmt.Sprintf("testKey%d", i), testValue)
+	}
+
+	for n := 0; n < b.N; n++ {
+		client.Batch(ctx, ops...)
+	}
+}
+
 func newTempDir(tb testing.TB) string {
 	tempDir, err := ioutil.TempDir("", "")
 	require.NoError(tb, err)
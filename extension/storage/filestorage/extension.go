@@ -58,9 +58,10 @@ func (lfs *localFileStorage) Start(context.Context, component.Host) error {
 }
 
 // Shutdown will close any open databases
-func (lfs *localFileStorage) Shutdown(context.Context) error {
+func (lfs *localFileStorage) Shutdown(ctx context.Context) error {
 	for _, client := range lfs.clients {
-		client.close()
+		// Closing a client already closed by its component is a no-op
+		client.Close(ctx)
 	}
 	// TODO clean up data files that did not have a client
 	// and are older than a threshold (possibly configurable)
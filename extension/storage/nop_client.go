@@ -40,6 +40,21 @@ func (c nopClient) Delete(context.Context, string) error {
 	return nil // no problem
 }
 
+// Batch does nothing, sets the result of get operations to nil and returns nil
+func (c nopClient) Batch(_ context.Context, ops ...Operation) error {
+	for _, op := range ops {
+		if op.Type == Get {
+			op.Value = nil
+		}
+	}
+	return nil // no problem
+}
+
+// List does nothing and returns nil, nil
+func (c nopClient) List(context.Context, string) ([]string, error) {
+	return nil, nil // no result, but no problem
+}
+
 // Close does nothing and returns nil
 func (c nopClient) Close(context.Context) error {
 	return nil
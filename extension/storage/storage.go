@@ -50,4 +50,56 @@ type Client interface {
 
 	// Delete will delete data associated with the specified key
 	Delete(context.Context, string) error
+
+	// Batch will run the specified operations in order, in a single transaction
+	// when the storage supports it. The result of a Get operation is stored in
+	// its Value field.
+	Batch(context.Context, ...Operation) error
+
+	// List will return the keys starting with the specified prefix, sorted
+	List(context.Context, string) ([]string, error)
+
+	// Close will release any resources held by the client. The client must
+	// not be used after Close is called.
+	Close(context.Context) error
+}
+
+// OpType is the type of an Operation
+type OpType int
+
+const (
+	// Get retrieves the value of the key
+	Get OpType = iota
+	// Set stores the value of the key
+	Set
+	// Delete deletes the key
+	Delete
+)
+
+// Operation is a single operation of a Batch
+type Operation *operation
+
+type operation struct {
+	// Key is the key the operation applies to
+	Key string
+	// Value is the value to store for a Set operation, and holds the
+	// result of a Get operation after the batch is run
+	Value []byte
+	// Type is the type of the operation
+	Type OpType
+}
+
+// GetOperation returns an Operation retrieving the value of the key
+func GetOperation(key string) Operation {
+	return &operation{Key: key, Type: Get}
+}
+
+// SetOperation returns an Operation storing the value of the key
+func SetOperation(key string, value []byte) Operation {
+	return &operation{Key: key, Value: value, Type: Set}
+}
+
+// DeleteOperation returns an Operation deleting the key
+func DeleteOperation(key string) Operation {
+	return &operation{Key: key, Type: Delete}
 }
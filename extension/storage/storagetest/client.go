@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+var errClientClosed = errors.New("client closed")
+
+// InMemoryClient is a storage.Client keeping its data in memory, for use in tests
+type InMemoryClient struct {
+	mux    sync.Mutex
+	cache  map[string][]byte
+	closed bool
+}
+
+// Ensure this client implements the appropriate interface
+var _ storage.Client = (*InMemoryClient)(nil)
+
+// NewInMemoryClient returns an empty in memory client
+func NewInMemoryClient() *InMemoryClient {
+	return &InMemoryClient{
+		cache: make(map[string][]byte),
+	}
+}
+
+// Get will retrieve data from memory that corresponds to the specified key
+func (c *InMemoryClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	if err := c.Batch(ctx, op); err != nil {
+		return nil, err
+	}
+	return op.Value, nil
+}
+
+// Set will store data in memory
+func (c *InMemoryClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete will delete data associated with the specified key
+func (c *InMemoryClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch will run the specified operations in order
+func (c *InMemoryClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.closed {
+		return errClientClosed
+	}
+
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.cache[op.Key]
+		case storage.Set:
+			c.cache[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.cache, op.Key)
+		default:
+			return errors.New("unknown operation type")
+		}
+	}
+	return nil
+}
+
+// List will return the keys starting with the specified prefix, sorted
+func (c *InMemoryClient) List(_ context.Context, prefix string) ([]string, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.closed {
+		return nil, errClientClosed
+	}
+
+	var keys []string
+	for key := range c.cache {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Close will prevent any further use of the client
+func (c *InMemoryClient) Close(context.Context) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.closed = true
+	return nil
+}
+
+// IsClosed returns whether Close was called on the client
+func (c *InMemoryClient) IsClosed() bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.closed
+}
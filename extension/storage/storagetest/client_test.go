@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+func TestInMemoryClient(t *testing.T) {
+	ctx := context.Background()
+	client := NewInMemoryClient()
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	get := storage.GetOperation("a.1")
+	err = client.Batch(ctx,
+		storage.SetOperation("a.1", []byte("1")),
+		storage.SetOperation("a.2", []byte("2")),
+		storage.SetOperation("b.1", []byte("3")),
+		get,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), get.Value)
+
+	require.NoError(t, client.Delete(ctx, "a.2"))
+	keys, err := client.List(ctx, "a.")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.1"}, keys)
+
+	require.NoError(t, client.Close(ctx))
+	require.True(t, client.IsClosed())
+	require.Error(t, client.Set(ctx, "key", []byte("value")))
+	_, err = client.List(ctx, "")
+	require.Error(t, err)
+}
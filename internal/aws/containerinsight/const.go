@@ -87,6 +87,11 @@ const (
 	FSUtilization = "filesystem_utilization"
 
 	RunningPodCount       = "number_of_running_pods"
+	PendingPodCount       = "number_of_pending_pods"
+	SucceededPodCount     = "number_of_succeeded_pods"
+	FailedPodCount        = "number_of_failed_pods"
+	UnknownPodCount       = "number_of_unknown_pods"
+	NotReadyEndpointCount = "number_of_not_ready_endpoints"
 	RunningContainerCount = "number_of_running_containers"
 	ContainerCount        = "number_of_containers"
 	NodeCount             = "node_count"
@@ -94,6 +99,11 @@ const (
 	ContainerRestartCount = "number_of_container_restarts"
 	RunningTaskCount      = "number_of_running_tasks"
 
+	SpecReplicas              = "spec_replicas"
+	StatusReplicas            = "status_replicas"
+	StatusReplicasAvailable   = "status_replicas_available"
+	StatusReplicasUnavailable = "status_replicas_unavailable"
+
 	DiskIOServiceBytesPrefix = "diskio_io_service_bytes_"
 	DiskIOServicedPrefix     = "diskio_io_serviced_"
 	DiskIOAsync              = "Async"
@@ -103,23 +113,24 @@ const (
 	DiskIOTotal              = "Total"
 
 	//Define the metric types
-	TypeCluster          = "Cluster"
-	TypeClusterService   = "ClusterService"
-	TypeClusterNamespace = "ClusterNamespace"
-	TypeService          = "Service"
-	TypeInstance         = "Instance" //mean EC2 Instance in ECS
-	TypeNode             = "Node"     //mean EC2 Instance in EKS
-	TypeInstanceFS       = "InstanceFS"
-	TypeNodeFS           = "NodeFS"
-	TypeInstanceNet      = "InstanceNet"
-	TypeNodeNet          = "NodeNet"
-	TypeInstanceDiskIO   = "InstanceDiskIO"
-	TypeNodeDiskIO       = "NodeDiskIO"
-	TypePod              = "Pod"
-	TypePodNet           = "PodNet"
-	TypeContainer        = "Container"
-	TypeContainerFS      = "ContainerFS"
-	TypeContainerDiskIO  = "ContainerDiskIO"
+	TypeCluster           = "Cluster"
+	TypeClusterService    = "ClusterService"
+	TypeClusterNamespace  = "ClusterNamespace"
+	TypeClusterDeployment = "ClusterDeployment"
+	TypeService           = "Service"
+	TypeInstance          = "Instance" //mean EC2 Instance in ECS
+	TypeNode              = "Node"     //mean EC2 Instance in EKS
+	TypeInstanceFS        = "InstanceFS"
+	TypeNodeFS            = "NodeFS"
+	TypeInstanceNet       = "InstanceNet"
+	TypeNodeNet           = "NodeNet"
+	TypeInstanceDiskIO    = "InstanceDiskIO"
+	TypeNodeDiskIO        = "NodeDiskIO"
+	TypePod               = "Pod"
+	TypePodNet            = "PodNet"
+	TypeContainer         = "Container"
+	TypeContainerFS       = "ContainerFS"
+	TypeContainerDiskIO   = "ContainerDiskIO"
 
 	//unit
 	UnitBytes       = "Bytes"
@@ -199,8 +210,19 @@ func init() {
 		FSUtilization: UnitPercent,
 
 		//cluster metrics
-		NodeCount:       UnitCount,
-		FailedNodeCount: UnitCount,
+		NodeCount:             UnitCount,
+		FailedNodeCount:       UnitCount,
+		PendingPodCount:       UnitCount,
+		SucceededPodCount:     UnitCount,
+		FailedPodCount:        UnitCount,
+		UnknownPodCount:       UnitCount,
+		NotReadyEndpointCount: UnitCount,
+
+		//deployment metrics
+		SpecReplicas:              UnitCount,
+		StatusReplicas:            UnitCount,
+		StatusReplicasAvailable:   UnitCount,
+		StatusReplicasUnavailable: UnitCount,
 
 		//others
 		RunningPodCount:       UnitCount,
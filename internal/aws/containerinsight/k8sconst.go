@@ -21,14 +21,16 @@ const (
 	CAFile         = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
 
 	//attribute names
-	Kubernetes       = "kubernetes"
-	K8sNamespace     = "Namespace"
-	PodIDKey         = "PodId"
-	PodNameKey       = "PodName"
-	K8sPodNameKey    = "K8sPodName"
-	ContainerNamekey = "ContainerName"
-	ContainerIDkey   = "ContainerId"
-	PodOwnersKey     = "PodOwners"
+	Kubernetes        = "kubernetes"
+	K8sNamespace      = "Namespace"
+	ServiceNameKey    = "Service"
+	DeploymentNameKey = "DeploymentName"
+	PodIDKey          = "PodId"
+	PodNameKey        = "PodName"
+	K8sPodNameKey     = "K8sPodName"
+	ContainerNamekey  = "ContainerName"
+	ContainerIDkey    = "ContainerId"
+	PodOwnersKey      = "PodOwners"
 
 	PodStatus       = "pod_status"
 	ContainerStatus = "container_status"
@@ -105,6 +105,7 @@ func getPrefixByMetricType(mType string) string {
 	service := "service_"
 	cluster := "cluster_"
 	namespace := "namespace_"
+	deployment := "deployment_"
 
 	switch mType {
 	case TypeInstance:
@@ -141,6 +142,8 @@ func getPrefixByMetricType(mType string) string {
 		prefix = service
 	case TypeClusterNamespace:
 		prefix = namespace
+	case TypeClusterDeployment:
+		prefix = deployment
 	default:
 		log.Printf("E! Unexpected MetricType: %s", mType)
 	}
@@ -74,6 +74,7 @@ func TestMetricName(t *testing.T) {
 	assert.Equal(t, "instance_diskio_io_service_bytes_total", MetricName(TypeInstanceDiskIO, "diskio_io_service_bytes_total"))
 	assert.Equal(t, "service_number_of_running_pods", MetricName(TypeService, "number_of_running_pods"))
 	assert.Equal(t, "namespace_number_of_running_pods", MetricName(TypeClusterNamespace, "number_of_running_pods"))
+	assert.Equal(t, "deployment_status_replicas_available", MetricName(TypeClusterDeployment, "status_replicas_available"))
 	assert.Equal(t, "container_diskio_io_service_bytes_total", MetricName(TypeContainerDiskIO, "diskio_io_service_bytes_total"))
 	assert.Equal(t, "unknown_metrics", MetricName("unknown_type", "unknown_metrics"))
 }
@@ -278,6 +279,23 @@ func TestConvertToOTLPMetricsForClusterMetrics(t *testing.T) {
 	md = ConvertToOTLPMetrics(fields, tags, zap.NewNop())
 	checkMetricsAreExpected(t, md, fields, tags, expectedUnits)
 
+	//test cluster deployment metrics
+	fields = map[string]interface{}{
+		"deployment_spec_replicas":               int64(3),
+		"deployment_status_replicas_unavailable": int64(1),
+	}
+	expectedUnits = map[string]string{
+		"deployment_spec_replicas":               UnitCount,
+		"deployment_status_replicas_unavailable": UnitCount,
+	}
+	tags = map[string]string{
+		"ClusterName": "test-cluster",
+		"Type":        TypeClusterDeployment,
+		"Timestamp":   timestamp,
+		"Version":     "0",
+	}
+	md = ConvertToOTLPMetrics(fields, tags, zap.NewNop())
+	checkMetricsAreExpected(t, md, fields, tags, expectedUnits)
 }
 
 func TestConvertToOTLPMetricsForContainerMetrics(t *testing.T) {
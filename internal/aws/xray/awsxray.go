@@ -46,6 +46,14 @@ const (
 	// will be AWSXraySegmentMetadataAttributePrefix + <metadata_key>.
 	AWSXraySegmentMetadataAttributePrefix = "aws.xray.metadata."
 
+	// AWSXraySpanEventsMetadataNamespace is the namespace of the X-Ray metadata
+	// holding, under the AWSXraySpanEventsMetadataKey key, the span events
+	// which aren't recorded as the cause of the (sub)segment.
+	AWSXraySpanEventsMetadataNamespace = "otel"
+	// AWSXraySpanEventsMetadataKey is the key of the span events within the
+	// AWSXraySpanEventsMetadataNamespace metadata namespace.
+	AWSXraySpanEventsMetadataKey = "events"
+
 	// AWSXrayRetriesAttribute is the `retries` field in an X-Ray (sub)segment.
 	AWSXrayRetriesAttribute = "aws.xray.retries"
 
@@ -63,6 +63,7 @@ type Segment struct {
 	Annotations map[string]interface{}            `json:"annotations,omitempty"`
 	Metadata    map[string]map[string]interface{} `json:"metadata,omitempty"`
 	Subsegments []Segment                         `json:"subsegments,omitempty"`
+	Links       []SpanLinkData                    `json:"links,omitempty"`
 
 	// (for both embedded and independent) subsegment-only (optional) fields.
 	// Please refer to https://docs.aws.amazon.com/xray/latest/devguide/xray-api-segmentdocuments.html#api-segmentdocuments-subsegments
@@ -274,6 +275,23 @@ type SQLData struct {
 	Preparation      *string `json:"preparation,omitempty"` // "statement" / "call"
 }
 
+// SpanLinkData provides the shape for unmarshalling the links field, each
+// link referencing a span of the same or of another trace.
+type SpanLinkData struct {
+	TraceID    *string                `json:"trace_id,omitempty"`
+	SpanID     *string                `json:"id,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// SpanEventData provides the shape of a span event stored, along with the
+// other events of the span, as metadata of the (sub)segment. See
+// AWSXraySpanEventsMetadataNamespace.
+type SpanEventData struct {
+	Name       *string                `json:"name,omitempty"`
+	Timestamp  *float64               `json:"timestamp,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
 // ServiceData provides the shape for unmarshalling the service field.
 type ServiceData struct {
 	Version         *string `json:"version,omitempty"`
@@ -591,6 +591,34 @@ func TestTraceBodyUnMarshalling(t *testing.T) {
 				}, actualSeg, testCase+": unmarshalled segment is different from the expected")
 			},
 		},
+		{
+			testCase:   "TestTraceBodyLinksUnmarshalled",
+			samplePath: path.Join("testdata", "minLinks.txt"),
+			verification: func(testCase string, actualSeg Segment, err error) {
+				assert.NoError(t, err, testCase+": JSON Unmarshalling should've succeeded")
+
+				assert.Equal(t, Segment{
+					Name:      String("Links"),
+					ID:        String("5cc4a447f5d4d696"),
+					StartTime: aws.Float64(1595437651.680097),
+					EndTime:   aws.Float64(1595437652.197392),
+					TraceID:   String("1-5f187253-6a106696d56b1f4ef9eba2ed"),
+					Links: []SpanLinkData{
+						{
+							TraceID: String("1-5f187253-d4ebf299219a65bd5c31d6da"),
+							SpanID:  String("defdfd9912dc5a56"),
+							Attributes: map[string]interface{}{
+								"messaging.message_id": "a1b2c3",
+							},
+						},
+						{
+							TraceID: String("1-5f187253-6a106696d56b1f4ef9eba2ed"),
+							SpanID:  String("88ad1df59cd7a7be"),
+						},
+					},
+				}, actualSeg, testCase+": unmarshalled segment is different from the expected")
+			},
+		},
 		{
 			testCase:   "TestTraceBodyCauseIsExceptionIdUnmarshalled",
 			samplePath: path.Join("testdata", "minCauseIsExceptionId.txt"),
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonclient fetches the JSON documents exposed by the HTTP APIs of
+// the monitored services, such as status and management APIs.
+package jsonclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Client fetches JSON documents from the paths of an HTTP API.
+type Client struct {
+	client   *http.Client
+	endpoint string
+	username string
+	password string
+}
+
+// New creates a Client for the API at the given endpoint. The requests are
+// sent with basic auth when username is set.
+func New(client *http.Client, endpoint, username, password string) *Client {
+	return &Client{
+		client:   client,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		username: username,
+		password: password,
+	}
+}
+
+// Get decodes the JSON returned by the API for the path into v.
+func (c *Client) Get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("expected 200 response from %s, got %d", path, resp.StatusCode)
+	}
+
+	// The body is left out of the error as it may be large.
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response body from %s: %w", path, err)
+	}
+	return nil
+}
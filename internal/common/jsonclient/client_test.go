@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/status":
+			username, password, ok := req.BasicAuth()
+			if !ok || username != "user" || password != "pass" {
+				rw.WriteHeader(401)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"status":"green"}`))
+		case "/api/invalid":
+			_, _ = rw.Write([]byte(`Bad status page`))
+		default:
+			rw.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	var status struct {
+		Status string `json:"status"`
+	}
+
+	// The trailing slash of the endpoint is ignored.
+	client := New(server.Client(), server.URL+"/api/", "user", "pass")
+	require.NoError(t, client.Get(context.Background(), "/status", &status))
+	require.Equal(t, "green", status.Status)
+
+	require.EqualError(t, client.Get(context.Background(), "/missing", &status), "expected 200 response from /missing, got 404")
+	require.EqualError(t, client.Get(context.Background(), "/invalid", &status),
+		`failed to parse response body from /invalid: invalid character 'B' looking for beginning of value`)
+
+	client = New(server.Client(), server.URL+"/api", "", "")
+	require.EqualError(t, client.Get(context.Background(), "/status", &status), "expected 200 response from /status, got 401")
+}
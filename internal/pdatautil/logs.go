@@ -0,0 +1,57 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pdatautil holds helpers to manipulate pdata shared by the components.
+package pdatautil
+
+import "go.opentelemetry.io/collector/consumer/pdata"
+
+// SubLogs returns a copy of the log records of ld whose index is in records, with their
+// resource and library. Log records are indexed in the order in which they appear in ld.
+func SubLogs(ld pdata.Logs, records map[int]bool) pdata.Logs {
+	subset := pdata.NewLogs()
+	record := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		// The resource and library are copied with their first record kept.
+		var dstRl pdata.ResourceLogs
+		hasRl := false
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			var dstLogs pdata.LogSlice
+			hasIll := false
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k, record = k+1, record+1 {
+				if !records[record] {
+					continue
+				}
+				if !hasRl {
+					dstRl = subset.ResourceLogs().AppendEmpty()
+					rl.Resource().CopyTo(dstRl.Resource())
+					hasRl = true
+				}
+				if !hasIll {
+					dstIll := dstRl.InstrumentationLibraryLogs().AppendEmpty()
+					ill.InstrumentationLibrary().CopyTo(dstIll.InstrumentationLibrary())
+					dstLogs = dstIll.Logs()
+					hasIll = true
+				}
+				logs.At(k).CopyTo(dstLogs.AppendEmpty())
+			}
+		}
+	}
+	return subset
+}
@@ -0,0 +1,53 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatautil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestSubLogs(t *testing.T) {
+	ld := pdata.NewLogs()
+	for i := 0; i < 2; i++ {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertInt("resource", int64(i))
+		for j := 0; j < 2; j++ {
+			lrs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+			for k := 0; k < 2; k++ {
+				lrs.AppendEmpty().SetName(fmt.Sprintf("%d_%d_%d", i, j, k))
+			}
+		}
+	}
+
+	subset := SubLogs(ld, map[int]bool{3: true, 4: true, 7: true})
+	assert.Equal(t, 3, subset.LogRecordCount())
+	rls := subset.ResourceLogs()
+	require.Equal(t, 2, rls.Len())
+	// Resources and libraries without any record kept are left out.
+	require.Equal(t, 1, rls.At(0).InstrumentationLibraryLogs().Len())
+	assert.Equal(t, "0_1_1", rls.At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
+	resource, _ := rls.At(1).Resource().Attributes().Get("resource")
+	assert.EqualValues(t, 1, resource.IntVal())
+	require.Equal(t, 2, rls.At(1).InstrumentationLibraryLogs().Len())
+	assert.Equal(t, "1_0_0", rls.At(1).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
+	assert.Equal(t, "1_1_1", rls.At(1).InstrumentationLibraryLogs().At(1).Logs().At(0).Name())
+
+	assert.Equal(t, 0, SubLogs(ld, nil).LogRecordCount())
+}
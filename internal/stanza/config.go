@@ -15,6 +15,7 @@
 package stanza
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/open-telemetry/opentelemetry-log-collection/operator"
@@ -27,6 +28,12 @@ type BaseConfig struct {
 	config.ReceiverSettings `mapstructure:",squash"`
 	Operators               OperatorConfigs `mapstructure:"operators"`
 	Converter               ConverterConfig `mapstructure:"converter"`
+	// StorageID is the ID of the storage extension used to persist the state of the
+	// receiver (e.g. file_storage/checkpoints). It is only required when more than
+	// one storage extension is configured.
+	StorageID string `mapstructure:"storage"`
+	// RetryOnFailure configures at-least-once delivery of the log entries.
+	RetryOnFailure RetryOnFailureConfig `mapstructure:"retry_on_failure"`
 }
 
 // OperatorConfigs is an alias that allows for unmarshaling outside of mapstructure
@@ -49,6 +56,43 @@ type ConverterConfig struct {
 	WorkerCount int `mapstructure:"worker_count"`
 }
 
+const (
+	DefaultRetryInitialInterval = 5 * time.Second
+	DefaultRetryMaxInterval     = 30 * time.Second
+)
+
+// RetryOnFailureConfig controls what happens when the next consumer fails to
+// consume the converted logs.
+// When enabled, failed calls are retried with an exponential backoff. While
+// retrying, no new entries are converted, so that the operators are blocked
+// instead of reading further, and the checkpoints of the operators (e.g. file
+// offsets) are only committed once all the entries read before them have
+// been consumed.
+type RetryOnFailureConfig struct {
+	// Enabled enables retrying and checkpointing on successful consumption.
+	Enabled bool `mapstructure:"enabled"`
+	// InitialInterval is the time to wait after the first failure before retrying.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// MaxInterval is the upper bound on the backoff interval.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+	// MaxElapsedTime is the maximum amount of time spent trying to consume
+	// a batch of logs, after which the batch is dropped. Zero means that
+	// the receiver retries until it is stopped.
+	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+}
+
+// storageID parses the configured storage extension ID, returning nil if none is set.
+func (cfg BaseConfig) storageID() (*config.ComponentID, error) {
+	if cfg.StorageID == "" {
+		return nil, nil
+	}
+	id, err := config.IDFromString(cfg.StorageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage extension %q: %w", cfg.StorageID, err)
+	}
+	return &id, nil
+}
+
 // InputConfig is an alias that allows unmarshaling outside of mapstructure
 // This is meant to be used only for the input operator
 type InputConfig map[string]interface{}
@@ -15,13 +15,14 @@
 package stanza
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
+	"hash/fnv"
 	"math"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -56,7 +57,7 @@ const (
 //  └─┼─┼─► workerLoop()                                      │
 //    └─┤ │   consumes sent log entries from workerChan,      │
 //      │ │   translates received entries to pdata.LogRecords,│
-//      └─┤   marshalls them to JSON and send them onto       │
+//      └─┤   hashes their Resource and sends them onto       │
 //        │   batchChan                                       │
 //        └─────────────────────────┬─────────────────────────┘
 //                                  │
@@ -64,7 +65,7 @@ const (
 //      ┌─────────────────────────────────────────────────────┐
 //      │ batchLoop()                                         │
 //      │   consumes from batchChan, aggregates log records   │
-//      │   by marshaled Resource and based on flush interval │
+//      │   by Resource hash and based on flush interval      │
 //      │   and maxFlushCount decides whether to send the     │
 //      │   aggregated buffer to flushChan                    │
 //      └───────────────────────────┬─────────────────────────┘
@@ -103,8 +104,9 @@ type Converter struct {
 	// flushChan is an internal channel used for transporting batched pdata.Logs.
 	flushChan chan pdata.Logs
 
-	// data holds currently converted and aggregated log entries, grouped by Resource.
-	data map[string]pdata.Logs
+	// data holds currently converted and aggregated log entries, grouped by
+	// the hash of their Resource.
+	data map[uint64]pdata.Logs
 	// logRecordCount holds the number of translated and accumulated log Records
 	// and is compared against maxFlushCount to make a decision whether to flush.
 	logRecordCount uint
@@ -155,7 +157,7 @@ func NewConverter(opts ...ConverterOption) *Converter {
 		workerChan:    make(chan *entry.Entry),
 		workerCount:   int(math.Max(1, float64(runtime.NumCPU()/4))),
 		batchChan:     make(chan *workerItem),
-		data:          make(map[string]pdata.Logs),
+		data:          make(map[uint64]pdata.Logs),
 		pLogsChan:     make(chan pdata.Logs),
 		stopChan:      make(chan struct{}),
 		logger:        zap.NewNop(),
@@ -200,9 +202,9 @@ func (c *Converter) OutChannel() <-chan pdata.Logs {
 }
 
 type workerItem struct {
-	Resource       map[string]string
-	LogRecord      pdata.LogRecord
-	ResourceString string
+	Resource   map[string]string
+	LogRecord  pdata.LogRecord
+	ResourceID uint64
 }
 
 // workerLoop is responsible for obtaining log entries from Batch() calls,
@@ -211,11 +213,6 @@ type workerItem struct {
 func (c *Converter) workerLoop() {
 	defer c.wg.Done()
 
-	var (
-		buff    = bytes.Buffer{}
-		encoder = json.NewEncoder(&buff)
-	)
-
 	for {
 
 		select {
@@ -227,21 +224,13 @@ func (c *Converter) workerLoop() {
 				return
 			}
 
-			buff.Reset()
 			lr := convert(e)
 
-			if err := encoder.Encode(e.Resource); err != nil {
-				c.logger.Debug("Failed marshaling entry.Resource to JSON",
-					zap.Any("resource", e.Resource),
-				)
-				continue
-			}
-
 			select {
 			case c.batchChan <- &workerItem{
-				Resource:       e.Resource,
-				ResourceString: buff.String(),
-				LogRecord:      lr,
+				Resource:   e.Resource,
+				ResourceID: hashResource(e.Resource),
+				LogRecord:  lr,
 			}:
 			case <-c.stopChan:
 			}
@@ -265,7 +254,7 @@ func (c *Converter) batchLoop() {
 				return
 			}
 
-			pLogs, ok := c.data[wi.ResourceString]
+			pLogs, ok := c.data[wi.ResourceID]
 			if ok {
 				pLogs.ResourceLogs().
 					At(0).InstrumentationLibraryLogs().
@@ -288,7 +277,7 @@ func (c *Converter) batchLoop() {
 				ills.At(0).Logs().Append(wi.LogRecord)
 			}
 
-			c.data[wi.ResourceString] = pLogs
+			c.data[wi.ResourceID] = pLogs
 			c.logRecordCount++
 
 			if c.logRecordCount >= c.maxFlushCount {
@@ -527,6 +516,60 @@ func toAttributeArray(obsArr []interface{}) pdata.AttributeValue {
 	return arrVal
 }
 
+var (
+	// emptyResourceID is the hash of entries without a Resource.
+	emptyResourceID uint64
+
+	resourceHasherPool = sync.Pool{
+		New: func() interface{} {
+			return &resourceHasher{
+				hash: fnv.New64a(),
+				keys: make([]string, 0, 16),
+				buf:  make([]byte, 0, 1024),
+			}
+		},
+	}
+)
+
+// resourceHasher holds the state reused across hashResource calls.
+type resourceHasher struct {
+	hash hash.Hash64
+	keys []string
+	buf  []byte
+}
+
+// hashResource computes the key used to group entries coming from the same
+// Resource. Keys are sorted so that the hash doesn't depend on the map
+// iteration order.
+func hashResource(resource map[string]string) uint64 {
+	if len(resource) == 0 {
+		return emptyResourceID
+	}
+
+	h := resourceHasherPool.Get().(*resourceHasher)
+	defer resourceHasherPool.Put(h)
+
+	h.keys = h.keys[:0]
+	for k := range resource {
+		h.keys = append(h.keys, k)
+	}
+	sort.Strings(h.keys)
+
+	// Every key and value is followed by a separator so that
+	// e.g. {"ab": "c"} and {"a": "bc"} don't hash to the same value.
+	h.buf = h.buf[:0]
+	for _, k := range h.keys {
+		h.buf = append(h.buf, k...)
+		h.buf = append(h.buf, 0xff)
+		h.buf = append(h.buf, resource[k]...)
+		h.buf = append(h.buf, 0xff)
+	}
+
+	h.hash.Reset()
+	h.hash.Write(h.buf)
+	return h.hash.Sum64()
+}
+
 func convertSeverity(s entry.Severity) (string, pdata.SeverityNumber) {
 	switch {
 
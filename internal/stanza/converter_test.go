@@ -15,7 +15,9 @@
 package stanza
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"sync"
@@ -182,6 +184,83 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+func TestConvertResource(t *testing.T) {
+	ent := entry.New()
+	ent.Resource = map[string]string{
+		"host.name":    "host-1",
+		"service.name": "my-service",
+		"empty":        "",
+	}
+
+	pLogs := Convert(ent)
+	require.Equal(t, 1, pLogs.ResourceLogs().Len())
+
+	m := pdata.NewAttributeMap()
+	m.InitFromMap(map[string]pdata.AttributeValue{
+		"host.name":    pdata.NewAttributeValueString("host-1"),
+		"service.name": pdata.NewAttributeValueString("my-service"),
+		"empty":        pdata.NewAttributeValueString(""),
+	})
+	assert.EqualValues(t, m.Sort(), pLogs.ResourceLogs().At(0).Resource().Attributes().Sort())
+}
+
+func TestHashResource(t *testing.T) {
+	testcases := []struct {
+		name string
+		r1   map[string]string
+		r2   map[string]string
+		same bool
+	}{
+		{
+			name: "empty",
+			r1:   map[string]string{},
+			r2:   nil,
+			same: true,
+		},
+		{
+			name: "same",
+			r1:   map[string]string{"a": "1", "b": "2", "c": "3"},
+			r2:   map[string]string{"c": "3", "b": "2", "a": "1"},
+			same: true,
+		},
+		{
+			name: "different value",
+			r1:   map[string]string{"a": "1", "b": "2"},
+			r2:   map[string]string{"a": "1", "b": "3"},
+		},
+		{
+			name: "different key",
+			r1:   map[string]string{"a": "1", "b": "2"},
+			r2:   map[string]string{"a": "1", "c": "2"},
+		},
+		{
+			name: "extra key",
+			r1:   map[string]string{"a": "1"},
+			r2:   map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name: "key and value boundary",
+			r1:   map[string]string{"ab": "c"},
+			r2:   map[string]string{"a": "bc"},
+		},
+		{
+			name: "empty value",
+			r1:   map[string]string{"a": ""},
+			r2:   map[string]string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.same {
+				assert.Equal(t, hashResource(tc.r1), hashResource(tc.r2))
+			} else {
+				assert.NotEqual(t, hashResource(tc.r1), hashResource(tc.r2))
+			}
+		})
+	}
+}
+
 func TestAllConvertedEntriesAreSentAndReceived(t *testing.T) {
 	t.Parallel()
 
@@ -663,6 +742,35 @@ func TestConvertTrace(t *testing.T) {
 	require.Equal(t, uint32(0x01), record.Flags())
 }
 
+// BenchmarkResourceKey compares the hash used to group entries by Resource
+// with the JSON serialization it replaced.
+func BenchmarkResourceKey(b *testing.B) {
+	resource := map[string]string{}
+	for i := 0; i < 20; i++ {
+		resource[fmt.Sprintf("resource.key.%d", i)] = fmt.Sprintf("resource-value-%d", i)
+	}
+
+	b.Run("json", func(b *testing.B) {
+		var (
+			buff    = bytes.Buffer{}
+			encoder = json.NewEncoder(&buff)
+		)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buff.Reset()
+			require.NoError(b, encoder.Encode(resource))
+			_ = buff.String()
+		}
+	})
+
+	b.Run("hash", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			hashResource(resource)
+		}
+	})
+}
+
 func BenchmarkConverter(b *testing.B) {
 	const (
 		entryCount = 1_000_000
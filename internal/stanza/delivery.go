@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stanza
+
+import (
+	"context"
+	"sync"
+)
+
+// inflightTracker keeps count of the log entries that have been emitted by
+// the operators but not yet consumed downstream. It is used to hold back the
+// checkpoints of the operators until everything they read before taking them
+// has been delivered.
+type inflightTracker struct {
+	mu    sync.Mutex
+	count int
+	// drained is closed whenever count drops to zero and replaced as soon
+	// as new entries are added.
+	drained chan struct{}
+}
+
+func newInflightTracker() *inflightTracker {
+	drained := make(chan struct{})
+	close(drained)
+	return &inflightTracker{drained: drained}
+}
+
+// add records n more entries in flight.
+func (t *inflightTracker) add(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.count == 0 && n > 0 {
+		t.drained = make(chan struct{})
+	}
+	t.count += n
+}
+
+// done records n entries as no longer in flight, either consumed or dropped.
+func (t *inflightTracker) done(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.count == 0 {
+		return
+	}
+	t.count -= n
+	if t.count <= 0 {
+		t.count = 0
+		close(t.drained)
+	}
+}
+
+// wait blocks until no entry is in flight or the context is done.
+func (t *inflightTracker) wait(ctx context.Context) error {
+	t.mu.Lock()
+	drained := t.drained
+	t.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stanza
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInflightTracker(t *testing.T) {
+	tracker := newInflightTracker()
+	require.NoError(t, tracker.wait(context.Background()))
+
+	tracker.add(2)
+	tracker.done(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, tracker.wait(ctx))
+
+	waitErr := make(chan error)
+	go func() {
+		waitErr <- tracker.wait(context.Background())
+	}()
+	tracker.done(1)
+	require.NoError(t, <-waitErr)
+
+	// More entries done than added must not block or panic.
+	tracker.done(1)
+	require.NoError(t, tracker.wait(context.Background()))
+}
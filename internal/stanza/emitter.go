@@ -28,6 +28,8 @@ type LogEmitter struct {
 	helper.OutputOperator
 	logChan  chan *entry.Entry
 	stopOnce sync.Once
+	// inflight, if set, tracks the entries emitted until they are consumed.
+	inflight *inflightTracker
 }
 
 // NewLogEmitter creates a new receiver output
@@ -46,9 +48,16 @@ func NewLogEmitter(logger *zap.SugaredLogger) *LogEmitter {
 
 // Process will emit an entry to the output channel
 func (e *LogEmitter) Process(ctx context.Context, ent *entry.Entry) error {
+	if e.inflight != nil {
+		e.inflight.add(1)
+	}
+
 	select {
 	case e.logChan <- ent:
 	case <-ctx.Done():
+		if e.inflight != nil {
+			e.inflight.done(1)
+		}
 	}
 	return nil
 }
@@ -60,6 +60,11 @@ func createLogsReceiver(logReceiverType LogReceiverType) receiverhelper.CreateLo
 			return nil, err
 		}
 
+		storageID, err := baseCfg.storageID()
+		if err != nil {
+			return nil, err
+		}
+
 		pipeline := append([]operator.Config{*inputCfg}, operatorCfgs...)
 
 		emitter := NewLogEmitter(params.Logger.Sugar())
@@ -85,13 +90,29 @@ func createLogsReceiver(logReceiverType LogReceiverType) receiverhelper.CreateLo
 		}
 		converter := NewConverter(opts...)
 
+		retry := baseCfg.RetryOnFailure
+		if retry.InitialInterval <= 0 {
+			retry.InitialInterval = DefaultRetryInitialInterval
+		}
+		if retry.MaxInterval <= 0 {
+			retry.MaxInterval = DefaultRetryMaxInterval
+		}
+		var inflight *inflightTracker
+		if retry.Enabled {
+			inflight = newInflightTracker()
+			emitter.inflight = inflight
+		}
+
 		return &receiver{
 			id:        cfg.ID(),
+			storageID: storageID,
 			agent:     logAgent,
 			emitter:   emitter,
 			consumer:  nextConsumer,
 			logger:    params.Logger,
 			converter: converter,
+			retry:     retry,
+			inflight:  inflight,
 		}, nil
 	}
 }
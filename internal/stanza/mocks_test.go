@@ -17,6 +17,8 @@ package stanza
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,6 +30,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
 )
 
 // This file implements some useful testing components
@@ -110,6 +114,35 @@ func (m *mockLogsRejecter) Rejected() int {
 	return int(ret)
 }
 
+// mockLogsFlaky rejects the first failures calls and accepts the next ones
+type mockLogsFlaky struct {
+	failures int32
+	calls    int32
+	accepted int32
+}
+
+func (m *mockLogsFlaky) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (m *mockLogsFlaky) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if atomic.AddInt32(&m.calls, 1) <= m.failures {
+		return fmt.Errorf("not now")
+	}
+	atomic.AddInt32(&m.accepted, 1)
+	return nil
+}
+
+func (m *mockLogsFlaky) Calls() int {
+	ret := atomic.LoadInt32(&m.calls)
+	return int(ret)
+}
+
+func (m *mockLogsFlaky) Accepted() int {
+	ret := atomic.LoadInt32(&m.accepted)
+	return int(ret)
+}
+
 const testType = "test"
 
 type TestConfig struct {
@@ -191,3 +224,36 @@ func (p *mockClient) Delete(_ context.Context, key string) error {
 	delete(p.cache, key)
 	return nil
 }
+
+func (p *mockClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	p.cacheMux.Lock()
+	defer p.cacheMux.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = p.cache[op.Key]
+		case storage.Set:
+			p.cache[op.Key] = op.Value
+		case storage.Delete:
+			delete(p.cache, op.Key)
+		}
+	}
+	return nil
+}
+
+func (p *mockClient) List(_ context.Context, prefix string) ([]string, error) {
+	p.cacheMux.Lock()
+	defer p.cacheMux.Unlock()
+	var keys []string
+	for key := range p.cache {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (p *mockClient) Close(context.Context) error {
+	return nil
+}
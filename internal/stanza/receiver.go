@@ -18,18 +18,22 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-log-collection/agent"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
 )
 
 type receiver struct {
-	id config.ComponentID
+	id        config.ComponentID
+	storageID *config.ComponentID
 	sync.Mutex
 	wg     sync.WaitGroup
 	cancel context.CancelFunc
@@ -40,6 +44,9 @@ type receiver struct {
 	storageClient storage.Client
 	converter     *Converter
 	logger        *zap.Logger
+
+	retry    RetryOnFailureConfig
+	inflight *inflightTracker
 }
 
 // Ensure this receiver adheres to required interface
@@ -128,11 +135,59 @@ func (r *receiver) consumerLoop(ctx context.Context) {
 				r.logger.Debug("Converter channel got closed")
 				continue
 			}
-			if cErr := r.consumer.ConsumeLogs(ctx, pLogs); cErr != nil {
-				r.logger.Error("ConsumeLogs() failed", zap.Error(cErr))
-			}
+			r.consume(ctx, pLogs)
+		}
+	}
+}
+
+// consume calls the consumer to consume the logs. When retry on failure is
+// enabled, failures are retried with an exponential backoff until the logs are
+// consumed, the error is permanent, the maximum elapsed time is exceeded or the
+// receiver is stopped. Retrying blocks the converter, which in turn applies
+// backpressure to the operators.
+func (r *receiver) consume(ctx context.Context, pLogs pdata.Logs) {
+	start := time.Now()
+	interval := r.retry.InitialInterval
+	for {
+		cErr := r.consumer.ConsumeLogs(ctx, pLogs)
+		if cErr == nil {
+			break
+		}
+		if !r.retry.Enabled || consumererror.IsPermanent(cErr) {
+			r.logger.Error("ConsumeLogs() failed", zap.Error(cErr))
+			break
+		}
+		if r.retry.MaxElapsedTime > 0 && time.Since(start)+interval > r.retry.MaxElapsedTime {
+			r.logger.Error("ConsumeLogs() failed, dropping logs after retrying",
+				zap.Error(cErr),
+				zap.Int("dropped_items", pLogs.LogRecordCount()),
+			)
+			break
+		}
+
+		r.logger.Warn("ConsumeLogs() failed, will retry",
+			zap.Error(cErr),
+			zap.Duration("interval", interval),
+		)
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			// The logs are neither consumed nor dropped, so leave them in
+			// flight to keep pending checkpoints from being committed.
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > r.retry.MaxInterval {
+			interval = r.retry.MaxInterval
 		}
 	}
+
+	if r.inflight != nil {
+		r.inflight.done(pLogs.LogRecordCount())
+	}
 }
 
 // Shutdown is invoked during service shutdown
@@ -145,5 +200,11 @@ func (r *receiver) Shutdown(ctx context.Context) error {
 	r.converter.Stop()
 	r.cancel()
 	r.wg.Wait()
+
+	if r.storageClient != nil {
+		if closeErr := r.storageClient.Close(ctx); closeErr != nil && err == nil {
+			err = fmt.Errorf("close storage client: %s", closeErr)
+		}
+	}
 	return err
 }
@@ -110,6 +110,99 @@ func TestHandleConsumeError(t *testing.T) {
 	logsReceiver.Shutdown(context.Background())
 }
 
+func TestRetryConsumeError(t *testing.T) {
+	params := component.ReceiverCreateParams{
+		Logger: zaptest.NewLogger(t),
+	}
+	mockConsumer := mockLogsFlaky{failures: 2}
+	factory := NewFactory(TestReceiverType{})
+
+	cfg := factory.CreateDefaultConfig().(*TestConfig)
+	cfg.RetryOnFailure = RetryOnFailureConfig{
+		Enabled:         true,
+		InitialInterval: 10 * time.Millisecond,
+		MaxInterval:     20 * time.Millisecond,
+	}
+	logsReceiver, err := factory.CreateLogsReceiver(context.Background(), params, cfg, &mockConsumer)
+	require.NoError(t, err, "receiver should successfully build")
+
+	err = logsReceiver.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "receiver start failed")
+	defer logsReceiver.Shutdown(context.Background())
+
+	stanzaReceiver := logsReceiver.(*receiver)
+	require.NoError(t, stanzaReceiver.emitter.Process(context.Background(), entry.New()))
+
+	// The checkpoint is only committed once the entry has been consumed.
+	persister := stanzaReceiver.getPersister()
+	require.NoError(t, persister.Set(context.Background(), "key", []byte("value")))
+	require.Equal(t, 1, mockConsumer.Accepted())
+	require.Equal(t, 3, mockConsumer.Calls())
+}
+
+func TestRetryConsumeErrorMaxElapsedTime(t *testing.T) {
+	params := component.ReceiverCreateParams{
+		Logger: zaptest.NewLogger(t),
+	}
+	mockConsumer := mockLogsRejecter{}
+	factory := NewFactory(TestReceiverType{})
+
+	cfg := factory.CreateDefaultConfig().(*TestConfig)
+	cfg.RetryOnFailure = RetryOnFailureConfig{
+		Enabled:         true,
+		InitialInterval: 10 * time.Millisecond,
+		MaxInterval:     10 * time.Millisecond,
+		MaxElapsedTime:  50 * time.Millisecond,
+	}
+	logsReceiver, err := factory.CreateLogsReceiver(context.Background(), params, cfg, &mockConsumer)
+	require.NoError(t, err, "receiver should successfully build")
+
+	err = logsReceiver.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "receiver start failed")
+	defer logsReceiver.Shutdown(context.Background())
+
+	stanzaReceiver := logsReceiver.(*receiver)
+	require.NoError(t, stanzaReceiver.emitter.Process(context.Background(), entry.New()))
+
+	// Dropped logs no longer hold back the checkpoint.
+	persister := stanzaReceiver.getPersister()
+	require.NoError(t, persister.Set(context.Background(), "key", []byte("value")))
+	require.Greater(t, mockConsumer.Rejected(), 1)
+}
+
+func TestRetryCheckpointNotCommittedOnShutdown(t *testing.T) {
+	params := component.ReceiverCreateParams{
+		Logger: zaptest.NewLogger(t),
+	}
+	mockConsumer := mockLogsRejecter{}
+	factory := NewFactory(TestReceiverType{})
+
+	cfg := factory.CreateDefaultConfig().(*TestConfig)
+	cfg.RetryOnFailure = RetryOnFailureConfig{
+		Enabled:         true,
+		InitialInterval: 10 * time.Millisecond,
+	}
+	logsReceiver, err := factory.CreateLogsReceiver(context.Background(), params, cfg, &mockConsumer)
+	require.NoError(t, err, "receiver should successfully build")
+
+	err = logsReceiver.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "receiver start failed")
+
+	stanzaReceiver := logsReceiver.(*receiver)
+	require.NoError(t, stanzaReceiver.emitter.Process(context.Background(), entry.New()))
+	require.Eventually(t,
+		func() bool {
+			return mockConsumer.Rejected() > 1
+		},
+		10*time.Second, 5*time.Millisecond, "consumption should be retried",
+	)
+	require.NoError(t, logsReceiver.Shutdown(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.Error(t, stanzaReceiver.getPersister().Set(ctx, "key", []byte("value")))
+}
+
 func BenchmarkReadLine(b *testing.B) {
 
 	tempDir, err := ioutil.TempDir("", "")
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package socketinput implements a stanza input operator reading log entries
+// from UDP and Unix domain sockets, modeled after the tcp_input operator.
+package socketinput
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+)
+
+const (
+	// DefaultMaxLogSize is the max buffer sized used
+	// if MaxLogSize is not set
+	DefaultMaxLogSize = 1024 * 1024
+
+	// Networks supported by the input
+	NetworkUDP      = "udp"
+	NetworkUnix     = "unix"
+	NetworkUnixgram = "unixgram"
+)
+
+// NewConfig creates a new socket input config with default values
+func NewConfig(operatorID, operatorType string) Config {
+	return Config{
+		InputConfig: helper.NewInputConfig(operatorID, operatorType),
+		Multiline:   helper.NewMultilineConfig(),
+		Encoding:    helper.NewEncodingConfig(),
+	}
+}
+
+// Config is the configuration shared by the socket input operators. It is
+// embedded in the configuration of each input, which knows the network and
+// the address to listen on.
+type Config struct {
+	helper.InputConfig `yaml:",inline"`
+
+	MaxLogSize    helper.ByteSize        `mapstructure:"max_log_size,omitempty"          json:"max_log_size,omitempty"         yaml:"max_log_size,omitempty"`
+	AddAttributes bool                   `mapstructure:"add_attributes,omitempty"        json:"add_attributes,omitempty"       yaml:"add_attributes,omitempty"`
+	Encoding      helper.EncodingConfig  `mapstructure:",squash,omitempty"               json:",inline,omitempty"              yaml:",inline,omitempty"`
+	Multiline     helper.MultilineConfig `mapstructure:"multiline,omitempty"             json:"multiline,omitempty"            yaml:"multiline,omitempty"`
+}
+
+// Build will build a socket input operator listening on the given network and address.
+func (c Config) Build(context operator.BuildContext, network, address string) ([]operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(context)
+	if err != nil {
+		return nil, err
+	}
+
+	switch network {
+	case NetworkUDP, NetworkUnix, NetworkUnixgram:
+	default:
+		return nil, fmt.Errorf("unsupported network '%s'", network)
+	}
+
+	if address == "" {
+		return nil, fmt.Errorf("missing required parameter for the %s address", network)
+	}
+
+	if c.MaxLogSize == 0 {
+		c.MaxLogSize = DefaultMaxLogSize
+	}
+
+	if c.MaxLogSize < 0 {
+		return nil, fmt.Errorf("invalid value for parameter 'max_log_size'")
+	}
+
+	encoding, err := c.Encoding.Build(context)
+	if err != nil {
+		return nil, err
+	}
+
+	splitFunc, err := c.Multiline.Build(context, encoding.Encoding, true)
+	if err != nil {
+		return nil, err
+	}
+
+	socketInput := &Input{
+		InputOperator: inputOperator,
+		network:       network,
+		address:       address,
+		MaxLogSize:    int(c.MaxLogSize),
+		addAttributes: c.AddAttributes,
+		encoding:      encoding,
+		splitFunc:     splitFunc,
+	}
+	return []operator.Operator{socketInput}, nil
+}
@@ -0,0 +1,301 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package socketinput
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+	"go.uber.org/zap"
+)
+
+// Input is an operator that reads log entries from a UDP or Unix domain socket.
+// Stream sockets are split into entries as they are read, while every
+// datagram is split on its own.
+type Input struct {
+	helper.InputOperator
+
+	network       string
+	address       string
+	MaxLogSize    int
+	addAttributes bool
+
+	encoding  helper.Encoding
+	splitFunc bufio.SplitFunc
+
+	listener   net.Listener
+	packetConn net.PacketConn
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+var _ operator.Operator = (*Input)(nil)
+
+const (
+	// Bounds of the delay before accepting or reading again after a temporary
+	// error, the same as the ones of net/http.
+	minRetryDelay = 5 * time.Millisecond
+	maxRetryDelay = time.Second
+)
+
+// Start will start listening for log entries.
+func (i *Input) Start(_ operator.Persister) error {
+	if i.network == NetworkUnix || i.network == NetworkUnixgram {
+		if err := removeStaleSocket(i.address); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+
+	switch i.network {
+	case NetworkUnix:
+		listener, err := net.Listen(i.network, i.address)
+		if err != nil {
+			cancel()
+			return fmt.Errorf("failed to listen on %s: %w", i.address, err)
+		}
+		i.listener = listener
+		i.wg.Add(1)
+		go i.acceptConnections(ctx)
+	default:
+		conn, err := net.ListenPacket(i.network, i.address)
+		if err != nil {
+			cancel()
+			return fmt.Errorf("failed to listen on %s: %w", i.address, err)
+		}
+		i.packetConn = conn
+		i.wg.Add(1)
+		go i.readDatagrams(ctx)
+	}
+	return nil
+}
+
+// Stop will stop listening for log entries.
+func (i *Input) Stop() error {
+	if i.cancel != nil {
+		i.cancel()
+	}
+	if i.listener != nil {
+		i.listener.Close()
+	}
+	if i.packetConn != nil {
+		i.packetConn.Close()
+		if i.network == NetworkUnixgram {
+			os.Remove(i.address)
+		}
+	}
+	i.wg.Wait()
+	return nil
+}
+
+// acceptConnections accepts connections on the stream socket until it is closed.
+func (i *Input) acceptConnections(ctx context.Context) {
+	defer i.wg.Done()
+
+	var delay time.Duration
+	for {
+		conn, err := i.listener.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+			if !isTemporary(err) {
+				i.Errorw("Failed to accept connection, no longer accepting connections", zap.Error(err))
+				return
+			}
+			delay = nextRetryDelay(delay)
+			i.Warnw("Failed to accept connection, retrying", zap.Error(err), zap.Duration("delay", delay))
+			if !sleep(ctx, delay) {
+				return
+			}
+			continue
+		}
+		delay = 0
+
+		i.wg.Add(1)
+		go i.readStream(ctx, conn)
+	}
+}
+
+// readStream reads the entries sent on a stream connection until it is closed.
+func (i *Input) readStream(ctx context.Context, conn net.Conn) {
+	defer i.wg.Done()
+
+	// Close the connection when the input is stopped to unblock the reads.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), i.MaxLogSize)
+	scanner.Split(i.splitFunc)
+	for scanner.Scan() {
+		i.handleMessage(ctx, scanner.Bytes(), conn.RemoteAddr())
+	}
+	if err := scanner.Err(); err != nil {
+		select {
+		case <-ctx.Done():
+		default:
+			i.Errorw("Failed to read from connection", zap.Error(err))
+		}
+	}
+}
+
+// readDatagrams reads datagrams from the packet socket until it is closed.
+func (i *Input) readDatagrams(ctx context.Context) {
+	defer i.wg.Done()
+
+	buf := make([]byte, i.MaxLogSize)
+	var delay time.Duration
+	for {
+		n, addr, err := i.packetConn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+			if !isTemporary(err) {
+				i.Errorw("Failed to read datagram, no longer reading datagrams", zap.Error(err))
+				return
+			}
+			delay = nextRetryDelay(delay)
+			i.Warnw("Failed to read datagram, retrying", zap.Error(err), zap.Duration("delay", delay))
+			if !sleep(ctx, delay) {
+				return
+			}
+			continue
+		}
+		delay = 0
+
+		// Every datagram is split on its own, flushing what remains at its end.
+		scanner := bufio.NewScanner(bytes.NewReader(buf[:n]))
+		scanner.Buffer(make([]byte, 0, n+1), n+1)
+		scanner.Split(i.splitFunc)
+		for scanner.Scan() {
+			i.handleMessage(ctx, scanner.Bytes(), addr)
+		}
+	}
+}
+
+// handleMessage creates an entry out of a message and sends it to the next operators.
+func (i *Input) handleMessage(ctx context.Context, message []byte, remoteAddr net.Addr) {
+	if len(message) == 0 {
+		return
+	}
+
+	decoded, err := i.encoding.Decode(message)
+	if err != nil {
+		i.Errorw("Failed to decode data", zap.Error(err))
+		return
+	}
+
+	entry, err := i.NewEntry(decoded)
+	if err != nil {
+		i.Errorw("Failed to create entry", zap.Error(err))
+		return
+	}
+
+	if i.addAttributes {
+		switch i.network {
+		case NetworkUDP:
+			entry.AddAttribute("net.transport", "IP.UDP")
+			if addr, ok := remoteAddr.(*net.UDPAddr); ok {
+				entry.AddAttribute("net.peer.ip", addr.IP.String())
+				entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
+			}
+
+			if addr, ok := i.packetConn.LocalAddr().(*net.UDPAddr); ok {
+				entry.AddAttribute("net.host.ip", addr.IP.String())
+				entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
+			}
+		default:
+			entry.AddAttribute("net.transport", "Unix")
+			entry.AddAttribute("net.host.name", i.address)
+			if addr, ok := remoteAddr.(*net.UnixAddr); ok && addr != nil && addr.Name != "" {
+				entry.AddAttribute("net.peer.name", addr.Name)
+			}
+		}
+	}
+
+	i.Write(ctx, entry)
+}
+
+// isTemporary returns true if the error of an accept or a read may go away
+// by itself, e.g. when running out of file descriptors.
+func isTemporary(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Temporary()
+}
+
+// nextRetryDelay doubles the delay before retrying, within the retry bounds.
+func nextRetryDelay(delay time.Duration) time.Duration {
+	delay *= 2
+	if delay < minRetryDelay {
+		return minRetryDelay
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+// sleep waits for the given delay. It returns false if ctx is done first.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// removeStaleSocket removes a socket file left over at the given path, e.g.
+// by a previous run that did not shut down cleanly. Other kinds of files
+// are left in place so that listening fails.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return nil
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
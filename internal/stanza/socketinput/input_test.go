@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package socketinput
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/entry"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func startInput(t *testing.T, cfg Config, network, address string) (*Input, *testutil.FakeOutput) {
+	ops, err := cfg.Build(testutil.NewBuildContext(t), network, address)
+	require.NoError(t, err)
+	input, ok := ops[0].(*Input)
+	require.True(t, ok)
+
+	fake := testutil.NewFakeOutput(t)
+	input.OutputOperators = []operator.Operator{fake}
+
+	require.NoError(t, input.Start(testutil.NewMockPersister("test")))
+	t.Cleanup(func() { require.NoError(t, input.Stop()) })
+	return input, fake
+}
+
+func expectEntries(t *testing.T, fake *testutil.FakeOutput, bodies ...string) []*entry.Entry {
+	var entries []*entry.Entry
+	for _, body := range bodies {
+		select {
+		case e := <-fake.Received:
+			require.Equal(t, body, e.Body)
+			entries = append(entries, e)
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for entry", body)
+		}
+	}
+
+	select {
+	case e := <-fake.Received:
+		require.FailNow(t, "Unexpected entry", "%v", e)
+	case <-time.After(100 * time.Millisecond):
+	}
+	return entries
+}
+
+func TestBuildErrors(t *testing.T) {
+	cfg := NewConfig("test_input", "test_input")
+
+	_, err := cfg.Build(testutil.NewBuildContext(t), "tcp", "localhost:0")
+	require.Error(t, err)
+
+	_, err = cfg.Build(testutil.NewBuildContext(t), NetworkUDP, "")
+	require.Error(t, err)
+
+	cfg.MaxLogSize = -1
+	_, err = cfg.Build(testutil.NewBuildContext(t), NetworkUDP, "localhost:0")
+	require.Error(t, err)
+
+	cfg = NewConfig("test_input", "test_input")
+	cfg.Encoding.Encoding = "unknown"
+	_, err = cfg.Build(testutil.NewBuildContext(t), NetworkUDP, "localhost:0")
+	require.Error(t, err)
+}
+
+func TestUDP(t *testing.T) {
+	cfg := NewConfig("test_input", "test_input")
+	cfg.AddAttributes = true
+	input, fake := startInput(t, cfg, NetworkUDP, "127.0.0.1:0")
+
+	conn, err := net.Dial("udp", input.packetConn.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("message1\nmessage2\n"))
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("message3"))
+	require.NoError(t, err)
+
+	entries := expectEntries(t, fake, "message1", "message2", "message3")
+	require.Equal(t, "IP.UDP", entries[0].Attributes["net.transport"])
+	require.Equal(t, "127.0.0.1", entries[0].Attributes["net.peer.ip"])
+	require.Equal(t, "127.0.0.1", entries[0].Attributes["net.host.ip"])
+	require.NotEmpty(t, entries[0].Attributes["net.peer.port"])
+	require.NotEmpty(t, entries[0].Attributes["net.host.port"])
+}
+
+func TestUDPMultiline(t *testing.T) {
+	cfg := NewConfig("test_input", "test_input")
+	cfg.Multiline.LineStartPattern = `^\S`
+	input, fake := startInput(t, cfg, NetworkUDP, "127.0.0.1:0")
+
+	conn, err := net.Dial("udp", input.packetConn.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("first\n  continued\nsecond\n"))
+	require.NoError(t, err)
+
+	expectEntries(t, fake, "first\n  continued", "second")
+}
+
+func TestNextRetryDelay(t *testing.T) {
+	var delays []time.Duration
+	var delay time.Duration
+	for n := 0; n < 10; n++ {
+		delay = nextRetryDelay(delay)
+		delays = append(delays, delay)
+	}
+	require.Equal(t, []time.Duration{
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		80 * time.Millisecond,
+		160 * time.Millisecond,
+		320 * time.Millisecond,
+		640 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}, delays)
+}
+
+func TestStopsReadingOnPermanentError(t *testing.T) {
+	// Accepting and reading fail for good once the socket is closed, which
+	// must end the loop even though the input wasn't stopped.
+	t.Run("stream", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "stream.sock")
+		input, _ := startInput(t, NewConfig("test_input", "test_input"), NetworkUnix, path)
+		require.NoError(t, input.listener.Close())
+		expectStopped(t, input)
+	})
+
+	t.Run("datagram", func(t *testing.T) {
+		input, _ := startInput(t, NewConfig("test_input", "test_input"), NetworkUDP, "127.0.0.1:0")
+		require.NoError(t, input.packetConn.Close())
+		expectStopped(t, input)
+	})
+}
+
+func expectStopped(t *testing.T, input *Input) {
+	done := make(chan struct{})
+	go func() {
+		input.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "Input still running after a permanent error")
+	}
+}
+
+func TestUnix(t *testing.T) {
+	cfg := NewConfig("test_input", "test_input")
+	cfg.AddAttributes = true
+	path := filepath.Join(t.TempDir(), "stream.sock")
+	_, fake := startInput(t, cfg, NetworkUnix, path)
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("message1\nmessage2\nmessage3"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	entries := expectEntries(t, fake, "message1", "message2", "message3")
+	require.Equal(t, "Unix", entries[0].Attributes["net.transport"])
+	require.Equal(t, path, entries[0].Attributes["net.host.name"])
+}
+
+func TestUnixgram(t *testing.T) {
+	cfg := NewConfig("test_input", "test_input")
+	cfg.Encoding.Encoding = "utf16"
+	path := filepath.Join(t.TempDir(), "datagram.sock")
+	_, fake := startInput(t, cfg, NetworkUnixgram, path)
+
+	conn, err := net.Dial("unixgram", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// "hello\n" encoded in UTF-16LE.
+	_, err = conn.Write([]byte{'h', 0, 'e', 0, 'l', 0, 'l', 0, 'o', 0, '\n', 0})
+	require.NoError(t, err)
+
+	expectEntries(t, fake, "hello")
+}
+
+func TestStaleSocketRemoved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stale.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	// Leave the socket file behind as a crashed process would.
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, listener.Close())
+
+	_, fake := startInput(t, NewConfig("test_input", "test_input"), NetworkUnix, path)
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("message\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	expectEntries(t, fake, "message")
+}
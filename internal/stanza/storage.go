@@ -16,7 +16,7 @@ package stanza
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/open-telemetry/opentelemetry-log-collection/operator"
 	"go.opentelemetry.io/collector/component"
@@ -25,22 +25,7 @@ import (
 )
 
 func (r *receiver) setStorageClient(ctx context.Context, host component.Host) error {
-	var storageExtension storage.Extension
-	for _, ext := range host.GetExtensions() {
-		if se, ok := ext.(storage.Extension); ok {
-			if storageExtension != nil {
-				return errors.New("multiple storage extensions found")
-			}
-			storageExtension = se
-		}
-	}
-
-	if storageExtension == nil {
-		r.storageClient = storage.NewNopClient()
-		return nil
-	}
-
-	client, err := storageExtension.GetClient(ctx, component.KindReceiver, r.id)
+	client, err := storage.GetClient(ctx, host, r.storageID, component.KindReceiver, r.id)
 	if err != nil {
 		return err
 	}
@@ -50,11 +35,14 @@ func (r *receiver) setStorageClient(ctx context.Context, host component.Host) er
 }
 
 func (r *receiver) getPersister() operator.Persister {
-	return &persister{r.storageClient}
+	return &persister{r.storageClient, r.inflight}
 }
 
 type persister struct {
 	client storage.Client
+	// inflight, if set, holds back checkpoints until all the entries emitted
+	// before them have been consumed.
+	inflight *inflightTracker
 }
 
 var _ operator.Persister = &persister{}
@@ -64,6 +52,13 @@ func (p *persister) Get(ctx context.Context, key string) ([]byte, error) {
 }
 
 func (p *persister) Set(ctx context.Context, key string, value []byte) error {
+	if p.inflight != nil {
+		// Operators save their checkpoints after emitting the entries they
+		// read, so waiting here also keeps them from reading any further.
+		if err := p.inflight.wait(ctx); err != nil {
+			return fmt.Errorf("checkpoint not committed: %w", err)
+		}
+	}
 	return p.client.Set(ctx, key, value)
 }
 
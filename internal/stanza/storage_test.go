@@ -21,6 +21,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap/zaptest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
@@ -78,10 +80,84 @@ func TestFailOnMultipleStorageExtensions(t *testing.T) {
 	host := storagetest.NewStorageHost(t, tempDir, "one", "two")
 	err = r.Start(ctx, host)
 	require.Error(t, err)
-	require.Equal(t, "storage client: multiple storage extensions found", err.Error())
+	require.Equal(t, "storage client: multiple storage extensions found, select one with the storage setting", err.Error())
+}
+
+func TestSelectStorageExtension(t *testing.T) {
+	ctx := context.Background()
+	oneDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	twoDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	host := extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewIDWithName("nop", "one"): storagetest.NewTestExtension(t, oneDir),
+			config.NewIDWithName("nop", "two"): storagetest.NewTestExtension(t, twoDir),
+		},
+	}
+
+	cfg := NewFactory(TestReceiverType{}).CreateDefaultConfig().(*TestConfig)
+	cfg.StorageID = "nop/two"
+	r := createReceiverWithConfig(t, cfg)
+	require.NoError(t, r.Start(ctx, host))
+	require.NoError(t, r.storageClient.Set(ctx, "key", []byte("two")))
+	require.NoError(t, r.Shutdown(ctx))
+	for _, e := range host.GetExtensions() {
+		require.NoError(t, e.Shutdown(ctx))
+	}
+
+	// The value was only written to the selected extension.
+	files, err := ioutil.ReadDir(oneDir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+	files, err = ioutil.ReadDir(twoDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestSelectMissingStorageExtension(t *testing.T) {
+	ctx := context.Background()
+	tempDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	cfg := NewFactory(TestReceiverType{}).CreateDefaultConfig().(*TestConfig)
+	cfg.StorageID = "nop/missing"
+	r := createReceiverWithConfig(t, cfg)
+	err = r.Start(ctx, storagetest.NewStorageHost(t, tempDir, "one"))
+	require.Error(t, err)
+	require.Equal(t, `storage client: storage extension "nop/missing" not found`, err.Error())
+}
+
+func TestInvalidStorageID(t *testing.T) {
+	factory := NewFactory(TestReceiverType{})
+	cfg := factory.CreateDefaultConfig().(*TestConfig)
+	cfg.StorageID = "/missing-type"
+	_, err := factory.CreateLogsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zaptest.NewLogger(t)},
+		cfg,
+		&mockLogsConsumer{},
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `invalid storage extension "/missing-type"`)
 }
 
 func createReceiver(t *testing.T) *receiver {
+	return createReceiverWithConfig(t, NewFactory(TestReceiverType{}).CreateDefaultConfig())
+}
+
+func createReceiverWithConfig(t *testing.T, cfg config.Receiver) *receiver {
 	params := component.ReceiverCreateParams{
 		Logger: zaptest.NewLogger(t),
 	}
@@ -92,7 +168,7 @@ func createReceiver(t *testing.T) *receiver {
 	logsReceiver, err := factory.CreateLogsReceiver(
 		context.Background(),
 		params,
-		factory.CreateDefaultConfig(),
+		cfg,
 		&mockConsumer,
 	)
 	require.NoError(t, err, "receiver should successfully build")
@@ -0,0 +1,51 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apachereceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+func TestValidate(t *testing.T) {
+	testcases := []struct {
+		endpoint string
+		err      string
+	}{
+		{endpoint: "http://localhost:8080/server-status?auto"},
+		{endpoint: "https://localhost/server-status"},
+		{endpoint: "", err: "endpoint must be an http or https URL"},
+		{endpoint: "localhost:8080/server-status", err: "endpoint must be an http or https URL"},
+		{endpoint: "http://local host/server-status", err: `invalid endpoint "http://local host/server-status": parse "http://local host/server-status": invalid character " " in host name`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: tc.endpoint,
+				},
+			}
+			err := cfg.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apachereceiver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/apachereceiver/internal/metadata"
+)
+
+// statusMetrics maps the fields of the server status to the metric they are
+// reported as. Values are multiplied by scale to be reported in the unit of
+// the metric.
+var statusMetrics = []struct {
+	field  string
+	metric metadata.MetricIntf
+	labels map[string]string
+	scale  int64
+}{
+	{"ServerUptimeSeconds", metadata.M.ApacheUptime, nil, 1},
+	{"ConnsTotal", metadata.M.ApacheCurrentConnections, nil, 1},
+	{"BusyWorkers", metadata.M.ApacheWorkers, map[string]string{metadata.L.WorkersState: "busy"}, 1},
+	{"IdleWorkers", metadata.M.ApacheWorkers, map[string]string{metadata.L.WorkersState: "idle"}, 1},
+	{"Total Accesses", metadata.M.ApacheRequests, nil, 1},
+	{"Total kBytes", metadata.M.ApacheTraffic, nil, 1024},
+}
+
+// scoreboardStates maps the characters of the scoreboard to the state of the
+// worker slot they stand for, in the order they are reported.
+var scoreboardStates = []struct {
+	char  byte
+	state string
+}{
+	{'.', "open"},
+	{'_', "waiting"},
+	{'S', "starting"},
+	{'R', "reading"},
+	{'W', "sending"},
+	{'K', "keepalive"},
+	{'D', "dnslookup"},
+	{'C', "closing"},
+	{'L', "logging"},
+	{'G', "finishing"},
+	{'I', "idle_cleanup"},
+}
+
+type apacheScraper struct {
+	httpClient *http.Client
+	statusURL  string
+	serverName string
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newApacheScraper(
+	logger *zap.Logger,
+	cfg *Config,
+) *apacheScraper {
+	return &apacheScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *apacheScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	// Init client in scrape method in case there are transient errors in the
+	// constructor.
+	if r.httpClient == nil {
+		u, err := url.Parse(r.cfg.Endpoint)
+		if err != nil {
+			return pdata.ResourceMetricsSlice{}, err
+		}
+		// mod_status outputs the machine readable server status when the
+		// auto query flag is set.
+		if _, ok := u.Query()["auto"]; !ok {
+			if u.RawQuery != "" {
+				u.RawQuery += "&"
+			}
+			u.RawQuery += "auto"
+		}
+
+		httpClient, err := r.cfg.ToClient()
+		if err != nil {
+			return pdata.ResourceMetricsSlice{}, err
+		}
+
+		r.httpClient = httpClient
+		r.statusURL = u.String()
+		r.serverName = u.Hostname()
+	}
+
+	stats, err := r.getStats(ctx)
+	if err != nil {
+		r.logger.Error("Failed to fetch Apache HTTP server status", zap.Error(err))
+		return pdata.ResourceMetricsSlice{}, err
+	}
+
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/apache",
+	}
+	serverMetrics := metrics.WithLabels(map[string]string{metadata.L.ServerName: r.serverName})
+
+	var scrapeErrors scrapererror.ScrapeErrors
+
+	// Fields that depend on the MPM or on ExtendedStatus may be missing and
+	// are skipped.
+	for _, m := range statusMetrics {
+		value, ok := stats[m.field]
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			scrapeErrors.AddPartial(1, fmt.Errorf("failed to parse %s: %w", m.field, err))
+			continue
+		}
+		addDataPoint(serverMetrics.WithLabels(m.labels), m.metric, v*m.scale)
+	}
+
+	if scoreboard, ok := stats["Scoreboard"]; ok {
+		for _, s := range scoreboardStates {
+			addDataPoint(
+				serverMetrics.WithLabels(map[string]string{metadata.L.ScoreboardState: s.state}),
+				metadata.M.ApacheScoreboard,
+				int64(strings.Count(scoreboard, string(s.char))),
+			)
+		}
+	}
+
+	return metrics.Metrics.ResourceMetrics(), scrapeErrors.Combine()
+}
+
+// getStats fetches the server status and returns its fields.
+func (r *apacheScraper) getStats(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.statusURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expected 200 response, got %d", resp.StatusCode)
+	}
+
+	// The body is left out of the error as it may be large.
+	stats, err := parseStats(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response body: %w", err)
+	}
+	return stats, nil
+}
+
+// parseStats parses the "Name: value" lines of the machine readable server
+// status. Lines that aren't fields, like the server name on the first line,
+// are ignored.
+func parseStats(body io.Reader) (map[string]string, error) {
+	stats := map[string]string{}
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		stats[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, errors.New("no status field found")
+	}
+	return stats, nil
+}
+
+func addDataPoint(metrics *simple.Metrics, metric metadata.MetricIntf, value int64) {
+	switch metric.New().DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		metrics.AddGaugeDataPoint(metric.Name(), value)
+	case pdata.MetricDataTypeIntSum:
+		metrics.AddSumDataPoint(metric.Name(), value)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apachereceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const serverStatus = `localhost
+ServerVersion: Apache/2.4.46 (Unix)
+ServerMPM: event
+Server Built: Aug  5 2020 23:20:17
+CurrentTime: Wednesday, 19-May-2021 14:27:05 UTC
+RestartTime: Wednesday, 19-May-2021 14:20:15 UTC
+ParentServerConfigGeneration: 1
+ParentServerMPMGeneration: 0
+ServerUptimeSeconds: 410
+ServerUptime: 6 minutes 50 seconds
+Load1: 0.61
+Load5: 0.35
+Load15: 0.21
+Total Accesses: 2018
+Total kBytes: 1268
+Total Duration: 1237
+CPUUser: .15
+CPUSystem: .04
+CPUChildrenUser: 0
+CPUChildrenSystem: 0
+CPULoad: .0463415
+Uptime: 410
+ReqPerSec: 4.92195
+BytesPerSec: 3166.91
+BytesPerReq: 643.393
+DurationPerReq: .612983
+BusyWorkers: 3
+IdleWorkers: 72
+Processes: 3
+Stopping: 0
+ConnsTotal: 3
+ConnsAsyncWriting: 0
+ConnsAsyncKeepAlive: 1
+ConnsAsyncClosing: 0
+Scoreboard: _W___K_____R_______________________________________________________________.....................................................................................................................................................................................................................................................................................................................................
+`
+
+func TestScraper(t *testing.T) {
+	apacheMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/server-status" && req.URL.RawQuery == "auto" {
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte(serverStatus))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer apacheMock.Close()
+
+	u, err := url.Parse(apacheMock.URL)
+	require.NoError(t, err)
+
+	// The auto query flag is added when missing.
+	for _, endpoint := range []string{apacheMock.URL + "/server-status?auto", apacheMock.URL + "/server-status"} {
+		t.Run(endpoint, func(t *testing.T) {
+			sc := newApacheScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: endpoint,
+				},
+			})
+			rms, err := sc.scrape(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, 1, rms.Len())
+			rm := rms.At(0)
+
+			ilms := rm.InstrumentationLibraryMetrics()
+			require.Equal(t, 1, ilms.Len())
+
+			ilm := ilms.At(0)
+			ms := ilm.Metrics()
+
+			require.Equal(t, 6, ms.Len())
+
+			// The data points are keyed by the metric name followed by their
+			// sorted labels.
+			metricValues := make(map[string]int64, 17)
+
+			for i := 0; i < ms.Len(); i++ {
+				m := ms.At(i)
+
+				var dps pdata.IntDataPointSlice
+
+				switch m.DataType() {
+				case pdata.MetricDataTypeIntGauge:
+					dps = m.IntGauge().DataPoints()
+				case pdata.MetricDataTypeIntSum:
+					dps = m.IntSum().DataPoints()
+				}
+
+				for j := 0; j < dps.Len(); j++ {
+					var labels []string
+					dps.At(j).LabelsMap().Range(func(k string, v string) bool {
+						labels = append(labels, k+"="+v)
+						return true
+					})
+					sort.Strings(labels)
+					metricValues[strings.Join(append([]string{m.Name()}, labels...), " ")] = dps.At(j).Value()
+				}
+			}
+
+			server := "server_name=" + u.Hostname()
+			require.Equal(t, map[string]int64{
+				"apache.uptime " + server:                                   410,
+				"apache.current_connections " + server:                      3,
+				"apache.workers " + server + " workers_state=busy":          3,
+				"apache.workers " + server + " workers_state=idle":          72,
+				"apache.requests " + server:                                 2018,
+				"apache.traffic " + server:                                  1268 * 1024,
+				"apache.scoreboard scoreboard_state=open " + server:         325,
+				"apache.scoreboard scoreboard_state=waiting " + server:      72,
+				"apache.scoreboard scoreboard_state=starting " + server:     0,
+				"apache.scoreboard scoreboard_state=reading " + server:      1,
+				"apache.scoreboard scoreboard_state=sending " + server:      1,
+				"apache.scoreboard scoreboard_state=keepalive " + server:    1,
+				"apache.scoreboard scoreboard_state=dnslookup " + server:    0,
+				"apache.scoreboard scoreboard_state=closing " + server:      0,
+				"apache.scoreboard scoreboard_state=logging " + server:      0,
+				"apache.scoreboard scoreboard_state=finishing " + server:    0,
+				"apache.scoreboard scoreboard_state=idle_cleanup " + server: 0,
+			}, metricValues)
+		})
+	}
+}
+
+func TestScraperMissingFields(t *testing.T) {
+	apacheMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+		_, _ = rw.Write([]byte("localhost\nServerUptimeSeconds: 410\nBusyWorkers: 2\nIdleWorkers: abc\n"))
+	}))
+	defer apacheMock.Close()
+
+	sc := newApacheScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: apacheMock.URL + "/server-status?auto",
+		},
+	})
+	rms, err := sc.scrape(context.Background())
+	require.EqualError(t, err, `failed to parse IdleWorkers: strconv.ParseInt: parsing "abc": invalid syntax`)
+
+	// Fields that are missing or can't be parsed are skipped.
+	require.Equal(t, 1, rms.Len())
+	ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+
+	require.Equal(t, "apache.uptime", ms.At(0).Name())
+	require.Equal(t, int64(410), ms.At(0).IntSum().DataPoints().At(0).Value())
+
+	require.Equal(t, "apache.workers", ms.At(1).Name())
+	dps := ms.At(1).IntGauge().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	state, _ := dps.At(0).LabelsMap().Get("workers_state")
+	require.Equal(t, "busy", state)
+	require.Equal(t, int64(2), dps.At(0).Value())
+}
+
+func TestScraperError(t *testing.T) {
+	apacheMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/server-status" {
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte(`Bad status page`))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer apacheMock.Close()
+
+	t.Run("404", func(t *testing.T) {
+		sc := newApacheScraper(zap.NewNop(), &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: apacheMock.URL + "/badpath",
+			},
+		})
+
+		_, err := sc.scrape(context.Background())
+		require.Equal(t, errors.New("expected 200 response, got 404"), err)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		sc := newApacheScraper(zap.NewNop(), &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: apacheMock.URL + "/server-status?auto",
+			},
+		})
+		_, err := sc.scrape(context.Background())
+		require.EqualError(t, err, "failed to parse response body: no status field found")
+	})
+}
@@ -0,0 +1,214 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sapiserver
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
+)
+
+// collector generates the cluster-level metrics in the Container Insights schema from a snapshot
+// of the objects cached by the informers of the leader
+type collector struct {
+	listers     *listers
+	clusterName string
+	nodeName    string
+	timestamp   time.Time
+	logger      *zap.Logger
+}
+
+// podPhaseMeasurements maps each pod phase to the measurement counting the pods in that phase
+var podPhaseMeasurements = map[corev1.PodPhase]string{
+	corev1.PodPending:   ci.PendingPodCount,
+	corev1.PodRunning:   ci.RunningPodCount,
+	corev1.PodSucceeded: ci.SucceededPodCount,
+	corev1.PodFailed:    ci.FailedPodCount,
+	corev1.PodUnknown:   ci.UnknownPodCount,
+}
+
+func (c *collector) collect() []pdata.Metrics {
+	var result []pdata.Metrics
+
+	nodes, err := c.listers.nodes.List(labels.Everything())
+	if err != nil {
+		c.logger.Warn("Failed to list nodes", zap.Error(err))
+		return nil
+	}
+	pods, err := c.listers.pods.List(labels.Everything())
+	if err != nil {
+		c.logger.Warn("Failed to list pods", zap.Error(err))
+		return nil
+	}
+
+	result = append(result, c.clusterMetrics(nodes, pods))
+	result = append(result, c.namespaceMetrics(pods)...)
+
+	services, err := c.listers.services.List(labels.Everything())
+	if err != nil {
+		c.logger.Warn("Failed to list services", zap.Error(err))
+	} else {
+		endpoints, err := c.listers.endpoints.List(labels.Everything())
+		if err != nil {
+			c.logger.Warn("Failed to list endpoints", zap.Error(err))
+		} else {
+			result = append(result, c.serviceMetrics(services, endpoints)...)
+		}
+	}
+
+	deployments, err := c.listers.deployments.List(labels.Everything())
+	if err != nil {
+		c.logger.Warn("Failed to list deployments", zap.Error(err))
+	} else {
+		result = append(result, c.deploymentMetrics(deployments)...)
+	}
+
+	return result
+}
+
+func (c *collector) clusterMetrics(nodes []*corev1.Node, pods []*corev1.Pod) pdata.Metrics {
+	failedNodes := 0
+	for _, node := range nodes {
+		if !isNodeReady(node) {
+			failedNodes++
+		}
+	}
+
+	fields := map[string]interface{}{
+		ci.MetricName(ci.TypeCluster, ci.NodeCount):       int64(len(nodes)),
+		ci.MetricName(ci.TypeCluster, ci.FailedNodeCount): int64(failedNodes),
+	}
+	addPodPhaseFields(fields, ci.TypeCluster, pods)
+
+	return c.newMetrics(ci.TypeCluster, fields, nil)
+}
+
+func (c *collector) namespaceMetrics(pods []*corev1.Pod) []pdata.Metrics {
+	podsByNamespace := map[string][]*corev1.Pod{}
+	for _, pod := range pods {
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+
+	var result []pdata.Metrics
+	namespaces := make([]string, 0, len(podsByNamespace))
+	for namespace := range podsByNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		fields := map[string]interface{}{}
+		addPodPhaseFields(fields, ci.TypeClusterNamespace, podsByNamespace[namespace])
+		result = append(result, c.newMetrics(ci.TypeClusterNamespace, fields, map[string]string{
+			ci.K8sNamespace: namespace,
+		}))
+	}
+	return result
+}
+
+func (c *collector) serviceMetrics(services []*corev1.Service, endpoints []*corev1.Endpoints) []pdata.Metrics {
+	endpointsByService := make(map[string]*corev1.Endpoints, len(endpoints))
+	for _, ep := range endpoints {
+		endpointsByService[ep.Namespace+"/"+ep.Name] = ep
+	}
+
+	var result []pdata.Metrics
+	for _, service := range services {
+		var runningPods, notReadyEndpoints int64
+		if ep, ok := endpointsByService[service.Namespace+"/"+service.Name]; ok {
+			for _, subset := range ep.Subsets {
+				for _, address := range subset.Addresses {
+					if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+						runningPods++
+					}
+				}
+				notReadyEndpoints += int64(len(subset.NotReadyAddresses))
+			}
+		}
+
+		result = append(result, c.newMetrics(ci.TypeClusterService, map[string]interface{}{
+			ci.MetricName(ci.TypeClusterService, ci.RunningPodCount):       runningPods,
+			ci.MetricName(ci.TypeClusterService, ci.NotReadyEndpointCount): notReadyEndpoints,
+		}, map[string]string{
+			ci.K8sNamespace:   service.Namespace,
+			ci.ServiceNameKey: service.Name,
+		}))
+	}
+	return result
+}
+
+func (c *collector) deploymentMetrics(deployments []*appsv1.Deployment) []pdata.Metrics {
+	var result []pdata.Metrics
+	for _, deployment := range deployments {
+		// the number of desired replicas defaults to 1 when it is not specified
+		specReplicas := int64(1)
+		if deployment.Spec.Replicas != nil {
+			specReplicas = int64(*deployment.Spec.Replicas)
+		}
+
+		result = append(result, c.newMetrics(ci.TypeClusterDeployment, map[string]interface{}{
+			ci.MetricName(ci.TypeClusterDeployment, ci.SpecReplicas):              specReplicas,
+			ci.MetricName(ci.TypeClusterDeployment, ci.StatusReplicas):            int64(deployment.Status.Replicas),
+			ci.MetricName(ci.TypeClusterDeployment, ci.StatusReplicasAvailable):   int64(deployment.Status.AvailableReplicas),
+			ci.MetricName(ci.TypeClusterDeployment, ci.StatusReplicasUnavailable): int64(deployment.Status.UnavailableReplicas),
+		}, map[string]string{
+			ci.K8sNamespace:      deployment.Namespace,
+			ci.DeploymentNameKey: deployment.Name,
+		}))
+	}
+	return result
+}
+
+func (c *collector) newMetrics(metricType string, fields map[string]interface{}, attributes map[string]string) pdata.Metrics {
+	tags := map[string]string{
+		ci.ClusterNameKey: c.clusterName,
+		ci.NodeNameKey:    c.nodeName,
+		ci.MetricType:     metricType,
+		ci.Timestamp:      strconv.FormatInt(c.timestamp.UnixNano(), 10),
+		ci.Version:        "0",
+	}
+	for k, v := range attributes {
+		tags[k] = v
+	}
+	return ci.ConvertToOTLPMetrics(fields, tags, c.logger)
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// addPodPhaseFields adds the number of pods in each pod phase to the fields of the given metric type
+func addPodPhaseFields(fields map[string]interface{}, metricType string, pods []*corev1.Pod) {
+	counts := make(map[corev1.PodPhase]int64, len(podPhaseMeasurements))
+	for _, pod := range pods {
+		counts[pod.Status.Phase]++
+	}
+	for phase, measurement := range podPhaseMeasurements {
+		fields[ci.MetricName(metricType, measurement)] = counts[phase]
+	}
+}
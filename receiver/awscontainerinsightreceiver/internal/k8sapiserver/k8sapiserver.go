@@ -16,52 +16,229 @@ package k8sapiserver
 
 import (
 	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const (
+	// environment variables set on the DaemonSet pods through the downward API
+	hostNameEnv  = "HOST_NAME"
+	namespaceEnv = "K8S_NAMESPACE"
+
+	defaultNamespace = "default"
+	lockName         = "otel-container-insight-clusterleader"
+
+	leaseDuration = 60 * time.Second
+	renewDeadline = 15 * time.Second
+	retryPeriod   = 5 * time.Second
 )
 
 // K8sAPIServer is a struct that produces metrics from kubernetes api server
 type K8sAPIServer struct {
+	nodeName            string // get the value from downward API
+	namespace           string // namespace holding the leader election lease
 	logger              *zap.Logger
 	clusterNameProvider clusterNameProvider
+	client              kubernetes.Interface
+	ctx                 context.Context
 	cancel              context.CancelFunc
+	leading             *atomic.Bool
+
+	mu      sync.Mutex
+	listers *listers // only set while this receiver is the leader
+}
+
+// listers give access to the objects cached by the informers started by the leader, so that
+// the api server is watched instead of being fully listed at every collection interval
+type listers struct {
+	nodes       corelisters.NodeLister
+	pods        corelisters.PodLister
+	services    corelisters.ServiceLister
+	endpoints   corelisters.EndpointsLister
+	deployments appslisters.DeploymentLister
+}
+
+// newListers starts the informers of the objects needed by the collector. The informers are
+// stopped when ctx is done. It returns false if ctx is done before the caches are synced.
+func newListers(ctx context.Context, client kubernetes.Interface) (*listers, bool) {
+	factory := informers.NewSharedInformerFactory(client, 0)
+	l := &listers{
+		nodes:       factory.Core().V1().Nodes().Lister(),
+		pods:        factory.Core().V1().Pods().Lister(),
+		services:    factory.Core().V1().Services().Lister(),
+		endpoints:   factory.Core().V1().Endpoints().Lister(),
+		deployments: factory.Apps().V1().Deployments().Lister(),
+	}
+	factory.Start(ctx.Done())
+	for _, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return nil, false
+		}
+	}
+	return l, true
 }
 
 type clusterNameProvider interface {
 	GetClusterName() string
 }
 
-// New creates a k8sApiServer which can generate cluster-level metrics
-func New(clusterNameProvider clusterNameProvider, logger *zap.Logger) *K8sAPIServer {
-	_, cancel := context.WithCancel(context.Background())
+// Option is an option that can be used to customize the K8sAPIServer
+type Option func(*K8sAPIServer)
+
+// withClient is used to inject a clientset, e.g. a fake one in unit tests
+func withClient(client kubernetes.Interface) Option {
+	return func(k *K8sAPIServer) {
+		k.client = client
+	}
+}
+
+// New creates a k8sApiServer which can generate cluster-level metrics. The metrics are only
+// generated by the receiver which holds the leader election lease, so that cluster-level
+// metrics are not duplicated across the DaemonSet pods.
+func New(clusterNameProvider clusterNameProvider, logger *zap.Logger, options ...Option) (*K8sAPIServer, error) {
 	k := &K8sAPIServer{
+		nodeName:            os.Getenv(hostNameEnv),
+		namespace:           os.Getenv(namespaceEnv),
 		logger:              logger,
 		clusterNameProvider: clusterNameProvider,
-		cancel:              cancel,
+		leading:             atomic.NewBool(false),
 	}
 
-	if err := k.start(); err != nil {
-		k.logger.Warn("Fail to start k8sapiserver", zap.Error(err))
-		return nil
+	for _, opt := range options {
+		opt(k)
 	}
 
-	return k
+	if err := k.start(); err != nil {
+		return nil, err
+	}
+	return k, nil
 }
 
 // GetMetrics returns an array of metrics
 func (k *K8sAPIServer) GetMetrics() []pdata.Metrics {
-	// TODO: add the logic to generate the metrics
-	var result []pdata.Metrics
-	return result
+	if !k.leading.Load() {
+		return nil
+	}
+
+	k.mu.Lock()
+	l := k.listers
+	k.mu.Unlock()
+	if l == nil {
+		return nil
+	}
+
+	clusterName := k.clusterNameProvider.GetClusterName()
+	if clusterName == "" {
+		k.logger.Warn("Failed to detect cluster name, skip cluster-level metrics")
+		return nil
+	}
+
+	c := &collector{
+		listers:     l,
+		clusterName: clusterName,
+		nodeName:    k.nodeName,
+		timestamp:   time.Now(),
+		logger:      k.logger,
+	}
+	return c.collect()
 }
 
 func (k *K8sAPIServer) start() error {
-	//TODO: add implementation
+	if k.nodeName == "" {
+		return errors.New("missing environment variable " + hostNameEnv + ", please set it through the downward API")
+	}
+	if k.namespace == "" {
+		k.namespace = defaultNamespace
+	}
+
+	if k.client == nil {
+		client, err := k8sconfig.MakeClient(k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount})
+		if err != nil {
+			return err
+		}
+		k.client = client
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      lockName,
+			Namespace: k.namespace,
+		},
+		Client: k.client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: k.nodeName,
+		},
+	}
+
+	k.ctx, k.cancel = context.WithCancel(context.Background())
+	go k.startLeaderElection(lock)
 	return nil
 }
 
-// Stop stops the k8sApiServer
+// startLeaderElection keeps campaigning for the lease until the K8sAPIServer is stopped.
+// RunOrDie returns whenever the leadership is lost, in which case this receiver goes back
+// to being a candidate.
+func (k *K8sAPIServer) startLeaderElection(lock resourcelock.Interface) {
+	for {
+		leaderelection.RunOrDie(k.ctx, leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   leaseDuration,
+			RenewDeadline:   renewDeadline,
+			RetryPeriod:     retryPeriod,
+			ReleaseOnCancel: true,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(ctx context.Context) {
+					k.logger.Info("k8sapiserver became the leader", zap.String("node", k.nodeName))
+					// ctx is canceled when the leadership is lost, which stops the informers
+					l, ok := newListers(ctx, k.client)
+					if !ok {
+						return
+					}
+					k.mu.Lock()
+					defer k.mu.Unlock()
+					if ctx.Err() != nil {
+						return
+					}
+					k.listers = l
+					k.leading.Store(true)
+				},
+				OnStoppedLeading: func() {
+					k.logger.Info("k8sapiserver lost the leadership", zap.String("node", k.nodeName))
+					k.mu.Lock()
+					defer k.mu.Unlock()
+					k.listers = nil
+					k.leading.Store(false)
+				},
+				OnNewLeader: func(identity string) {
+					k.logger.Debug("k8sapiserver observed a new leader", zap.String("leader", identity))
+				},
+			},
+		})
+
+		select {
+		case <-k.ctx.Done():
+			return
+		default:
+		}
+	}
+}
+
+// Stop stops the k8sApiServer and releases the leader election lease if it is held
 func (k *K8sAPIServer) Stop() {
 	if k.cancel != nil {
 		k.cancel()
@@ -15,20 +15,252 @@
 package k8sapiserver
 
 import (
+	"context"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/host"
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
 )
 
-func TestK8sapiserver(t *testing.T) {
-	machineInfo := host.NewMachineInfo(time.Minute, zap.NewNop())
-	k := New(machineInfo, zap.NewNop())
-	k.start()
-	assert.NotNil(t, k)
+type mockClusterNameProvider struct {
+	clusterName string
+}
+
+func (m mockClusterNameProvider) GetClusterName() string {
+	return m.clusterName
+}
+
+func setEnv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func newNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+		},
+	}
+}
+
+func newPod(namespace, name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func clusterObjects() []runtime.Object {
+	replicas := int32(3)
+	return []runtime.Object{
+		newNode("node-1", corev1.ConditionTrue),
+		newNode("node-2", corev1.ConditionTrue),
+		newNode("node-3", corev1.ConditionFalse),
+		newPod("default", "pod-1", corev1.PodRunning),
+		newPod("default", "pod-2", corev1.PodRunning),
+		newPod("default", "pod-3", corev1.PodPending),
+		newPod("kube-system", "pod-4", corev1.PodRunning),
+		newPod("kube-system", "pod-5", corev1.PodFailed),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "no-endpoints"},
+		},
+		&corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: []corev1.EndpointAddress{
+					{IP: "10.0.0.1", TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-1"}},
+					{IP: "10.0.0.2", TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-2"}},
+				},
+				NotReadyAddresses: []corev1.EndpointAddress{
+					{IP: "10.0.0.3", TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-3"}},
+				},
+			}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{
+				Replicas:            3,
+				AvailableReplicas:   2,
+				UnavailableReplicas: 1,
+			},
+		},
+	}
+}
+
+// metricsByType indexes the generated metrics by their Type resource attribute and
+// the value of the given attribute, e.g. Namespace
+func metricsByType(mds []pdata.Metrics, metricType string, key string) map[string]map[string]int64 {
+	result := map[string]map[string]int64{}
+	for _, md := range mds {
+		rm := md.ResourceMetrics().At(0)
+		attrs := rm.Resource().Attributes()
+		if v, _ := attrs.Get(ci.MetricType); v.StringVal() != metricType {
+			continue
+		}
+		id := ""
+		if key != "" {
+			v, _ := attrs.Get(key)
+			id = v.StringVal()
+		}
+		fields := map[string]int64{}
+		ilms := rm.InstrumentationLibraryMetrics()
+		for i := 0; i < ilms.Len(); i++ {
+			metrics := ilms.At(i).Metrics()
+			for j := 0; j < metrics.Len(); j++ {
+				fields[metrics.At(j).Name()] = metrics.At(j).IntGauge().DataPoints().At(0).Value()
+			}
+		}
+		result[id] = fields
+	}
+	return result
+}
+
+func TestK8sAPIServerMissingNodeName(t *testing.T) {
+	setEnv(t, hostNameEnv, "")
+	k, err := New(mockClusterNameProvider{"cluster"}, zap.NewNop(), withClient(fake.NewSimpleClientset()))
+	assert.Error(t, err)
+	assert.Nil(t, k)
+}
+
+func TestK8sAPIServer(t *testing.T) {
+	setEnv(t, hostNameEnv, "node-1")
+	setEnv(t, namespaceEnv, "amazon-cloudwatch")
+
+	client := fake.NewSimpleClientset(clusterObjects()...)
+	k, err := New(mockClusterNameProvider{"cluster"}, zap.NewNop(), withClient(client))
+	require.NoError(t, err)
+	require.NotNil(t, k)
+	defer k.Stop()
+
+	require.Eventually(t, k.leading.Load, 5*time.Second, 10*time.Millisecond)
+
+	lease, err := client.CoordinationV1().Leases("amazon-cloudwatch").Get(context.Background(), lockName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", *lease.Spec.HolderIdentity)
+
+	mds := k.GetMetrics()
+	require.NotEmpty(t, mds)
+
+	// every metric carries the common resource attributes
+	for _, md := range mds {
+		attrs := md.ResourceMetrics().At(0).Resource().Attributes()
+		clusterName, _ := attrs.Get(ci.ClusterNameKey)
+		assert.Equal(t, "cluster", clusterName.StringVal())
+		nodeName, _ := attrs.Get(ci.NodeNameKey)
+		assert.Equal(t, "node-1", nodeName.StringVal())
+		_, ok := attrs.Get(ci.Timestamp)
+		assert.True(t, ok)
+		version, _ := attrs.Get(ci.Version)
+		assert.Equal(t, "0", version.StringVal())
+	}
+
+	cluster := metricsByType(mds, ci.TypeCluster, "")
+	assert.Equal(t, map[string]int64{
+		"cluster_node_count":               3,
+		"cluster_failed_node_count":        1,
+		"cluster_number_of_pending_pods":   1,
+		"cluster_number_of_running_pods":   3,
+		"cluster_number_of_succeeded_pods": 0,
+		"cluster_number_of_failed_pods":    1,
+		"cluster_number_of_unknown_pods":   0,
+	}, cluster[""])
+
+	namespaces := metricsByType(mds, ci.TypeClusterNamespace, ci.K8sNamespace)
+	assert.Len(t, namespaces, 2)
+	assert.Equal(t, int64(2), namespaces["default"]["namespace_number_of_running_pods"])
+	assert.Equal(t, int64(1), namespaces["default"]["namespace_number_of_pending_pods"])
+	assert.Equal(t, int64(1), namespaces["kube-system"]["namespace_number_of_running_pods"])
+	assert.Equal(t, int64(1), namespaces["kube-system"]["namespace_number_of_failed_pods"])
+
+	services := metricsByType(mds, ci.TypeClusterService, ci.ServiceNameKey)
+	assert.Equal(t, map[string]map[string]int64{
+		"web": {
+			"service_number_of_running_pods":        2,
+			"service_number_of_not_ready_endpoints": 1,
+		},
+		"no-endpoints": {
+			"service_number_of_running_pods":        0,
+			"service_number_of_not_ready_endpoints": 0,
+		},
+	}, services)
+
+	deployments := metricsByType(mds, ci.TypeClusterDeployment, ci.DeploymentNameKey)
+	assert.Equal(t, map[string]map[string]int64{
+		"web": {
+			"deployment_spec_replicas":               3,
+			"deployment_status_replicas":             3,
+			"deployment_status_replicas_available":   2,
+			"deployment_status_replicas_unavailable": 1,
+		},
+	}, deployments)
+
+	// objects created afterwards are picked up by the informers
+	_, err = client.CoreV1().Nodes().Create(context.Background(), newNode("node-4", corev1.ConditionTrue), metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return metricsByType(k.GetMetrics(), ci.TypeCluster, "")[""]["cluster_node_count"] == 4
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestK8sAPIServerNotLeader(t *testing.T) {
+	setEnv(t, hostNameEnv, "node-2")
+
+	// another node already holds a valid lease
+	holder := "node-1"
+	duration := int32(leaseDuration / time.Second)
+	now := metav1.NewMicroTime(time.Now())
+	client := fake.NewSimpleClientset(clusterObjects()...)
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace, Name: lockName},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			AcquireTime:          &now,
+			RenewTime:            &now,
+		},
+	}
+	_, err := client.CoordinationV1().Leases(defaultNamespace).Create(context.Background(), lease, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	k, err := New(mockClusterNameProvider{"cluster"}, zap.NewNop(), withClient(client))
+	require.NoError(t, err)
+	defer k.Stop()
+
+	assert.Never(t, k.leading.Load, 100*time.Millisecond, 10*time.Millisecond)
+	assert.Nil(t, k.GetMetrics())
+}
+
+func TestK8sAPIServerNoClusterName(t *testing.T) {
+	setEnv(t, hostNameEnv, "node-1")
+
+	k, err := New(mockClusterNameProvider{""}, zap.NewNop(), withClient(fake.NewSimpleClientset(clusterObjects()...)))
+	require.NoError(t, err)
+	defer k.Stop()
+
+	require.Eventually(t, k.leading.Load, 5*time.Second, 10*time.Millisecond)
 	assert.Nil(t, k.GetMetrics())
-	k.Stop()
 }
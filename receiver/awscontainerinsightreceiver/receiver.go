@@ -47,6 +47,10 @@ type awsContainerInsightReceiver struct {
 	k8sapiserver MetricsProvider
 }
 
+type stopper interface {
+	Stop()
+}
+
 // New creates the aws container insight receiver with the given parameters.
 func New(
 	logger *zap.Logger,
@@ -69,9 +73,13 @@ func (acir *awsContainerInsightReceiver) Start(ctx context.Context, host compone
 	ctx, acir.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, acir.config.ID(), "http"))
 	machineInfo := hostInfo.NewMachineInfo(acir.config.CollectionInterval, acir.logger)
 	acir.cadvisor = cadvisor.New(acir.config.ContainerOrchestrator, machineInfo, acir.logger)
-	acir.k8sapiserver = k8sapiserver.New(machineInfo, acir.logger)
-
-	// TODO: add more intialization code
+	k8sAPIServer, err := k8sapiserver.New(machineInfo, acir.logger)
+	if err != nil {
+		acir.logger.Warn("Failed to start k8sapiserver, cluster-level metrics will not be collected", zap.Error(err))
+	} else {
+		// only the elected leader generates cluster-level metrics
+		acir.k8sapiserver = k8sAPIServer
+	}
 
 	go func() {
 		ticker := time.NewTicker(acir.config.CollectionInterval)
@@ -93,6 +101,9 @@ func (acir *awsContainerInsightReceiver) Start(ctx context.Context, host compone
 // Shutdown stops the awsContainerInsightReceiver receiver.
 func (acir *awsContainerInsightReceiver) Shutdown(context.Context) error {
 	acir.cancel()
+	if s, ok := acir.k8sapiserver.(stopper); ok {
+		s.Stop()
+	}
 	return nil
 }
 
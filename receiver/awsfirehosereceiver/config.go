@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+var (
+	errMissingEndpoint = errors.New("'endpoint' must be set")
+)
+
+// Config defines configuration for the Kinesis Data Firehose receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+
+	// HTTPServerSettings is used to set up the Firehose HTTP endpoint, Firehose
+	// only delivers to HTTPS endpoints so TLS settings are usually required.
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// RecordType is the format of the records delivered by Firehose, either
+	// "cwmetrics" (CloudWatch metric streams in JSON) or "otlp_v1" (CloudWatch
+	// metric streams in OpenTelemetry 0.7.0). Defaults to "cwmetrics".
+	RecordType string `mapstructure:"record_type"`
+
+	// AccessKey, if set, must match the access key configured on the delivery
+	// stream, which Firehose sends in the X-Amz-Firehose-Access-Key header.
+	AccessKey string `mapstructure:"access_key"`
+}
+
+// Validate checks that the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errMissingEndpoint
+	}
+	if _, ok := unmarshalers[c.RecordType]; !ok {
+		return fmt.Errorf("unsupported 'record_type' %q", c.RecordType)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+const (
+	attributeMetricStreamName = "aws.cloudwatch.metric_stream_name"
+)
+
+var errNoValidCWMetric = errors.New("no valid CloudWatch metric in the records")
+
+// cwMetric is a single metric of a CloudWatch metric stream in the JSON format, see
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch-metric-streams-formats-json.html.
+type cwMetric struct {
+	MetricStreamName string            `json:"metric_stream_name"`
+	AccountID        string            `json:"account_id"`
+	Region           string            `json:"region"`
+	Namespace        string            `json:"namespace"`
+	MetricName       string            `json:"metric_name"`
+	Dimensions       map[string]string `json:"dimensions"`
+	// Timestamp is in milliseconds.
+	Timestamp int64          `json:"timestamp"`
+	Value     *cwMetricValue `json:"value"`
+	Unit      string         `json:"unit"`
+}
+
+// cwMetricValue holds the statistics of a metric over the aggregation period.
+type cwMetricValue struct {
+	Max   float64 `json:"max"`
+	Min   float64 `json:"min"`
+	Sum   float64 `json:"sum"`
+	Count float64 `json:"count"`
+}
+
+// cwResourceKey identifies the resource the metrics are reported for.
+type cwResourceKey struct {
+	metricStreamName string
+	accountID        string
+	region           string
+	namespace        string
+}
+
+// cwMetricKey identifies a metric within a resource.
+type cwMetricKey struct {
+	name string
+	unit string
+}
+
+// unmarshalCWMetrics converts records holding newline delimited CloudWatch metrics
+// in the JSON format into summaries, the min and max statistics being reported as
+// the 0 and 1 quantiles.
+func unmarshalCWMetrics(records [][]byte, logger *zap.Logger) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	resources := map[cwResourceKey]pdata.InstrumentationLibraryMetrics{}
+	metrics := map[cwResourceKey]map[cwMetricKey]pdata.Metric{}
+	// The details of the invalid metrics are only logged at the debug level,
+	// a summary is logged once per request.
+	var malformedRecords, invalidMetrics int
+
+	for recordIndex, record := range records {
+		decoder := json.NewDecoder(bytes.NewReader(record))
+		for {
+			var m cwMetric
+			err := decoder.Decode(&m)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				// The rest of the record can't be decoded once it is malformed.
+				logger.Debug("Unable to unmarshal CloudWatch metric", zap.Int("record_index", recordIndex), zap.Error(err))
+				malformedRecords++
+				break
+			}
+			if m.MetricName == "" || m.Value == nil {
+				logger.Debug("Skipping invalid CloudWatch metric", zap.Int("record_index", recordIndex), zap.String("metric_name", m.MetricName))
+				invalidMetrics++
+				continue
+			}
+
+			resourceKey := cwResourceKey{
+				metricStreamName: m.MetricStreamName,
+				accountID:        m.AccountID,
+				region:           m.Region,
+				namespace:        m.Namespace,
+			}
+			ilm, ok := resources[resourceKey]
+			if !ok {
+				rm := md.ResourceMetrics().AppendEmpty()
+				setCWResourceAttributes(rm.Resource(), resourceKey)
+				ilm = rm.InstrumentationLibraryMetrics().AppendEmpty()
+				resources[resourceKey] = ilm
+				metrics[resourceKey] = map[cwMetricKey]pdata.Metric{}
+			}
+
+			metricKey := cwMetricKey{name: m.MetricName, unit: m.Unit}
+			metric, ok := metrics[resourceKey][metricKey]
+			if !ok {
+				metric = ilm.Metrics().AppendEmpty()
+				metric.SetName(m.MetricName)
+				metric.SetUnit(m.Unit)
+				metric.SetDataType(pdata.MetricDataTypeSummary)
+				metrics[resourceKey][metricKey] = metric
+			}
+			appendCWDataPoint(metric.Summary(), &m)
+		}
+	}
+
+	if malformedRecords > 0 || invalidMetrics > 0 {
+		logger.Warn("Skipped invalid CloudWatch metrics",
+			zap.Int("records", len(records)),
+			zap.Int("malformed_records", malformedRecords),
+			zap.Int("invalid_metrics", invalidMetrics))
+	}
+
+	if len(resources) == 0 {
+		return md, errNoValidCWMetric
+	}
+	return md, nil
+}
+
+func setCWResourceAttributes(resource pdata.Resource, key cwResourceKey) {
+	attrs := resource.Attributes()
+	attrs.UpsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	attrs.UpsertString(conventions.AttributeCloudAccount, key.accountID)
+	attrs.UpsertString(conventions.AttributeCloudRegion, key.region)
+	attrs.UpsertString(attributeMetricStreamName, key.metricStreamName)
+
+	// Namespaces of AWS services look like "AWS/EC2".
+	if parts := strings.SplitN(key.namespace, "/", 2); len(parts) == 2 {
+		attrs.UpsertString(conventions.AttributeServiceNamespace, parts[0])
+		attrs.UpsertString(conventions.AttributeServiceName, parts[1])
+	} else {
+		attrs.UpsertString(conventions.AttributeServiceName, key.namespace)
+	}
+}
+
+func appendCWDataPoint(summary pdata.Summary, m *cwMetric) {
+	dp := summary.DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.TimestampFromTime(time.Unix(0, m.Timestamp*int64(time.Millisecond))))
+	dp.SetCount(uint64(m.Value.Count))
+	dp.SetSum(m.Value.Sum)
+
+	min := dp.QuantileValues().AppendEmpty()
+	min.SetQuantile(0)
+	min.SetValue(m.Value.Min)
+	max := dp.QuantileValues().AppendEmpty()
+	max.SetQuantile(1)
+	max.SetValue(m.Value.Max)
+
+	for k, v := range m.Dimensions {
+		dp.LabelsMap().Upsert(k, v)
+	}
+}
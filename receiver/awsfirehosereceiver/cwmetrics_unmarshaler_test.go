@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const (
+	cpuMetric = `{"metric_stream_name":"stream","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2",` +
+		`"metric_name":"CPUUtilization","dimensions":{"InstanceId":"i-123"},"timestamp":1611929698000,` +
+		`"value":{"max":90.5,"min":10.5,"sum":201,"count":4},"unit":"Percent"}`
+	otherCPUMetric = `{"metric_stream_name":"stream","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2",` +
+		`"metric_name":"CPUUtilization","dimensions":{"InstanceId":"i-456"},"timestamp":1611929698000,` +
+		`"value":{"max":50,"min":50,"sum":50,"count":1},"unit":"Percent"}`
+	customMetric = `{"metric_stream_name":"stream","account_id":"123456789012","region":"us-east-1","namespace":"Custom",` +
+		`"metric_name":"Requests","timestamp":1611929698000,"value":{"max":1,"min":1,"sum":3,"count":3},"unit":"Count"}`
+)
+
+func TestUnmarshalCWMetrics(t *testing.T) {
+	records := [][]byte{
+		[]byte(cpuMetric + "\n" + otherCPUMetric + "\n"),
+		[]byte(customMetric),
+	}
+	md, err := unmarshalCWMetrics(records, zap.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	rm := md.ResourceMetrics().At(0)
+	attrs := rm.Resource().Attributes()
+	assertAttribute(t, attrs, conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	assertAttribute(t, attrs, conventions.AttributeCloudAccount, "123456789012")
+	assertAttribute(t, attrs, conventions.AttributeCloudRegion, "us-east-1")
+	assertAttribute(t, attrs, conventions.AttributeServiceNamespace, "AWS")
+	assertAttribute(t, attrs, conventions.AttributeServiceName, "EC2")
+	assertAttribute(t, attrs, attributeMetricStreamName, "stream")
+
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	metric := metrics.At(0)
+	assert.Equal(t, "CPUUtilization", metric.Name())
+	assert.Equal(t, "Percent", metric.Unit())
+	require.Equal(t, pdata.MetricDataTypeSummary, metric.DataType())
+	require.Equal(t, 2, metric.Summary().DataPoints().Len())
+
+	dp := metric.Summary().DataPoints().At(0)
+	assert.Equal(t, pdata.TimestampFromTime(time.Unix(1611929698, 0)), dp.Timestamp())
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, 201.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.0, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 10.5, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 1.0, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 90.5, dp.QuantileValues().At(1).Value())
+	instanceID, _ := dp.LabelsMap().Get("InstanceId")
+	assert.Equal(t, "i-123", instanceID)
+
+	attrs = md.ResourceMetrics().At(1).Resource().Attributes()
+	assertAttribute(t, attrs, conventions.AttributeServiceName, "Custom")
+	_, ok := attrs.Get(conventions.AttributeServiceNamespace)
+	assert.False(t, ok)
+}
+
+func TestUnmarshalCWMetricsInvalid(t *testing.T) {
+	// The invalid metrics are skipped.
+	records := [][]byte{
+		[]byte(`{"metric_name":"NoValue"}` + "\n" + cpuMetric),
+		[]byte(`{"metric_name":`),
+	}
+	core, logs := observer.New(zapcore.InfoLevel)
+	md, err := unmarshalCWMetrics(records, zap.New(core))
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.MetricCount())
+
+	// A single summary is logged for the whole request.
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, zapcore.WarnLevel, entry.Level)
+	assert.Equal(t, map[string]interface{}{
+		"records":           int64(2),
+		"malformed_records": int64(1),
+		"invalid_metrics":   int64(1),
+	}, entry.ContextMap())
+
+	_, err = unmarshalCWMetrics([][]byte{[]byte("invalid")}, zap.NewNop())
+	assert.Equal(t, errNoValidCWMetric, err)
+}
+
+func assertAttribute(t *testing.T, attrs pdata.AttributeMap, key, expected string) {
+	value, ok := attrs.Get(key)
+	require.True(t, ok, key)
+	assert.Equal(t, expected, value.StringVal())
+}
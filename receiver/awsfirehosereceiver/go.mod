@@ -0,0 +1,9 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver
+
+go 1.15
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.26.1-0.20210513162346-453d1d0dd603
+	go.uber.org/zap v1.16.0
+)
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+var errNoValidOTLPRequest = errors.New("no valid OTLP request in the records")
+
+// unmarshalOTLPV1 converts records holding length delimited OTLP (0.7.0)
+// ExportMetricsServiceRequest messages, see
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch-metric-streams-formats-opentelemetry.html.
+// The messages already hold the cloud.* resource attributes.
+func unmarshalOTLPV1(records [][]byte, logger *zap.Logger) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	valid := false
+	for recordIndex, record := range records {
+		for pos := 0; pos < len(record); {
+			size, n := binary.Uvarint(record[pos:])
+			if n <= 0 || uint64(len(record)-pos-n) < size {
+				logger.Error("Invalid length of OTLP request", zap.Int("record_index", recordIndex))
+				break
+			}
+			pos += n
+
+			request, err := pdata.MetricsFromOtlpProtoBytes(record[pos : pos+int(size)])
+			pos += int(size)
+			if err != nil {
+				logger.Error("Unable to unmarshal OTLP request", zap.Int("record_index", recordIndex), zap.Error(err))
+				continue
+			}
+			request.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+			valid = true
+		}
+	}
+
+	if !valid {
+		return md, errNoValidOTLPRequest
+	}
+	return md, nil
+}
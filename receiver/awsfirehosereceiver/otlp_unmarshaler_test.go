@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func newOTLPRequest(t *testing.T, metricName string) []byte {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().UpsertString("cloud.provider", "aws")
+	metric := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(metricName)
+	metric.SetDataType(pdata.MetricDataTypeSummary)
+	metric.Summary().DataPoints().AppendEmpty().SetCount(1)
+
+	b, err := md.ToOtlpProtoBytes()
+	require.NoError(t, err)
+	return appendLengthDelimited(nil, b)
+}
+
+func appendLengthDelimited(record []byte, msg []byte) []byte {
+	size := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(size, uint64(len(msg)))
+	return append(append(record, size[:n]...), msg...)
+}
+
+func TestUnmarshalOTLPV1(t *testing.T) {
+	records := [][]byte{
+		append(newOTLPRequest(t, "first"), newOTLPRequest(t, "second")...),
+		newOTLPRequest(t, "third"),
+	}
+	md, err := unmarshalOTLPV1(records, zap.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, 3, md.ResourceMetrics().Len())
+	for i, name := range []string{"first", "second", "third"} {
+		assert.Equal(t, name, md.ResourceMetrics().At(i).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
+	}
+}
+
+func TestUnmarshalOTLPV1Invalid(t *testing.T) {
+	// The request with an invalid length is skipped.
+	records := [][]byte{
+		newOTLPRequest(t, "valid"),
+		appendLengthDelimited(nil, []byte{0x0a})[:1],
+	}
+	md, err := unmarshalOTLPV1(records, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.MetricCount())
+
+	_, err = unmarshalOTLPV1([][]byte{{0xff}}, zap.NewNop())
+	assert.Equal(t, errNoValidOTLPRequest, err)
+}
@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const (
+	transport = "http"
+
+	headerFirehoseRequestID = "X-Amz-Firehose-Request-Id"
+	headerFirehoseAccessKey = "X-Amz-Firehose-Access-Key"
+	headerContentType       = "Content-Type"
+	headerContentEncoding   = "Content-Encoding"
+)
+
+var (
+	errInvalidAccessKey  = errors.New("invalid firehose access key")
+	errMissingRequestID  = errors.New("missing firehose request id")
+	errRequestIDMismatch = errors.New("firehose request id in the body doesn't match the header")
+)
+
+// firehoseRequest is the body of a Firehose HTTP endpoint delivery request, see
+// https://docs.aws.amazon.com/firehose/latest/dev/httpdeliveryrequestresponse.html.
+type firehoseRequest struct {
+	RequestID string           `json:"requestId"`
+	Timestamp int64            `json:"timestamp"`
+	Records   []firehoseRecord `json:"records"`
+}
+
+type firehoseRecord struct {
+	// Data is base64 encoded.
+	Data string `json:"data"`
+}
+
+// firehoseResponse is the body of the response expected by Firehose.
+type firehoseResponse struct {
+	RequestID    string `json:"requestId"`
+	Timestamp    int64  `json:"timestamp"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// firehoseReceiver implements component.MetricsReceiver for the Kinesis Data
+// Firehose HTTP endpoint delivery.
+type firehoseReceiver struct {
+	config       *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Metrics
+	unmarshaler  recordUnmarshaler
+
+	server *http.Server
+	wg     sync.WaitGroup
+}
+
+var _ component.MetricsReceiver = (*firehoseReceiver)(nil)
+var _ http.Handler = (*firehoseReceiver)(nil)
+
+func newMetricsReceiver(config *Config, logger *zap.Logger, nextConsumer consumer.Metrics) (*firehoseReceiver, error) {
+	unmarshaler, ok := unmarshalers[config.RecordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported 'record_type' %q", config.RecordType)
+	}
+	return &firehoseReceiver{
+		config:       config,
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		unmarshaler:  unmarshaler,
+	}, nil
+}
+
+// Start starts the HTTP server receiving the Firehose delivery requests.
+func (r *firehoseReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+
+	r.server = r.config.HTTPServerSettings.ToServer(r)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if err := r.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			host.ReportFatalError(err)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *firehoseReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Close()
+	r.wg.Wait()
+	return err
+}
+
+// ServeHTTP handles a Firehose delivery request. Firehose retries the request
+// unless a 200 status code is returned.
+func (r *firehoseReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	requestID := req.Header.Get(headerFirehoseRequestID)
+	if req.Method != http.MethodPost {
+		r.sendResponse(w, requestID, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", req.Method))
+		return
+	}
+	if requestID == "" {
+		r.sendResponse(w, requestID, http.StatusBadRequest, errMissingRequestID)
+		return
+	}
+	if r.config.AccessKey != "" && !r.validAccessKey(req.Header.Get(headerFirehoseAccessKey)) {
+		r.sendResponse(w, requestID, http.StatusUnauthorized, errInvalidAccessKey)
+		return
+	}
+
+	records, err := r.decodeRecords(req, requestID)
+	if err != nil {
+		r.sendResponse(w, requestID, http.StatusBadRequest, err)
+		return
+	}
+	if len(records) == 0 {
+		r.sendResponse(w, requestID, http.StatusOK, nil)
+		return
+	}
+
+	md, err := r.unmarshaler(records, r.logger)
+	if err != nil {
+		r.sendResponse(w, requestID, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx := obsreport.ReceiverContext(req.Context(), r.config.ID(), transport)
+	ctx = obsreport.StartMetricsReceiveOp(ctx, r.config.ID(), transport)
+	_, numPoints := md.MetricAndDataPointCount()
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	obsreport.EndMetricsReceiveOp(ctx, r.config.RecordType, numPoints, err)
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		r.sendResponse(w, requestID, status, err)
+		return
+	}
+
+	r.sendResponse(w, requestID, http.StatusOK, nil)
+}
+
+// validAccessKey compares the access key of a request with the configured one
+// in constant time, so that it can't be guessed from the response times.
+func (r *firehoseReceiver) validAccessKey(accessKey string) bool {
+	return subtle.ConstantTimeCompare([]byte(accessKey), []byte(r.config.AccessKey)) == 1
+}
+
+// decodeRecords decodes the body of the request and returns the base64 decoded
+// data of its records.
+func (r *firehoseReceiver) decodeRecords(req *http.Request, requestID string) ([][]byte, error) {
+	body := io.Reader(req.Body)
+	if req.Header.Get(headerContentEncoding) == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	var fr firehoseRequest
+	if err := json.NewDecoder(body).Decode(&fr); err != nil {
+		return nil, err
+	}
+	if fr.RequestID != requestID {
+		return nil, errRequestIDMismatch
+	}
+
+	records := make([][]byte, 0, len(fr.Records))
+	for index, record := range fr.Records {
+		data, err := base64.StdEncoding.DecodeString(record.Data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode record %d: %w", index, err)
+		}
+		records = append(records, data)
+	}
+	return records, nil
+}
+
+func (r *firehoseReceiver) sendResponse(w http.ResponseWriter, requestID string, status int, err error) {
+	resp := firehoseResponse{
+		RequestID: requestID,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	if err != nil {
+		resp.ErrorMessage = err.Error()
+		r.logger.Debug("Firehose delivery request failed", zap.String("request_id", requestID), zap.Int("status", status), zap.Error(err))
+	}
+
+	w.Header().Set(headerContentType, "application/json")
+	w.WriteHeader(status)
+	if encodeErr := json.NewEncoder(w).Encode(resp); encodeErr != nil {
+		r.logger.Debug("Failed to write the firehose response", zap.Error(encodeErr))
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+)
+
+const testRequestID = "ed4acda5-034f-9f42-bba1-f29aea6d7d8f"
+
+func newTestReceiver(t *testing.T, nextConsumer consumer.Metrics) *firehoseReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AccessKey = "secret"
+	r, err := newMetricsReceiver(cfg, zap.NewNop(), nextConsumer)
+	require.NoError(t, err)
+	return r
+}
+
+func newFirehoseBody(t *testing.T, requestID string, records ...string) []byte {
+	fr := firehoseRequest{RequestID: requestID, Timestamp: 1578090901599}
+	for _, record := range records {
+		fr.Records = append(fr.Records, firehoseRecord{Data: base64.StdEncoding.EncodeToString([]byte(record))})
+	}
+	body, err := json.Marshal(fr)
+	require.NoError(t, err)
+	return body
+}
+
+func newFirehoseRequest(body []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(headerFirehoseRequestID, testRequestID)
+	req.Header.Set(headerFirehoseAccessKey, "secret")
+	req.Header.Set(headerContentType, "application/json")
+	return req
+}
+
+func serve(r *firehoseReceiver, req *http.Request) (int, firehoseResponse) {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp firehoseResponse
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	return w.Code, resp
+}
+
+func TestServeHTTP(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	r := newTestReceiver(t, sink)
+
+	status, resp := serve(r, newFirehoseRequest(newFirehoseBody(t, testRequestID, cpuMetric, customMetric)))
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, testRequestID, resp.RequestID)
+	assert.NotZero(t, resp.Timestamp)
+	assert.Empty(t, resp.ErrorMessage)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 2, sink.AllMetrics()[0].MetricCount())
+}
+
+func TestServeHTTPGzip(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	r := newTestReceiver(t, sink)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(newFirehoseBody(t, testRequestID, cpuMetric))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	req := newFirehoseRequest(buf.Bytes())
+	req.Header.Set(headerContentEncoding, "gzip")
+	status, _ := serve(r, req)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestServeHTTPErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  func() *http.Request
+		consumer consumer.Metrics
+		status   int
+	}{
+		{
+			name: "method_not_allowed",
+			request: func() *http.Request {
+				req := newFirehoseRequest(nil)
+				req.Method = http.MethodGet
+				return req
+			},
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "missing_request_id",
+			request: func() *http.Request {
+				req := newFirehoseRequest(newFirehoseBody(t, testRequestID, cpuMetric))
+				req.Header.Del(headerFirehoseRequestID)
+				return req
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "invalid_access_key",
+			request: func() *http.Request {
+				req := newFirehoseRequest(newFirehoseBody(t, testRequestID, cpuMetric))
+				req.Header.Set(headerFirehoseAccessKey, "invalid")
+				return req
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "request_id_mismatch",
+			request: func() *http.Request {
+				return newFirehoseRequest(newFirehoseBody(t, "other", cpuMetric))
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "invalid_body",
+			request: func() *http.Request {
+				return newFirehoseRequest([]byte("{"))
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "invalid_base64",
+			request: func() *http.Request {
+				return newFirehoseRequest([]byte(`{"requestId":"` + testRequestID + `","records":[{"data":"!"}]}`))
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "invalid_records",
+			request: func() *http.Request {
+				return newFirehoseRequest(newFirehoseBody(t, testRequestID, "invalid"))
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "consumer_error",
+			request: func() *http.Request {
+				return newFirehoseRequest(newFirehoseBody(t, testRequestID, cpuMetric))
+			},
+			consumer: consumertest.NewErr(errors.New("consumer error")),
+			status:   http.StatusServiceUnavailable,
+		},
+		{
+			name: "permanent_consumer_error",
+			request: func() *http.Request {
+				return newFirehoseRequest(newFirehoseBody(t, testRequestID, cpuMetric))
+			},
+			consumer: consumertest.NewErr(consumererror.Permanent(errors.New("consumer error"))),
+			status:   http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextConsumer := tt.consumer
+			if nextConsumer == nil {
+				nextConsumer = consumertest.NewNop()
+			}
+			r := newTestReceiver(t, nextConsumer)
+
+			status, resp := serve(r, tt.request())
+			assert.Equal(t, tt.status, status)
+			assert.NotEmpty(t, resp.ErrorMessage)
+		})
+	}
+}
+
+func TestStartShutdown(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r, err := newMetricsReceiver(cfg, zap.NewNop(), consumertest.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+cfg.Endpoint, bytes.NewReader(newFirehoseBody(t, testRequestID, cpuMetric)))
+	require.NoError(t, err)
+	req.Header.Set(headerFirehoseRequestID, testRequestID)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get(headerContentType))
+}
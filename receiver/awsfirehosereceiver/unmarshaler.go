@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	cwMetricsRecordType = "cwmetrics"
+	otlpV1RecordType    = "otlp_v1"
+)
+
+// recordUnmarshaler converts the decoded records of a Firehose delivery request
+// into metrics. Records, or the metrics within them, that can't be converted are
+// logged and skipped, an error is only returned if none of them could be converted.
+type recordUnmarshaler func(records [][]byte, logger *zap.Logger) (pdata.Metrics, error)
+
+var unmarshalers = map[string]recordUnmarshaler{
+	cwMetricsRecordType: unmarshalCWMetrics,
+	otlpV1RecordType:    unmarshalOTLPV1,
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"encoding/json"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// addEvents converts back the span events stored as metadata by the X-Ray
+// exporter, see awsxray.AWSXraySpanEventsMetadataNamespace.
+func addEvents(meta map[string]map[string]interface{}, span *pdata.Span) error {
+	raw, ok := meta[awsxray.AWSXraySpanEventsMetadataNamespace][awsxray.AWSXraySpanEventsMetadataKey]
+	if !ok {
+		return nil
+	}
+
+	// the metadata are unmarshalled as generic JSON values, so they have to
+	// be marshalled again to get the events.
+	val, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	var events []awsxray.SpanEventData
+	if err = json.Unmarshal(val, &events); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		event := span.Events().AppendEmpty()
+		if e.Name != nil {
+			event.SetName(*e.Name)
+		}
+		if e.Timestamp != nil {
+			event.SetTimestamp(floatSecToNanoEpoch(e.Timestamp))
+		}
+		attrs := event.Attributes()
+		addAnnotations(e.Attributes, &attrs)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+func addLinks(links []awsxray.SpanLinkData, span *pdata.Span) error {
+	for _, l := range links {
+		traceID, err := decodeXRayTraceID(l.TraceID)
+		if err != nil {
+			return err
+		}
+		spanID, err := decodeXRaySpanID(l.SpanID)
+		if err != nil {
+			return err
+		}
+
+		link := span.Links().AppendEmpty()
+		link.SetTraceID(pdata.NewTraceID(traceID))
+		link.SetSpanID(pdata.NewSpanID(spanID))
+		attrs := link.Attributes()
+		addAnnotations(l.Attributes, &attrs)
+	}
+	return nil
+}
@@ -24,6 +24,14 @@ import (
 
 func addMetadata(meta map[string]map[string]interface{}, attrs *pdata.AttributeMap) error {
 	for k, v := range meta {
+		if k == awsxray.AWSXraySpanEventsMetadataNamespace {
+			// the span events are converted back by addEvents, the rest of
+			// the namespace is kept as is.
+			v = withoutEvents(v)
+			if len(v) == 0 {
+				continue
+			}
+		}
 		val, err := json.Marshal(v)
 		if err != nil {
 			return err
@@ -33,3 +41,16 @@ func addMetadata(meta map[string]map[string]interface{}, attrs *pdata.AttributeM
 	}
 	return nil
 }
+
+func withoutEvents(meta map[string]interface{}) map[string]interface{} {
+	if _, ok := meta[awsxray.AWSXraySpanEventsMetadataKey]; !ok {
+		return meta
+	}
+	filtered := make(map[string]interface{}, len(meta)-1)
+	for k, v := range meta {
+		if k != awsxray.AWSXraySpanEventsMetadataKey {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	exportertranslator "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter/translator"
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// roundTrip converts the span into a segment document with the X-Ray exporter
+// translator and back into a span.
+func roundTrip(t *testing.T, span pdata.Span, resource pdata.Resource) pdata.Span {
+	doc, err := exportertranslator.MakeSegmentDocumentString(span, resource, nil, false)
+	require.NoError(t, err)
+
+	traces, count, err := ToTraces([]byte(doc))
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	return traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+}
+
+func newRoundTripSpan(kind pdata.SpanKind) pdata.Span {
+	now := time.Now()
+	span := pdata.NewSpan()
+	span.SetTraceID(newTestTraceID(now, 1))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetParentSpanID(pdata.NewSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+	span.SetName("operation")
+	span.SetKind(kind)
+	span.SetStartTimestamp(pdata.TimestampFromTime(now.Add(-time.Second)))
+	span.SetEndTimestamp(pdata.TimestampFromTime(now))
+	return span
+}
+
+func newTestTraceID(epoch time.Time, id byte) pdata.TraceID {
+	var traceID [16]byte
+	binary.BigEndian.PutUint32(traceID[0:4], uint32(epoch.Unix()))
+	traceID[15] = id
+	return pdata.NewTraceID(traceID)
+}
+
+func TestRoundTripLinks(t *testing.T) {
+	span := newRoundTripSpan(pdata.SpanKindSERVER)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(newTestTraceID(time.Now(), 2))
+	link.SetSpanID(pdata.NewSpanID([8]byte{0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0, 1}))
+	link.Attributes().InsertString("messaging.message_id", "a1b2c3")
+
+	actual := roundTrip(t, span, pdata.NewResource())
+
+	require.Equal(t, 1, actual.Links().Len())
+	actualLink := actual.Links().At(0)
+	assert.Equal(t, link.TraceID(), actualLink.TraceID())
+	assert.Equal(t, link.SpanID(), actualLink.SpanID())
+	assert.Equal(t, link.Attributes().Sort(), actualLink.Attributes().Sort())
+}
+
+func TestRoundTripEvents(t *testing.T) {
+	span := newRoundTripSpan(pdata.SpanKindSERVER)
+	event := span.Events().AppendEmpty()
+	event.SetName("cache miss")
+	event.SetTimestamp(span.StartTimestamp())
+	event.Attributes().InsertString("cache.key", "users")
+	event.Attributes().InsertBool("cache.cold", true)
+
+	actual := roundTrip(t, span, pdata.NewResource())
+
+	require.Equal(t, 1, actual.Events().Len())
+	actualEvent := actual.Events().At(0)
+	assert.Equal(t, "cache miss", actualEvent.Name())
+	// the timestamps are converted to float seconds by X-Ray.
+	assert.InDelta(t, float64(event.Timestamp()), float64(actualEvent.Timestamp()), float64(time.Microsecond))
+	assert.Equal(t, event.Attributes().Sort(), actualEvent.Attributes().Sort())
+
+	// the events are not kept as metadata attributes.
+	_, ok := actual.Attributes().Get(awsxray.AWSXraySegmentMetadataAttributePrefix + awsxray.AWSXraySpanEventsMetadataNamespace)
+	assert.False(t, ok)
+}
+
+func TestRoundTripSQSProducer(t *testing.T) {
+	span := newRoundTripSpan(pdata.SpanKindPRODUCER)
+	span.Attributes().InsertString(conventions.AttributeMessagingSystem, "AmazonSQS")
+	span.Attributes().InsertString(conventions.AttributeMessagingURL, "https://sqs.us-east-1.amazonaws.com/123456789012/orders")
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+
+	actual := roundTrip(t, span, resource)
+
+	// the remote subsegments are received as client spans.
+	assert.Equal(t, "SQS", actual.Name())
+	assert.Equal(t, pdata.SpanKindCLIENT, actual.Kind())
+	assert.Equal(t, span.ParentSpanID(), actual.ParentSpanID())
+	assertStringAttribute(t, actual.Attributes(), awsxray.AWSServiceAttribute, "SQS")
+	assertStringAttribute(t, actual.Attributes(), awsxray.AWSOperationAttribute, "SendMessage")
+	assertStringAttribute(t, actual.Attributes(), awsxray.AWSQueueURLAttribute, "https://sqs.us-east-1.amazonaws.com/123456789012/orders")
+}
+
+func TestRoundTripAWSAPIClient(t *testing.T) {
+	span := newRoundTripSpan(pdata.SpanKindCLIENT)
+	span.Attributes().InsertString(conventions.AttributeRPCSystem, "aws-api")
+	span.Attributes().InsertString(conventions.AttributeRPCService, "DynamoDB")
+	span.Attributes().InsertString(conventions.AttributeRPCMethod, "GetItem")
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+
+	actual := roundTrip(t, span, resource)
+
+	assert.Equal(t, "DynamoDB", actual.Name())
+	assertStringAttribute(t, actual.Attributes(), awsxray.AWSServiceAttribute, "DynamoDB")
+	assertStringAttribute(t, actual.Attributes(), awsxray.AWSOperationAttribute, "GetItem")
+}
+
+func TestRoundTripKafkaConsumer(t *testing.T) {
+	span := newRoundTripSpan(pdata.SpanKindCONSUMER)
+	span.Attributes().InsertString(conventions.AttributeMessagingSystem, "kafka")
+	span.Attributes().InsertString(conventions.AttributeMessagingDestination, "my-topic")
+
+	actual := roundTrip(t, span, pdata.NewResource())
+
+	assert.Equal(t, "my-topic", actual.Name())
+	assert.Equal(t, pdata.SpanKindCLIENT, actual.Kind())
+	_, ok := actual.Attributes().Get(awsxray.AWSServiceAttribute)
+	assert.False(t, ok)
+}
+
+func assertStringAttribute(t *testing.T, attrs pdata.AttributeMap, key, expected string) {
+	value, ok := attrs.Get(key)
+	require.True(t, ok, key)
+	assert.Equal(t, expected, value.StringVal())
+}
@@ -197,7 +197,11 @@ func populateSpan(
 	addAnnotations(seg.Annotations, &attrs)
 	addMetadata(seg.Metadata, &attrs)
 
-	return nil
+	err = addEvents(seg.Metadata, span)
+	if err != nil {
+		return err
+	}
+	return addLinks(seg.Links, span)
 }
 
 func populateResource(seg *awsxray.Segment, rs *pdata.Resource) {
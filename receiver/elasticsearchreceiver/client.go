@@ -0,0 +1,84 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchreceiver
+
+// clusterHealth is the response of /_cluster/health.
+type clusterHealth struct {
+	ClusterName         string `json:"cluster_name"`
+	Status              string `json:"status"`
+	NumberOfNodes       int64  `json:"number_of_nodes"`
+	NumberOfDataNodes   int64  `json:"number_of_data_nodes"`
+	ActivePrimaryShards int64  `json:"active_primary_shards"`
+	ActiveShards        int64  `json:"active_shards"`
+	RelocatingShards    int64  `json:"relocating_shards"`
+	InitializingShards  int64  `json:"initializing_shards"`
+	UnassignedShards    int64  `json:"unassigned_shards"`
+	PendingTasks        int64  `json:"number_of_pending_tasks"`
+}
+
+// nodesStats is the response of /_nodes/stats, whose nodes are keyed by
+// their ID.
+type nodesStats struct {
+	Nodes map[string]nodeStats `json:"nodes"`
+}
+
+type nodeStats struct {
+	Name string `json:"name"`
+	JVM  struct {
+		Mem struct {
+			HeapUsedInBytes int64 `json:"heap_used_in_bytes"`
+			HeapMaxInBytes  int64 `json:"heap_max_in_bytes"`
+		} `json:"mem"`
+		GC struct {
+			Collectors map[string]struct {
+				CollectionCount        int64 `json:"collection_count"`
+				CollectionTimeInMillis int64 `json:"collection_time_in_millis"`
+			} `json:"collectors"`
+		} `json:"gc"`
+	} `json:"jvm"`
+	ThreadPool map[string]struct {
+		Rejected int64 `json:"rejected"`
+	} `json:"thread_pool"`
+	Indices struct {
+		Indexing struct {
+			IndexTotal        int64 `json:"index_total"`
+			IndexTimeInMillis int64 `json:"index_time_in_millis"`
+		} `json:"indexing"`
+		Search struct {
+			QueryTotal        int64 `json:"query_total"`
+			QueryTimeInMillis int64 `json:"query_time_in_millis"`
+			FetchTotal        int64 `json:"fetch_total"`
+			FetchTimeInMillis int64 `json:"fetch_time_in_millis"`
+		} `json:"search"`
+	} `json:"indices"`
+}
+
+// indicesStats is the response of /_stats, whose indices are keyed by their
+// name.
+type indicesStats struct {
+	Indices map[string]struct {
+		Primaries struct {
+			Docs struct {
+				Count   int64 `json:"count"`
+				Deleted int64 `json:"deleted"`
+			} `json:"docs"`
+		} `json:"primaries"`
+		Total struct {
+			Store struct {
+				SizeInBytes int64 `json:"size_in_bytes"`
+			} `json:"store"`
+		} `json:"total"`
+	} `json:"indices"`
+}
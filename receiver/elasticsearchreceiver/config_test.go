@@ -0,0 +1,37 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+func TestValidate(t *testing.T) {
+	newConfig := func(endpoint string) *Config {
+		return &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: endpoint,
+			},
+		}
+	}
+
+	require.NoError(t, newConfig("http://localhost:9200").Validate())
+	require.NoError(t, newConfig("https://elasticsearch:9200/").Validate())
+	require.EqualError(t, newConfig("localhost:9200").Validate(), "endpoint must be an http or https URL")
+	require.EqualError(t, newConfig("http://local host:9200").Validate(), `invalid endpoint "http://local host:9200": parse "http://local host:9200": invalid character " " in host name`)
+}
@@ -0,0 +1,167 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/jsonclient"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/elasticsearchreceiver/internal/metadata"
+)
+
+// clusterNameAttribute is the resource attribute holding the name of the
+// cluster the metrics belong to.
+const clusterNameAttribute = "elasticsearch.cluster.name"
+
+const (
+	clusterHealthPath = "/_cluster/health"
+	nodesStatsPath    = "/_nodes/stats/jvm,thread_pool,indices"
+	indicesStatsPath  = "/_stats/docs,store"
+)
+
+// clusterStatuses are the health statuses a cluster can be in.
+var clusterStatuses = []string{"green", "yellow", "red"}
+
+type elasticsearchScraper struct {
+	client *jsonclient.Client
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newElasticsearchScraper(
+	logger *zap.Logger,
+	cfg *Config,
+) *elasticsearchScraper {
+	return &elasticsearchScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// scrape reports the health of the cluster along with the statistics of its
+// nodes and, when enabled, of its indices. Scraping fails if the health of
+// the cluster can't be fetched, while failing to fetch the statistics of the
+// nodes or of the indices only fails their metrics.
+func (r *elasticsearchScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	// Init client in scrape method in case there are transient errors in the
+	// constructor.
+	if r.client == nil {
+		httpClient, err := r.cfg.ToClient()
+		if err != nil {
+			return pdata.ResourceMetricsSlice{}, err
+		}
+		r.client = jsonclient.New(httpClient, r.cfg.Endpoint, r.cfg.Username, r.cfg.Password)
+	}
+
+	var health clusterHealth
+	if err := r.client.Get(ctx, clusterHealthPath, &health); err != nil {
+		r.logger.Error("Failed to fetch Elasticsearch cluster health", zap.Error(err))
+		return pdata.ResourceMetricsSlice{}, err
+	}
+
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/elasticsearch",
+	}
+
+	for _, status := range clusterStatuses {
+		var value int64
+		if status == health.Status {
+			value = 1
+		}
+		metrics.WithLabels(map[string]string{metadata.L.Status: status}).
+			AddGaugeDataPoint(metadata.M.ElasticsearchClusterStatus.Name(), value)
+	}
+	metrics.AddGaugeDataPoint(metadata.M.ElasticsearchClusterNodes.Name(), health.NumberOfNodes)
+	metrics.AddGaugeDataPoint(metadata.M.ElasticsearchClusterDataNodes.Name(), health.NumberOfDataNodes)
+	for state, value := range map[string]int64{
+		"active":       health.ActiveShards,
+		"relocating":   health.RelocatingShards,
+		"initializing": health.InitializingShards,
+		"unassigned":   health.UnassignedShards,
+	} {
+		metrics.WithLabels(map[string]string{metadata.L.ShardState: state}).
+			AddGaugeDataPoint(metadata.M.ElasticsearchClusterShards.Name(), value)
+	}
+	metrics.AddGaugeDataPoint(metadata.M.ElasticsearchClusterPrimaryShards.Name(), health.ActivePrimaryShards)
+	metrics.AddGaugeDataPoint(metadata.M.ElasticsearchClusterPendingTasks.Name(), health.PendingTasks)
+
+	var scrapeErrors scrapererror.ScrapeErrors
+
+	var nodes nodesStats
+	if err := r.client.Get(ctx, nodesStatsPath, &nodes); err != nil {
+		r.logger.Error("Failed to fetch Elasticsearch nodes stats", zap.Error(err))
+		scrapeErrors.AddPartial(7, err)
+	}
+	for _, node := range nodes.Nodes {
+		nodeMetrics := metrics.WithLabels(map[string]string{metadata.L.Node: node.Name})
+
+		nodeMetrics.AddGaugeDataPoint(metadata.M.ElasticsearchNodeJvmMemoryHeapUsed.Name(), node.JVM.Mem.HeapUsedInBytes)
+		nodeMetrics.AddGaugeDataPoint(metadata.M.ElasticsearchNodeJvmMemoryHeapMax.Name(), node.JVM.Mem.HeapMaxInBytes)
+		for name, collector := range node.JVM.GC.Collectors {
+			collectorMetrics := nodeMetrics.WithLabels(map[string]string{metadata.L.GcCollector: name})
+			collectorMetrics.AddSumDataPoint(metadata.M.ElasticsearchNodeJvmGcCollections.Name(), collector.CollectionCount)
+			collectorMetrics.AddSumDataPoint(metadata.M.ElasticsearchNodeJvmGcCollectionTime.Name(), collector.CollectionTimeInMillis)
+		}
+
+		for name, pool := range node.ThreadPool {
+			nodeMetrics.WithLabels(map[string]string{metadata.L.ThreadPool: name}).
+				AddSumDataPoint(metadata.M.ElasticsearchNodeThreadPoolRejected.Name(), pool.Rejected)
+		}
+
+		for _, op := range []struct {
+			operation string
+			total     int64
+			time      int64
+		}{
+			{"index", node.Indices.Indexing.IndexTotal, node.Indices.Indexing.IndexTimeInMillis},
+			{"query", node.Indices.Search.QueryTotal, node.Indices.Search.QueryTimeInMillis},
+			{"fetch", node.Indices.Search.FetchTotal, node.Indices.Search.FetchTimeInMillis},
+		} {
+			opMetrics := nodeMetrics.WithLabels(map[string]string{metadata.L.Operation: op.operation})
+			opMetrics.AddSumDataPoint(metadata.M.ElasticsearchNodeOperations.Name(), op.total)
+			opMetrics.AddSumDataPoint(metadata.M.ElasticsearchNodeOperationsTime.Name(), op.time)
+		}
+	}
+
+	if r.cfg.IncludeIndexStats {
+		var indices indicesStats
+		if err := r.client.Get(ctx, indicesStatsPath, &indices); err != nil {
+			r.logger.Error("Failed to fetch Elasticsearch indices stats", zap.Error(err))
+			scrapeErrors.AddPartial(2, err)
+		}
+		for name, index := range indices.Indices {
+			indexMetrics := metrics.WithLabels(map[string]string{metadata.L.Index: name})
+			indexMetrics.WithLabels(map[string]string{metadata.L.DocumentState: "active"}).
+				AddGaugeDataPoint(metadata.M.ElasticsearchIndexDocuments.Name(), index.Primaries.Docs.Count)
+			indexMetrics.WithLabels(map[string]string{metadata.L.DocumentState: "deleted"}).
+				AddGaugeDataPoint(metadata.M.ElasticsearchIndexDocuments.Name(), index.Primaries.Docs.Deleted)
+			indexMetrics.AddGaugeDataPoint(metadata.M.ElasticsearchIndexStoreSize.Name(), index.Total.Store.SizeInBytes)
+		}
+	}
+
+	rms := metrics.Metrics.ResourceMetrics()
+	rms.At(0).Resource().Attributes().InsertString(clusterNameAttribute, health.ClusterName)
+	return rms, scrapeErrors.Combine()
+}
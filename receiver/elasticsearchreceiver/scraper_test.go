@@ -0,0 +1,240 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchreceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+)
+
+// newElasticsearchMock serves the recorded responses of Elasticsearch to the
+// elastic user, except for the paths that fail with the given status code.
+func newElasticsearchMock(t *testing.T, failures map[string]int) *httptest.Server {
+	responses := map[string][]byte{}
+	for path, file := range map[string]string{
+		clusterHealthPath: "cluster_health.json",
+		nodesStatsPath:    "nodes_stats.json",
+		indicesStatsPath:  "indices_stats.json",
+	} {
+		response, err := ioutil.ReadFile(filepath.Join("testdata", file))
+		require.NoError(t, err)
+		responses[path] = response
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if username, password, ok := req.BasicAuth(); !ok || username != "elastic" || password != "changeme" {
+			rw.WriteHeader(401)
+			return
+		}
+		if statusCode, ok := failures[req.URL.Path]; ok {
+			rw.WriteHeader(statusCode)
+			return
+		}
+		if response, ok := responses[req.URL.Path]; ok {
+			rw.WriteHeader(200)
+			_, _ = rw.Write(response)
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+}
+
+func newTestScraper(endpoint string, includeIndexStats bool) *elasticsearchScraper {
+	return newElasticsearchScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: endpoint,
+		},
+		Username:          "elastic",
+		Password:          "changeme",
+		IncludeIndexStats: includeIndexStats,
+	})
+}
+
+func TestScraper(t *testing.T) {
+	elasticsearchMock := newElasticsearchMock(t, nil)
+	defer elasticsearchMock.Close()
+
+	for _, endpoint := range []string{elasticsearchMock.URL, elasticsearchMock.URL + "/"} {
+		t.Run(endpoint, func(t *testing.T) {
+			rms, err := newTestScraper(endpoint, true).scrape(context.Background())
+			require.NoError(t, err)
+
+			clusterName, ok := rms.At(0).Resource().Attributes().Get(clusterNameAttribute)
+			require.True(t, ok)
+			require.Equal(t, "logs", clusterName.StringVal())
+
+			require.Equal(t, 1, rms.Len())
+			rm := rms.At(0)
+
+			ilms := rm.InstrumentationLibraryMetrics()
+			require.Equal(t, 1, ilms.Len())
+
+			ilm := ilms.At(0)
+			ms := ilm.Metrics()
+
+			require.Equal(t, 15, ms.Len())
+
+			// The data points are keyed by the metric name followed by their
+			// sorted labels.
+			values := make(map[string]int64, 45)
+
+			for i := 0; i < ms.Len(); i++ {
+				m := ms.At(i)
+
+				var dps pdata.IntDataPointSlice
+
+				switch m.DataType() {
+				case pdata.MetricDataTypeIntGauge:
+					dps = m.IntGauge().DataPoints()
+				case pdata.MetricDataTypeIntSum:
+					dps = m.IntSum().DataPoints()
+				}
+
+				for j := 0; j < dps.Len(); j++ {
+					var labels []string
+					dps.At(j).LabelsMap().Range(func(k string, v string) bool {
+						labels = append(labels, k+"="+v)
+						return true
+					})
+					sort.Strings(labels)
+					values[strings.Join(append([]string{m.Name()}, labels...), " ")] = dps.At(j).Value()
+				}
+			}
+			require.Len(t, values, 45)
+
+			for key, value := range map[string]int64{
+				"elasticsearch.cluster.status status=green":                                  0,
+				"elasticsearch.cluster.status status=yellow":                                 1,
+				"elasticsearch.cluster.status status=red":                                    0,
+				"elasticsearch.cluster.nodes":                                                2,
+				"elasticsearch.cluster.data_nodes":                                           2,
+				"elasticsearch.cluster.shards shard_state=active":                            11,
+				"elasticsearch.cluster.shards shard_state=relocating":                        0,
+				"elasticsearch.cluster.shards shard_state=initializing":                      0,
+				"elasticsearch.cluster.shards shard_state=unassigned":                        1,
+				"elasticsearch.cluster.primary_shards":                                       6,
+				"elasticsearch.cluster.pending_tasks":                                        2,
+				"elasticsearch.node.jvm.memory.heap.used node=es-0":                          412315648,
+				"elasticsearch.node.jvm.memory.heap.max node=es-0":                           1073741824,
+				"elasticsearch.node.jvm.gc.collections gc_collector=young node=es-0":         412,
+				"elasticsearch.node.jvm.gc.collection_time gc_collector=old node=es-0":       421,
+				"elasticsearch.node.thread_pool.rejected node=es-0 thread_pool=search":       12,
+				"elasticsearch.node.thread_pool.rejected node=es-1 thread_pool=write":        3,
+				"elasticsearch.node.operations node=es-0 operation=index":                    186315,
+				"elasticsearch.node.operations.time node=es-0 operation=index":               52148,
+				"elasticsearch.node.operations node=es-1 operation=query":                    3980,
+				"elasticsearch.node.operations.time node=es-1 operation=query":               9102,
+				"elasticsearch.node.operations node=es-1 operation=fetch":                    3811,
+				"elasticsearch.node.operations.time node=es-1 operation=fetch":               587,
+				"elasticsearch.index.documents document_state=active index=logs-2021.05.12":  240312,
+				"elasticsearch.index.documents document_state=deleted index=logs-2021.05.13": 42,
+				"elasticsearch.index.store.size index=logs-2021.05.13":                       120332288,
+			} {
+				if assert.Contains(t, values, key) {
+					assert.Equal(t, value, values[key], key)
+				}
+			}
+		})
+	}
+}
+
+func TestScraperWithoutIndexStats(t *testing.T) {
+	elasticsearchMock := newElasticsearchMock(t, map[string]int{indicesStatsPath: 500})
+	defer elasticsearchMock.Close()
+
+	rms, err := newTestScraper(elasticsearchMock.URL, false).scrape(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, rms.Len())
+	ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 13, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		require.False(t, strings.HasPrefix(ms.At(i).Name(), "elasticsearch.index."), ms.At(i).Name())
+	}
+}
+
+func TestScraperError(t *testing.T) {
+	t.Run("unauthorized", func(t *testing.T) {
+		elasticsearchMock := newElasticsearchMock(t, nil)
+		defer elasticsearchMock.Close()
+
+		sc := newTestScraper(elasticsearchMock.URL, true)
+		sc.cfg.Password = "wrong"
+		_, err := sc.scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response from /_cluster/health, got 401")
+		require.False(t, scrapererror.IsPartialScrapeError(err))
+	})
+
+	t.Run("cluster health", func(t *testing.T) {
+		elasticsearchMock := newElasticsearchMock(t, map[string]int{clusterHealthPath: 503})
+		defer elasticsearchMock.Close()
+
+		_, err := newTestScraper(elasticsearchMock.URL, true).scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response from /_cluster/health, got 503")
+		require.False(t, scrapererror.IsPartialScrapeError(err))
+	})
+
+	t.Run("nodes stats", func(t *testing.T) {
+		elasticsearchMock := newElasticsearchMock(t, map[string]int{nodesStatsPath: 500})
+		defer elasticsearchMock.Close()
+
+		rms, err := newTestScraper(elasticsearchMock.URL, true).scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response from /_nodes/stats/jvm,thread_pool,indices, got 500")
+		require.True(t, scrapererror.IsPartialScrapeError(err))
+
+		// The metrics of the cluster and of the indices are still reported.
+		require.Equal(t, 1, rms.Len())
+		ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+		var names []string
+		for i := 0; i < ms.Len(); i++ {
+			names = append(names, ms.At(i).Name())
+		}
+		require.Len(t, names, 8)
+		require.Contains(t, names, "elasticsearch.index.documents")
+		require.NotContains(t, names, "elasticsearch.node.jvm.memory.heap.used")
+	})
+
+	t.Run("indices stats", func(t *testing.T) {
+		elasticsearchMock := newElasticsearchMock(t, map[string]int{indicesStatsPath: 500})
+		defer elasticsearchMock.Close()
+
+		rms, err := newTestScraper(elasticsearchMock.URL, true).scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response from /_stats/docs,store, got 500")
+		require.True(t, scrapererror.IsPartialScrapeError(err))
+
+		require.Equal(t, 1, rms.Len())
+		ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+		var names []string
+		for i := 0; i < ms.Len(); i++ {
+			names = append(names, ms.At(i).Name())
+		}
+		require.Len(t, names, 13)
+		require.Contains(t, names, "elasticsearch.node.jvm.memory.heap.used")
+		require.NotContains(t, names, "elasticsearch.index.documents")
+	})
+}
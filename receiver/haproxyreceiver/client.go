@@ -0,0 +1,200 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// statsClient fetches the statistics of an HAProxy instance.
+type statsClient interface {
+	// stats returns the rows of the stats CSV, keyed by column name.
+	stats(ctx context.Context) ([]map[string]string, error)
+	// info returns the information about the HAProxy process, or nil if
+	// the client can't fetch it.
+	info(ctx context.Context) (map[string]string, error)
+}
+
+func isHTTPEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://")
+}
+
+func newStatsClient(cfg *Config) (statsClient, error) {
+	if !isHTTPEndpoint(cfg.Endpoint) {
+		return &socketClient{
+			path:    strings.TrimPrefix(cfg.Endpoint, "unix://"),
+			timeout: cfg.Timeout,
+		}, nil
+	}
+
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	// The stats page is exported as CSV when its URI is suffixed with ;csv.
+	if !strings.Contains(u.Path, ";csv") {
+		u.Path += ";csv"
+	}
+
+	client, err := cfg.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &httpClient{client: client, endpoint: u.String()}, nil
+}
+
+// socketClient runs commands on the HAProxy stats socket.
+type socketClient struct {
+	path    string
+	timeout time.Duration
+}
+
+var _ statsClient = (*socketClient)(nil)
+
+func (c *socketClient) stats(ctx context.Context) ([]map[string]string, error) {
+	out, err := c.command(ctx, "show stat")
+	if err != nil {
+		return nil, err
+	}
+	return parseStats(out)
+}
+
+func (c *socketClient) info(ctx context.Context) (map[string]string, error) {
+	out, err := c.command(ctx, "show info")
+	if err != nil {
+		return nil, err
+	}
+	return parseInfo(out), nil
+}
+
+// command sends a command to the stats socket and returns its output. The
+// socket is closed by HAProxy once the output has been written.
+func (c *socketClient) command(ctx context.Context, command string) ([]byte, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", c.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the stats socket: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err = conn.Write([]byte(command + "\n")); err != nil {
+		return nil, fmt.Errorf("failed to send %q to the stats socket: %w", command, err)
+	}
+
+	out, err := ioutil.ReadAll(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the output of %q from the stats socket: %w", command, err)
+	}
+	return out, nil
+}
+
+// httpClient fetches the stats page exported as CSV.
+type httpClient struct {
+	client   *http.Client
+	endpoint string
+}
+
+var _ statsClient = (*httpClient)(nil)
+
+func (c *httpClient) stats(ctx context.Context) ([]map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expected 200 response, got %d", resp.StatusCode)
+	}
+
+	out, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseStats(out)
+}
+
+// info returns nil as the process information isn't part of the stats page.
+func (c *httpClient) info(context.Context) (map[string]string, error) {
+	return nil, nil
+}
+
+// parseStats parses the stats CSV, whose header line is prefixed with "# ".
+func parseStats(out []byte) ([]map[string]string, error) {
+	if !bytes.HasPrefix(out, []byte("# ")) {
+		return nil, errors.New("stats output doesn't start with a CSV header")
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(out[2:])).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stats: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("stats output doesn't start with a CSV header")
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseInfo parses the "Name: value" lines output by "show info".
+func parseInfo(out []byte) map[string]string {
+	info := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		info[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return info
+}
@@ -0,0 +1,45 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	// Endpoint is either the URL of the HAProxy stats page, or the path of
+	// the HAProxy stats socket optionally prefixed with unix://.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+}
+
+// Validate checks that the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+
+	if isHTTPEndpoint(c.Endpoint) {
+		if _, err := url.Parse(c.Endpoint); err != nil {
+			return fmt.Errorf("invalid endpoint %q: %w", c.Endpoint, err)
+		}
+	}
+	return nil
+}
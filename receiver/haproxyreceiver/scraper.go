@@ -0,0 +1,184 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/haproxyreceiver/internal/metadata"
+)
+
+// serviceTypes maps the values of the type column of the stats to the
+// service_type label. Listeners aren't reported.
+var serviceTypes = map[string]string{
+	"0": "frontend",
+	"1": "backend",
+	"2": "server",
+}
+
+// statsMetrics maps the columns of the stats to the metric they are
+// reported as.
+var statsMetrics = []struct {
+	column string
+	metric metadata.MetricIntf
+}{
+	{"scur", metadata.M.HaproxySessionsCurrent},
+	{"stot", metadata.M.HaproxySessionsTotal},
+	{"rate", metadata.M.HaproxySessionsRate},
+	{"bin", metadata.M.HaproxyBytesInput},
+	{"bout", metadata.M.HaproxyBytesOutput},
+	{"qcur", metadata.M.HaproxyRequestsQueued},
+	{"req_tot", metadata.M.HaproxyRequestsTotal},
+	{"dreq", metadata.M.HaproxyRequestsDenied},
+	{"ereq", metadata.M.HaproxyRequestsErrors},
+	{"dresp", metadata.M.HaproxyResponsesDenied},
+	{"eresp", metadata.M.HaproxyResponsesErrors},
+	{"econ", metadata.M.HaproxyConnectionsErrors},
+	{"wretr", metadata.M.HaproxyConnectionsRetries},
+	{"wredis", metadata.M.HaproxyConnectionsRedispatched},
+}
+
+// statusCodeColumns maps the status_code label to the column of the stats
+// holding the number of responses with that class of status code.
+var statusCodeColumns = []struct {
+	statusCode string
+	column     string
+}{
+	{"1xx", "hrsp_1xx"},
+	{"2xx", "hrsp_2xx"},
+	{"3xx", "hrsp_3xx"},
+	{"4xx", "hrsp_4xx"},
+	{"5xx", "hrsp_5xx"},
+	{"other", "hrsp_other"},
+}
+
+// infoMetrics maps the fields of the process information to the metric
+// they are reported as.
+var infoMetrics = []struct {
+	field  string
+	metric metadata.MetricIntf
+}{
+	{"CurrConns", metadata.M.HaproxyProcessConnectionsCurrent},
+	{"CumConns", metadata.M.HaproxyProcessConnectionsTotal},
+	{"CumReq", metadata.M.HaproxyProcessRequestsTotal},
+}
+
+type haproxyScraper struct {
+	client statsClient
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newHAProxyScraper(
+	logger *zap.Logger,
+	cfg *Config,
+) *haproxyScraper {
+	return &haproxyScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *haproxyScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	// Init client in scrape method in case there are transient errors in the
+	// constructor.
+	if r.client == nil {
+		var err error
+		r.client, err = newStatsClient(r.cfg)
+		if err != nil {
+			r.client = nil
+			return pdata.ResourceMetricsSlice{}, err
+		}
+	}
+
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/haproxy",
+	}
+
+	stats, err := r.client.stats(ctx)
+	if err != nil {
+		r.logger.Error("Failed to fetch HAProxy stats", zap.Error(err))
+		return pdata.ResourceMetricsSlice{}, err
+	}
+
+	for _, row := range stats {
+		serviceType, ok := serviceTypes[row["type"]]
+		if !ok {
+			continue
+		}
+
+		rowMetrics := metrics.WithLabels(map[string]string{
+			metadata.L.Proxy:       row["pxname"],
+			metadata.L.Server:      row["svname"],
+			metadata.L.ServiceType: serviceType,
+		})
+
+		for _, m := range statsMetrics {
+			addDataPoint(rowMetrics, m.metric, row[m.column])
+		}
+
+		for _, c := range statusCodeColumns {
+			addDataPoint(
+				rowMetrics.WithLabels(map[string]string{metadata.L.StatusCode: c.statusCode}),
+				metadata.M.HaproxyResponsesTotal,
+				row[c.column],
+			)
+		}
+	}
+
+	var scrapeErrors scrapererror.ScrapeErrors
+
+	info, err := r.client.info(ctx)
+	if err != nil {
+		r.logger.Error("Failed to fetch HAProxy process information", zap.Error(err))
+		scrapeErrors.AddPartial(len(infoMetrics), err)
+	}
+	for _, m := range infoMetrics {
+		addDataPoint(&metrics, m.metric, info[m.field])
+	}
+
+	return metrics.Metrics.ResourceMetrics(), scrapeErrors.Combine()
+}
+
+// addDataPoint adds a data point to the metric unless the value is missing,
+// as columns of the stats that don't apply to a service are left empty.
+func addDataPoint(metrics *simple.Metrics, metric metadata.MetricIntf, value string) {
+	if value == "" {
+		return
+	}
+
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return
+	}
+
+	switch metric.New().DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		metrics.AddGaugeDataPoint(metric.Name(), v)
+	case pdata.MetricDataTypeIntSum:
+		metrics.AddSumDataPoint(metric.Name(), v)
+	}
+}
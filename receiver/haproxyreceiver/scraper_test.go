@@ -0,0 +1,253 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// newFakeStatsSocket serves the recorded output of the stats socket
+// commands on a Unix socket and returns its path.
+func newFakeStatsSocket(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("the stats socket is a Unix socket")
+	}
+
+	outputs := map[string][]byte{}
+	for command, file := range map[string]string{"show stat": "stats.csv", "show info": "info.txt"} {
+		out, err := ioutil.ReadFile(filepath.Join("testdata", file))
+		require.NoError(t, err)
+		outputs[command] = out
+	}
+
+	path := filepath.Join(t.TempDir(), "haproxy.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			command, err := bufio.NewReader(conn).ReadString('\n')
+			if err == nil {
+				out, ok := outputs[strings.TrimSpace(command)]
+				if !ok {
+					out = []byte("Unknown command.\n")
+				}
+				_, _ = conn.Write(out)
+			}
+			conn.Close()
+		}
+	}()
+
+	return path
+}
+
+// assertStatsDataPoints checks the number of data points scraped and the
+// values of the data points of the stats, which are returned keyed by the
+// metric name followed by their sorted labels.
+func assertStatsDataPoints(t *testing.T, rms pdata.ResourceMetricsSlice, count int) map[string]int64 {
+	require.Equal(t, 1, rms.Len())
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	require.Equal(t, 1, ilms.Len())
+	ms := ilms.At(0).Metrics()
+
+	values := make(map[string]int64, count)
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+
+		var dps pdata.IntDataPointSlice
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			dps = m.IntGauge().DataPoints()
+		case pdata.MetricDataTypeIntSum:
+			dps = m.IntSum().DataPoints()
+		}
+
+		for j := 0; j < dps.Len(); j++ {
+			var labels []string
+			dps.At(j).LabelsMap().Range(func(k string, v string) bool {
+				labels = append(labels, k+"="+v)
+				return true
+			})
+			sort.Strings(labels)
+			key := strings.Join(append([]string{m.Name()}, labels...), " ")
+			require.NotContains(t, values, key)
+			values[key] = dps.At(j).Value()
+		}
+	}
+	require.Len(t, values, count)
+
+	expected := map[string]int64{
+		"haproxy.sessions.current proxy=http-in server=FRONTEND service_type=frontend":                5,
+		"haproxy.sessions.total proxy=http-in server=FRONTEND service_type=frontend":                  8734,
+		"haproxy.sessions.rate proxy=http-in server=FRONTEND service_type=frontend":                   12,
+		"haproxy.bytes.input proxy=http-in server=FRONTEND service_type=frontend":                     3927436,
+		"haproxy.bytes.output proxy=http-in server=FRONTEND service_type=frontend":                    197542389,
+		"haproxy.requests.total proxy=http-in server=FRONTEND service_type=frontend":                  8734,
+		"haproxy.requests.denied proxy=http-in server=FRONTEND service_type=frontend":                 3,
+		"haproxy.requests.errors proxy=http-in server=FRONTEND service_type=frontend":                 7,
+		"haproxy.responses.total proxy=http-in server=FRONTEND service_type=frontend status_code=2xx": 8412,
+		"haproxy.responses.total proxy=http-in server=FRONTEND service_type=frontend status_code=5xx": 16,
+		"haproxy.requests.queued proxy=web server=web2 service_type=server":                           1,
+		"haproxy.connections.errors proxy=web server=web1 service_type=server":                        1,
+		"haproxy.connections.retries proxy=web server=web1 service_type=server":                       1,
+		"haproxy.connections.redispatched proxy=web server=web2 service_type=server":                  1,
+		"haproxy.responses.errors proxy=web server=web1 service_type=server":                          2,
+		"haproxy.responses.total proxy=web server=web1 service_type=server status_code=other":         3,
+		"haproxy.responses.total proxy=web server=BACKEND service_type=backend status_code=4xx":       191,
+		"haproxy.sessions.current proxy=web server=BACKEND service_type=backend":                      5,
+		"haproxy.responses.denied proxy=web server=BACKEND service_type=backend":                      0,
+		"haproxy.sessions.total proxy=stats server=BACKEND service_type=backend":                      0,
+	}
+	for key, value := range expected {
+		if assert.Contains(t, values, key) {
+			assert.Equal(t, value, values[key], key)
+		}
+	}
+
+	// Listeners aren't reported, and columns that don't apply to a
+	// service are left out.
+	for key := range values {
+		assert.NotContains(t, key, "server=ipv4")
+	}
+	assert.NotContains(t, values, "haproxy.requests.queued proxy=http-in server=FRONTEND service_type=frontend")
+	assert.NotContains(t, values, "haproxy.requests.total proxy=web server=web1 service_type=server")
+
+	return values
+}
+
+func TestScraperSocket(t *testing.T) {
+	path := newFakeStatsSocket(t)
+
+	for _, endpoint := range []string{path, "unix://" + path} {
+		t.Run(endpoint, func(t *testing.T) {
+			sc := newHAProxyScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: endpoint,
+				},
+			})
+			rms, err := sc.scrape(context.Background())
+			require.NoError(t, err)
+
+			values := assertStatsDataPoints(t, rms, 103)
+
+			assert.Equal(t, int64(6), values["haproxy.process.connections.current"])
+			assert.Equal(t, int64(8747), values["haproxy.process.connections.total"])
+			assert.Equal(t, int64(8753), values["haproxy.process.requests.total"])
+		})
+	}
+}
+
+func TestScraperHTTP(t *testing.T) {
+	stats, err := ioutil.ReadFile(filepath.Join("testdata", "stats.csv"))
+	require.NoError(t, err)
+
+	haproxyMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/stats;csv" {
+			rw.WriteHeader(200)
+			_, _ = rw.Write(stats)
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer haproxyMock.Close()
+
+	for _, endpoint := range []string{haproxyMock.URL + "/stats", haproxyMock.URL + "/stats;csv"} {
+		t.Run(endpoint, func(t *testing.T) {
+			sc := newHAProxyScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: endpoint,
+				},
+			})
+			rms, err := sc.scrape(context.Background())
+			require.NoError(t, err)
+
+			// The process information is only available on the stats socket.
+			assertStatsDataPoints(t, rms, 100)
+		})
+	}
+}
+
+func TestScraperError(t *testing.T) {
+	haproxyMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/stats;csv" {
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte("Bad stats page"))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer haproxyMock.Close()
+
+	t.Run("404", func(t *testing.T) {
+		sc := newHAProxyScraper(zap.NewNop(), &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: haproxyMock.URL + "/badpath",
+			},
+		})
+		_, err := sc.scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response, got 404")
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		sc := newHAProxyScraper(zap.NewNop(), &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: haproxyMock.URL + "/stats",
+			},
+		})
+		_, err := sc.scrape(context.Background())
+		require.EqualError(t, err, "stats output doesn't start with a CSV header")
+	})
+
+	t.Run("missing socket", func(t *testing.T) {
+		sc := newHAProxyScraper(zap.NewNop(), &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: filepath.Join(t.TempDir(), "missing.sock"),
+			},
+		})
+		_, err := sc.scrape(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to connect to the stats socket")
+	})
+}
+
+func TestParseInfo(t *testing.T) {
+	info := parseInfo([]byte("Name: HAProxy\nUptime: 0d 1h25m20s\nnot a field\n\nnode: haproxy-1\n"))
+	assert.Equal(t, map[string]string{
+		"Name":   "HAProxy",
+		"Uptime": "0d 1h25m20s",
+		"node":   "haproxy-1",
+	}, info)
+}
@@ -0,0 +1,351 @@
+// Copyright 2021 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journaldreceiver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/entry"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultJournalctlPath = "journalctl"
+	defaultPriority       = "info"
+
+	// lastReadCursorKey is the key under which the cursor of the last
+	// entry read is persisted.
+	lastReadCursorKey = "lastReadCursor"
+
+	// maxUncheckpointedEntries is the number of entries after which the
+	// cursor is persisted even if journalctl has more output pending.
+	maxUncheckpointedEntries = 1000
+)
+
+var (
+	// journalFieldRegex matches the names journald accepts for fields.
+	journalFieldRegex = regexp.MustCompile(`^[A-Z0-9_]+$`)
+
+	// severityMapping maps the syslog levels used by the PRIORITY field to
+	// stanza severities.
+	severityMapping = [...]entry.Severity{
+		0: entry.Emergency,
+		1: entry.Alert,
+		2: entry.Critical,
+		3: entry.Error,
+		4: entry.Warning,
+		5: entry.Notice,
+		6: entry.Info,
+		7: entry.Debug,
+	}
+
+	// priorityNames are the names journalctl accepts for the --priority
+	// option, in the order of the levels they stand for.
+	priorityNames = [...]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+)
+
+// journaldInputConfig is the configuration of the operator reading logs
+// from journalctl
+type journaldInputConfig struct {
+	helper.InputConfig `mapstructure:",squash" yaml:",inline"`
+
+	Directory      *string             `mapstructure:"directory,omitempty"       json:"directory,omitempty"       yaml:"directory,omitempty"`
+	Files          []string            `mapstructure:"files,omitempty"           json:"files,omitempty"           yaml:"files,omitempty"`
+	StartAt        string              `mapstructure:"start_at,omitempty"        json:"start_at,omitempty"        yaml:"start_at,omitempty"`
+	Units          []string            `mapstructure:"units,omitempty"           json:"units,omitempty"           yaml:"units,omitempty"`
+	Priority       string              `mapstructure:"priority,omitempty"        json:"priority,omitempty"        yaml:"priority,omitempty"`
+	Matches        []map[string]string `mapstructure:"matches,omitempty"         json:"matches,omitempty"         yaml:"matches,omitempty"`
+	JournalctlPath string              `mapstructure:"journalctl_path,omitempty" json:"journalctl_path,omitempty" yaml:"journalctl_path,omitempty"`
+}
+
+func newJournaldInputConfig(operatorID string) *journaldInputConfig {
+	return &journaldInputConfig{
+		InputConfig:    helper.NewInputConfig(operatorID, "journald_input"),
+		StartAt:        "end",
+		Priority:       defaultPriority,
+		JournalctlPath: defaultJournalctlPath,
+	}
+}
+
+// Build will build a journald input operator
+func (c journaldInputConfig) Build(buildContext operator.BuildContext) ([]operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(buildContext)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := c.buildArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	journalctlPath := c.JournalctlPath
+	if journalctlPath == "" {
+		journalctlPath = defaultJournalctlPath
+	}
+
+	journaldInput := &journaldInput{
+		InputOperator: inputOperator,
+		newCmd: func(ctx context.Context, cursor []byte) *exec.Cmd {
+			cmdArgs := args
+			if cursor != nil {
+				cmdArgs = append(cmdArgs[:len(cmdArgs):len(cmdArgs)], "--after-cursor", string(cursor))
+			}
+			return exec.CommandContext(ctx, journalctlPath, cmdArgs...)
+		},
+	}
+	return []operator.Operator{journaldInput}, nil
+}
+
+// buildArgs returns the journalctl arguments selecting the entries to read.
+func (c journaldInputConfig) buildArgs() ([]string, error) {
+	// Export logs in UTC time as JSON, and keep watching for new ones.
+	args := []string{"--utc", "--output=json", "--follow"}
+
+	switch c.StartAt {
+	case "end":
+	case "beginning":
+		args = append(args, "--no-tail")
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'start_at'", c.StartAt)
+	}
+
+	switch {
+	case c.Directory != nil:
+		args = append(args, "--directory", *c.Directory)
+	case len(c.Files) > 0:
+		for _, file := range c.Files {
+			args = append(args, "--file", file)
+		}
+	}
+
+	for _, unit := range c.Units {
+		args = append(args, "--unit", unit)
+	}
+
+	if c.Priority != "" {
+		if _, ok := parsePriority(c.Priority); !ok {
+			return nil, fmt.Errorf("invalid value '%s' for parameter 'priority'", c.Priority)
+		}
+		args = append(args, "--priority", c.Priority)
+	}
+
+	// Fields of a match must all be matched, while an entry is read as soon
+	// as it satisfies one of the matches.
+	for i, match := range c.Matches {
+		if len(match) == 0 {
+			return nil, fmt.Errorf("invalid empty entry %d in parameter 'matches'", i)
+		}
+		if i > 0 {
+			args = append(args, "+")
+		}
+
+		fields := make([]string, 0, len(match))
+		for field := range match {
+			if !journalFieldRegex.MatchString(field) {
+				return nil, fmt.Errorf("invalid field '%s' in parameter 'matches', field names must be uppercase", field)
+			}
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			args = append(args, fmt.Sprintf("%s=%s", field, match[field]))
+		}
+	}
+
+	return args, nil
+}
+
+// parsePriority returns the syslog level of a priority given as a number or
+// as one of the names journalctl accepts.
+func parsePriority(priority string) (int, bool) {
+	if level, err := strconv.Atoi(priority); err == nil {
+		return level, level >= 0 && level < len(priorityNames)
+	}
+	for level, name := range priorityNames {
+		if name == priority {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// journaldInput is an operator reading the entries exported by journalctl
+type journaldInput struct {
+	helper.InputOperator
+
+	newCmd func(ctx context.Context, cursor []byte) *exec.Cmd
+
+	persister operator.Persister
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+var _ operator.Operator = (*journaldInput)(nil)
+
+// Start will start reading the entries from journalctl, after the last
+// persisted cursor if any.
+func (operator *journaldInput) Start(persister operator.Persister) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	operator.cancel = cancel
+
+	cursor, err := persister.Get(ctx, lastReadCursorKey)
+	if err != nil {
+		return fmt.Errorf("failed to get journalctl state: %w", err)
+	}
+
+	operator.persister = persister
+
+	cmd := operator.newCmd(ctx, cursor)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to get journalctl stdout: %w", err)
+	}
+	if err = cmd.Start(); err != nil {
+		return fmt.Errorf("start journalctl: %w", err)
+	}
+
+	operator.wg.Add(1)
+	go func() {
+		defer operator.wg.Done()
+		operator.readEntries(ctx, stdout)
+		// journalctl is killed once the context is cancelled.
+		_ = cmd.Wait()
+	}()
+
+	return nil
+}
+
+// readEntries emits the entries read from journalctl until its output is
+// closed. The cursor of the last entry emitted is persisted whenever
+// journalctl has no more output pending, so that checkpoints are written
+// in batches while catching up.
+func (operator *journaldInput) readEntries(ctx context.Context, stdout io.Reader) {
+	reader := bufio.NewReader(stdout)
+
+	var (
+		cursor         string
+		uncheckpointed int
+	)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+			if err != io.EOF {
+				operator.Errorw("Received error reading from journalctl stdout", zap.Error(err))
+			}
+			if uncheckpointed > 0 {
+				operator.saveCursor(ctx, cursor)
+			}
+			return
+		}
+
+		entry, entryCursor, err := operator.parseJournalEntry(line)
+		if err != nil {
+			operator.Warnw("Failed to parse journal entry", zap.Error(err))
+			continue
+		}
+
+		operator.Write(ctx, entry)
+		cursor = entryCursor
+		uncheckpointed++
+
+		if reader.Buffered() == 0 || uncheckpointed >= maxUncheckpointedEntries {
+			operator.saveCursor(ctx, cursor)
+			uncheckpointed = 0
+		}
+	}
+}
+
+func (operator *journaldInput) saveCursor(ctx context.Context, cursor string) {
+	if err := operator.persister.Set(ctx, lastReadCursorKey, []byte(cursor)); err != nil {
+		operator.Warnw("Failed to set offset", zap.Error(err))
+	}
+}
+
+// parseJournalEntry creates an entry out of a journal entry exported as
+// JSON and returns it along with its cursor.
+func (operator *journaldInput) parseJournalEntry(line []byte) (*entry.Entry, string, error) {
+	var body map[string]interface{}
+	if err := json.Unmarshal(line, &body); err != nil {
+		return nil, "", err
+	}
+
+	timestamp, ok := body["__REALTIME_TIMESTAMP"]
+	if !ok {
+		return nil, "", errors.New("journald body missing __REALTIME_TIMESTAMP field")
+	}
+
+	timestampString, ok := timestamp.(string)
+	if !ok {
+		return nil, "", errors.New("journald field for timestamp is not a string")
+	}
+
+	timestampInt, err := strconv.ParseInt(timestampString, 10, 64)
+	if err != nil {
+		return nil, "", fmt.Errorf("parse timestamp: %w", err)
+	}
+
+	delete(body, "__REALTIME_TIMESTAMP")
+
+	cursor, ok := body["__CURSOR"]
+	if !ok {
+		return nil, "", errors.New("journald body missing __CURSOR field")
+	}
+
+	cursorString, ok := cursor.(string)
+	if !ok {
+		return nil, "", errors.New("journald field for cursor is not a string")
+	}
+
+	entry, err := operator.NewEntry(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create entry: %w", err)
+	}
+
+	entry.Timestamp = time.Unix(0, timestampInt*1000) // in microseconds
+	if priority, ok := body["PRIORITY"].(string); ok {
+		if level, ok := parsePriority(priority); ok {
+			entry.Severity = severityMapping[level]
+		}
+	}
+	return entry, cursorString, nil
+}
+
+// Stop will stop reading entries and wait for journalctl to exit.
+func (operator *journaldInput) Stop() error {
+	if operator.cancel != nil {
+		operator.cancel()
+	}
+	operator.wg.Wait()
+	return nil
+}
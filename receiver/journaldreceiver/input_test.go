@@ -0,0 +1,250 @@
+// Copyright 2021 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journaldreceiver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/entry"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lastRecordedCursor = "s=b1ae5bba3f7d4ee3a2c3e5d5d3c12f07;i=1c4f;b=5f9e2c6a8b0d4c0e9f3a1b2c3d4e5f60;m=2e91c47;t=5c1a6e23fb9e2;x=9d8c7b6a5f4e3d21"
+
+// newFakeJournalctl writes a script standing in for journalctl, which
+// records its arguments and outputs the entries recorded in testdata.
+// It returns the path of the script and of the file the arguments of
+// every run are appended to.
+func newFakeJournalctl(t *testing.T) (string, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake journalctl is a shell script")
+	}
+
+	journal, err := filepath.Abs(filepath.Join("testdata", "journal.json"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	argsPath := filepath.Join(dir, "args")
+	scriptPath := filepath.Join(dir, "journalctl")
+	script := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> '%s'\ncat '%s'\n", argsPath, journal)
+	require.NoError(t, ioutil.WriteFile(scriptPath, []byte(script), 0700))
+	return scriptPath, argsPath
+}
+
+func readArgs(t *testing.T, argsPath string) []string {
+	args, err := ioutil.ReadFile(argsPath)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(args)), "\n")
+}
+
+func TestBuildArgs(t *testing.T) {
+	directory := "/var/log/journal"
+
+	testcases := []struct {
+		name     string
+		modify   func(*journaldInputConfig)
+		expected []string
+		err      string
+	}{
+		{
+			name:     "default",
+			modify:   func(cfg *journaldInputConfig) {},
+			expected: []string{"--utc", "--output=json", "--follow", "--priority", "info"},
+		},
+		{
+			name: "start at beginning without priority",
+			modify: func(cfg *journaldInputConfig) {
+				cfg.StartAt = "beginning"
+				cfg.Priority = ""
+			},
+			expected: []string{"--utc", "--output=json", "--follow", "--no-tail"},
+		},
+		{
+			name: "directory and units",
+			modify: func(cfg *journaldInputConfig) {
+				cfg.Directory = &directory
+				cfg.Units = []string{"ssh.service", "kubelet.service"}
+				cfg.Priority = "3"
+			},
+			expected: []string{"--utc", "--output=json", "--follow", "--directory", "/var/log/journal", "--unit", "ssh.service", "--unit", "kubelet.service", "--priority", "3"},
+		},
+		{
+			name: "files",
+			modify: func(cfg *journaldInputConfig) {
+				cfg.Files = []string{"/tmp/a.journal", "/tmp/b.journal"}
+				cfg.Priority = ""
+			},
+			expected: []string{"--utc", "--output=json", "--follow", "--file", "/tmp/a.journal", "--file", "/tmp/b.journal"},
+		},
+		{
+			name: "matches",
+			modify: func(cfg *journaldInputConfig) {
+				cfg.Priority = "warning"
+				cfg.Matches = []map[string]string{
+					{"_UID": "0", "_SYSTEMD_UNIT": "cron.service"},
+					{"_TRANSPORT": "kernel"},
+				}
+			},
+			expected: []string{"--utc", "--output=json", "--follow", "--priority", "warning", "_SYSTEMD_UNIT=cron.service", "_UID=0", "+", "_TRANSPORT=kernel"},
+		},
+		{
+			name:   "invalid start_at",
+			modify: func(cfg *journaldInputConfig) { cfg.StartAt = "middle" },
+			err:    "invalid value 'middle' for parameter 'start_at'",
+		},
+		{
+			name:   "invalid priority",
+			modify: func(cfg *journaldInputConfig) { cfg.Priority = "8" },
+			err:    "invalid value '8' for parameter 'priority'",
+		},
+		{
+			name:   "invalid priority name",
+			modify: func(cfg *journaldInputConfig) { cfg.Priority = "error" },
+			err:    "invalid value 'error' for parameter 'priority'",
+		},
+		{
+			name: "lowercase match field",
+			modify: func(cfg *journaldInputConfig) {
+				cfg.Matches = []map[string]string{{"_systemd_unit": "cron.service"}}
+			},
+			err: "invalid field '_systemd_unit' in parameter 'matches', field names must be uppercase",
+		},
+		{
+			name: "empty match",
+			modify: func(cfg *journaldInputConfig) {
+				cfg.Matches = []map[string]string{{"_TRANSPORT": "kernel"}, {}}
+			},
+			err: "invalid empty entry 1 in parameter 'matches'",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newJournaldInputConfig("journald_input")
+			tc.modify(cfg)
+
+			args, err := cfg.buildArgs()
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, args)
+
+			_, err = cfg.Build(testutil.NewBuildContext(t))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInput(t *testing.T) {
+	journalctlPath, argsPath := newFakeJournalctl(t)
+
+	cfg := newJournaldInputConfig("journald_input")
+	cfg.JournalctlPath = journalctlPath
+	cfg.Units = []string{"ssh.service"}
+
+	persister := testutil.NewMockPersister("test")
+	start := func() *testutil.FakeOutput {
+		ops, err := cfg.Build(testutil.NewBuildContext(t))
+		require.NoError(t, err)
+		input := ops[0].(*journaldInput)
+
+		fake := testutil.NewFakeOutput(t)
+		input.OutputOperators = []operator.Operator{fake}
+		require.NoError(t, input.Start(persister))
+		t.Cleanup(func() { require.NoError(t, input.Stop()) })
+		return fake
+	}
+
+	fake := start()
+
+	expected := []struct {
+		severity  entry.Severity
+		timestamp time.Time
+		message   string
+	}{
+		{entry.Info, time.Unix(0, 1620298496011068000), "Accepted publickey for admin from 10.0.0.12 port 51734 ssh2"},
+		{entry.Warning, time.Unix(0, 1620298496024653000), "Failed to get system container stats"},
+		{entry.Error, time.Unix(0, 1620298496035810000), "EXT4-fs error (device sda1): ext4_find_entry:1455: inode #2: comm ls: reading directory lblock 0"},
+	}
+	for _, e := range expected {
+		select {
+		case received := <-fake.Received:
+			assert.Equal(t, e.severity, received.Severity)
+			assert.True(t, e.timestamp.Equal(received.Timestamp))
+
+			body, ok := received.Body.(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, e.message, body["MESSAGE"])
+			assert.NotContains(t, body, "__REALTIME_TIMESTAMP")
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for entry")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		cursor, err := persister.Get(context.Background(), lastReadCursorKey)
+		return err == nil && string(cursor) == lastRecordedCursor
+	}, time.Second, 10*time.Millisecond)
+
+	// A new run resumes reading after the persisted cursor.
+	start()
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(argsPath)
+		return err == nil && len(readArgs(t, argsPath)) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	args := readArgs(t, argsPath)
+	assert.Equal(t, "--utc --output=json --follow --unit ssh.service --priority info", args[0])
+	assert.Equal(t, "--utc --output=json --follow --unit ssh.service --priority info --after-cursor "+lastRecordedCursor, args[1])
+}
+
+func TestInputInvalidEntries(t *testing.T) {
+	cfg := newJournaldInputConfig("journald_input")
+	ops, err := cfg.Build(testutil.NewBuildContext(t))
+	require.NoError(t, err)
+	input := ops[0].(*journaldInput)
+
+	testcases := []struct {
+		name string
+		line string
+		err  string
+	}{
+		{"invalid json", `{"MESSAGE":`, "unexpected end of JSON input"},
+		{"missing timestamp", `{"__CURSOR":"c"}`, "journald body missing __REALTIME_TIMESTAMP field"},
+		{"timestamp not a string", `{"__CURSOR":"c","__REALTIME_TIMESTAMP":1}`, "journald field for timestamp is not a string"},
+		{"invalid timestamp", `{"__CURSOR":"c","__REALTIME_TIMESTAMP":"now"}`, `parse timestamp: strconv.ParseInt: parsing "now": invalid syntax`},
+		{"missing cursor", `{"__REALTIME_TIMESTAMP":"1"}`, "journald body missing __CURSOR field"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := input.parseJournalEntry([]byte(tc.line))
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
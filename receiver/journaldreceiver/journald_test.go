@@ -0,0 +1,149 @@
+// Copyright 2021 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journaldreceiver
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza"
+)
+
+func TestJournald(t *testing.T) {
+	journalctlPath, _ := newFakeJournalctl(t)
+
+	cfg := testdataConfigYamlAsMap()
+	cfg.Input["journalctl_path"] = journalctlPath
+
+	f := NewFactory()
+	params := component.ReceiverCreateParams{Logger: zaptest.NewLogger(t)}
+	sink := new(consumertest.LogsSink)
+	rcvr, err := f.CreateLogsReceiver(context.Background(), params, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.Eventually(t, expectNLogs(sink, 3), 2*time.Second, time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+
+	resourceLogs := sink.AllLogs()[0].ResourceLogs().At(0)
+	logs := resourceLogs.InstrumentationLibraryLogs().At(0).Logs()
+
+	expected := []struct {
+		severity pdata.SeverityNumber
+		unit     string
+	}{
+		{pdata.SeverityNumberINFO, "ssh.service"},
+		{pdata.SeverityNumberINFO4, "kubelet.service"},
+		{pdata.SeverityNumberERROR, ""},
+	}
+	for i, e := range expected {
+		log := logs.At(i)
+		assert.Equal(t, e.severity, log.SeverityNumber())
+		require.Equal(t, pdata.AttributeValueMAP, log.Body().Type())
+
+		unit, ok := log.Body().MapVal().Get("_SYSTEMD_UNIT")
+		if e.unit == "" {
+			assert.False(t, ok)
+		} else if assert.True(t, ok) {
+			assert.Equal(t, e.unit, unit.StringVal())
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[config.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 1)
+	assert.Equal(t, testdataConfigYamlAsMap(), cfg.Receivers[config.NewID(typeStr)])
+}
+
+func testdataConfigYamlAsMap() *JournaldConfig {
+	return &JournaldConfig{
+		BaseConfig: stanza.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+			Operators:        stanza.OperatorConfigs{},
+			Converter: stanza.ConverterConfig{
+				WorkerCount: 1,
+			},
+		},
+		Input: stanza.InputConfig{
+			"units": []interface{}{
+				"ssh.service",
+				"kubelet.service",
+			},
+			"priority": "warning",
+			"matches": []interface{}{
+				map[interface{}]interface{}{
+					"_SYSTEMD_UNIT": "cron.service",
+					"_UID":          "0",
+				},
+				map[interface{}]interface{}{
+					"_TRANSPORT": "kernel",
+				},
+			},
+		},
+	}
+}
+
+func TestDecodeInputConfigFailure(t *testing.T) {
+	params := component.ReceiverCreateParams{
+		Logger: zap.NewNop(),
+	}
+	sink := new(consumertest.LogsSink)
+	factory := NewFactory()
+	badCfg := &JournaldConfig{
+		BaseConfig: stanza.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+			Operators:        stanza.OperatorConfigs{},
+		},
+		Input: stanza.InputConfig{
+			"units": map[string]interface{}{
+				"ssh": "service",
+			},
+		},
+	}
+	receiver, err := factory.CreateLogsReceiver(context.Background(), params, badCfg, sink)
+	require.Error(t, err, "receiver creation should fail if input config isn't valid")
+	require.Nil(t, receiver, "receiver creation should fail if input config isn't valid")
+}
+
+func expectNLogs(sink *consumertest.LogsSink, expected int) func() bool {
+	return func() bool {
+		return sink.LogRecordsCount() == expected
+	}
+}
@@ -27,4 +27,22 @@ type Config struct {
 
 	// Timeout for the memcache stats request
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Endpoints are the hostname/IP address and port of the memcached
+	// instances of a pool. When set, they are scraped instead of Endpoint.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// IncludeSlabStats enables the chunk usage metrics of every slab class.
+	IncludeSlabStats bool `mapstructure:"include_slab_stats"`
+
+	// IncludeItemStats enables the item metrics of every slab class.
+	IncludeItemStats bool `mapstructure:"include_item_stats"`
+}
+
+// servers returns the endpoints of the memcached instances to scrape.
+func (c *Config) servers() []string {
+	if len(c.Endpoints) > 0 {
+		return c.Endpoints
+	}
+	return []string{c.Endpoint}
 }
@@ -55,11 +55,20 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	MemcachedBytes              MetricIntf
-	MemcachedCurrentConnections MetricIntf
-	MemcachedGetHits            MetricIntf
-	MemcachedGetMisses          MetricIntf
-	MemcachedTotalConnections   MetricIntf
+	MemcachedBytes               MetricIntf
+	MemcachedCurrentConnections  MetricIntf
+	MemcachedGetHits             MetricIntf
+	MemcachedGetMisses           MetricIntf
+	MemcachedSlabChunkSize       MetricIntf
+	MemcachedSlabChunks          MetricIntf
+	MemcachedSlabEvictions       MetricIntf
+	MemcachedSlabItemAge         MetricIntf
+	MemcachedSlabItems           MetricIntf
+	MemcachedSlabMemoryRequested MetricIntf
+	MemcachedSlabOutOfMemory     MetricIntf
+	MemcachedSlabPages           MetricIntf
+	MemcachedSlabReclaimed       MetricIntf
+	MemcachedTotalConnections    MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
@@ -69,16 +78,34 @@ func (m *metricStruct) Names() []string {
 		"memcached.current_connections",
 		"memcached.get_hits",
 		"memcached.get_misses",
+		"memcached.slab.chunk_size",
+		"memcached.slab.chunks",
+		"memcached.slab.evictions",
+		"memcached.slab.item_age",
+		"memcached.slab.items",
+		"memcached.slab.memory_requested",
+		"memcached.slab.out_of_memory",
+		"memcached.slab.pages",
+		"memcached.slab.reclaimed",
 		"memcached.total_connections",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"memcached.bytes":               Metrics.MemcachedBytes,
-	"memcached.current_connections": Metrics.MemcachedCurrentConnections,
-	"memcached.get_hits":            Metrics.MemcachedGetHits,
-	"memcached.get_misses":          Metrics.MemcachedGetMisses,
-	"memcached.total_connections":   Metrics.MemcachedTotalConnections,
+	"memcached.bytes":                 Metrics.MemcachedBytes,
+	"memcached.current_connections":   Metrics.MemcachedCurrentConnections,
+	"memcached.get_hits":              Metrics.MemcachedGetHits,
+	"memcached.get_misses":            Metrics.MemcachedGetMisses,
+	"memcached.slab.chunk_size":       Metrics.MemcachedSlabChunkSize,
+	"memcached.slab.chunks":           Metrics.MemcachedSlabChunks,
+	"memcached.slab.evictions":        Metrics.MemcachedSlabEvictions,
+	"memcached.slab.item_age":         Metrics.MemcachedSlabItemAge,
+	"memcached.slab.items":            Metrics.MemcachedSlabItems,
+	"memcached.slab.memory_requested": Metrics.MemcachedSlabMemoryRequested,
+	"memcached.slab.out_of_memory":    Metrics.MemcachedSlabOutOfMemory,
+	"memcached.slab.pages":            Metrics.MemcachedSlabPages,
+	"memcached.slab.reclaimed":        Metrics.MemcachedSlabReclaimed,
+	"memcached.total_connections":     Metrics.MemcachedTotalConnections,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -87,11 +114,20 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 
 func (m *metricStruct) FactoriesByName() map[string]func(pdata.Metric) {
 	return map[string]func(pdata.Metric){
-		Metrics.MemcachedBytes.Name():              Metrics.MemcachedBytes.Init,
-		Metrics.MemcachedCurrentConnections.Name(): Metrics.MemcachedCurrentConnections.Init,
-		Metrics.MemcachedGetHits.Name():            Metrics.MemcachedGetHits.Init,
-		Metrics.MemcachedGetMisses.Name():          Metrics.MemcachedGetMisses.Init,
-		Metrics.MemcachedTotalConnections.Name():   Metrics.MemcachedTotalConnections.Init,
+		Metrics.MemcachedBytes.Name():               Metrics.MemcachedBytes.Init,
+		Metrics.MemcachedCurrentConnections.Name():  Metrics.MemcachedCurrentConnections.Init,
+		Metrics.MemcachedGetHits.Name():             Metrics.MemcachedGetHits.Init,
+		Metrics.MemcachedGetMisses.Name():           Metrics.MemcachedGetMisses.Init,
+		Metrics.MemcachedSlabChunkSize.Name():       Metrics.MemcachedSlabChunkSize.Init,
+		Metrics.MemcachedSlabChunks.Name():          Metrics.MemcachedSlabChunks.Init,
+		Metrics.MemcachedSlabEvictions.Name():       Metrics.MemcachedSlabEvictions.Init,
+		Metrics.MemcachedSlabItemAge.Name():         Metrics.MemcachedSlabItemAge.Init,
+		Metrics.MemcachedSlabItems.Name():           Metrics.MemcachedSlabItems.Init,
+		Metrics.MemcachedSlabMemoryRequested.Name(): Metrics.MemcachedSlabMemoryRequested.Init,
+		Metrics.MemcachedSlabOutOfMemory.Name():     Metrics.MemcachedSlabOutOfMemory.Init,
+		Metrics.MemcachedSlabPages.Name():           Metrics.MemcachedSlabPages.Init,
+		Metrics.MemcachedSlabReclaimed.Name():       Metrics.MemcachedSlabReclaimed.Init,
+		Metrics.MemcachedTotalConnections.Name():    Metrics.MemcachedTotalConnections.Init,
 	}
 }
 
@@ -138,6 +174,93 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"memcached.slab.chunk_size",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.chunk_size")
+			metric.SetDescription("The amount of space each chunk of the slab class uses. Only reported when include_slab_stats is enabled")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.chunks",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.chunks")
+			metric.SetDescription("The number of chunks of the slab class. Only reported when include_slab_stats is enabled")
+			metric.SetUnit("chunks")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.evictions",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.evictions")
+			metric.SetDescription("The number of items of the slab class evicted from the cache before expiring. Only reported when include_item_stats is enabled")
+			metric.SetUnit("items")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.item_age",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.item_age")
+			metric.SetDescription("The age of the oldest item of the slab class. Only reported when include_item_stats is enabled")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.items",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.items")
+			metric.SetDescription("The number of items stored in the slab class. Only reported when include_item_stats is enabled")
+			metric.SetUnit("items")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.memory_requested",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.memory_requested")
+			metric.SetDescription("The number of bytes requested to be stored in the slab class. Only reported when include_slab_stats is enabled")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.out_of_memory",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.out_of_memory")
+			metric.SetDescription("The number of times the slab class was unable to store a new item. Only reported when include_item_stats is enabled")
+			metric.SetUnit("times")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.pages",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.pages")
+			metric.SetDescription("The number of pages allocated to the slab class. Only reported when include_slab_stats is enabled")
+			metric.SetUnit("pages")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.reclaimed",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.reclaimed")
+			metric.SetDescription("The number of times an expired item of the slab class was reused to store a new item. Only reported when include_item_stats is enabled")
+			metric.SetUnit("items")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"memcached.total_connections",
 		func(metric pdata.Metric) {
@@ -157,7 +280,14 @@ var M = Metrics
 
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
-}{}
+	// Slab (The ID of the slab class)
+	Slab string
+	// State (The state of the chunks, one of used or free)
+	State string
+}{
+	"slab",
+	"state",
+}
 
 // L contains the possible metric labels that can be used. L is an alias for
 // Labels.
@@ -16,19 +16,44 @@ package memcachedreceiver
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/grobie/gomemcache/memcache"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/simple"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/memcachedreceiver/internal/metadata"
 )
 
+// serverAttribute is the resource attribute holding the endpoint of the
+// memcached instance the metrics belong to.
+const serverAttribute = "memcached.server"
+
+// slabMetrics and itemMetrics are the metrics only reported when the slab
+// stats and the item stats are enabled.
+var (
+	slabMetrics = []metadata.MetricIntf{
+		metadata.M.MemcachedSlabChunkSize,
+		metadata.M.MemcachedSlabChunks,
+		metadata.M.MemcachedSlabPages,
+		metadata.M.MemcachedSlabMemoryRequested,
+	}
+	itemMetrics = []metadata.MetricIntf{
+		metadata.M.MemcachedSlabItems,
+		metadata.M.MemcachedSlabItemAge,
+		metadata.M.MemcachedSlabEvictions,
+		metadata.M.MemcachedSlabOutOfMemory,
+		metadata.M.MemcachedSlabReclaimed,
+	}
+)
+
 type memcachedScraper struct {
-	client *memcache.Client
+	clients map[string]*memcache.Client
 
 	logger *zap.Logger
 	config *Config
@@ -39,24 +64,65 @@ func newMemcachedScraper(
 	config *Config,
 ) scraperhelper.ResourceMetricsScraper {
 	ms := &memcachedScraper{
-		logger: logger,
-		config: config,
+		clients: map[string]*memcache.Client{},
+		logger:  logger,
+		config:  config,
 	}
 	return scraperhelper.NewResourceMetricsScraper(config.ID(), ms.scrape)
 }
 
+// scrape scrapes every memcached instance into its own resource. Instances
+// that fail to be scraped don't prevent the others from being reported.
 func (r *memcachedScraper) scrape(_ context.Context) (pdata.ResourceMetricsSlice, error) {
+	rms := pdata.NewResourceMetricsSlice()
+	var errs []error
+
+	for _, endpoint := range r.config.servers() {
+		serverRms, err := r.scrapeServer(endpoint)
+		if err != nil {
+			r.logger.Error("Failed to fetch memcached stats", zap.String("endpoint", endpoint), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		serverRms.MoveAndAppendTo(rms)
+	}
+
+	switch {
+	case len(errs) == 0:
+		return rms, nil
+	case rms.Len() == 0:
+		return pdata.ResourceMetricsSlice{}, consumererror.Combine(errs)
+	default:
+		// All the enabled metrics of the instances that failed are missing.
+		return rms, scrapererror.NewPartialScrapeError(consumererror.Combine(errs), len(errs)*r.enabledMetrics())
+	}
+}
+
+// enabledMetrics returns the number of metrics reported for an instance.
+func (r *memcachedScraper) enabledMetrics() int {
+	count := len(metadata.M.Names()) - len(slabMetrics) - len(itemMetrics)
+	if r.config.IncludeSlabStats {
+		count += len(slabMetrics)
+	}
+	if r.config.IncludeItemStats {
+		count += len(itemMetrics)
+	}
+	return count
+}
+
+func (r *memcachedScraper) scrapeServer(endpoint string) (pdata.ResourceMetricsSlice, error) {
 	// Init client in scrape method in case there are transient errors in the
 	// constructor.
-	if r.client == nil {
+	client, ok := r.clients[endpoint]
+	if !ok {
 		var err error
-		r.client, err = memcache.New(r.config.Endpoint)
+		client, err = memcache.New(endpoint)
 		if err != nil {
-			r.client = nil
 			return pdata.ResourceMetricsSlice{}, err
 		}
 
-		r.client.Timeout = r.config.Timeout
+		client.Timeout = r.config.Timeout
+		r.clients[endpoint] = client
 	}
 
 	metrics := simple.Metrics{
@@ -66,9 +132,8 @@ func (r *memcachedScraper) scrape(_ context.Context) (pdata.ResourceMetricsSlice
 		InstrumentationLibraryName: "otelcol/memcached",
 	}
 
-	stats, err := r.client.Stats()
+	stats, err := client.Stats()
 	if err != nil {
-		r.logger.Error("Failed to fetch memcached stats", zap.Error(err))
 		return pdata.ResourceMetricsSlice{}, err
 	}
 
@@ -87,7 +152,53 @@ func (r *memcachedScraper) scrape(_ context.Context) (pdata.ResourceMetricsSlice
 				metrics.AddSumDataPoint(metadata.M.MemcachedGetMisses.Name(), parseInt(v))
 			}
 		}
+
+		if r.config.IncludeSlabStats {
+			for slab, slabStats := range stats.Slabs {
+				slabMetrics := metrics.WithLabels(map[string]string{metadata.L.Slab: strconv.Itoa(slab)})
+				for k, v := range slabStats {
+					switch k {
+					case "chunk_size":
+						slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabChunkSize.Name(), parseInt(v))
+					case "used_chunks":
+						slabMetrics.WithLabels(map[string]string{metadata.L.State: "used"}).
+							AddGaugeDataPoint(metadata.M.MemcachedSlabChunks.Name(), parseInt(v))
+					case "free_chunks":
+						slabMetrics.WithLabels(map[string]string{metadata.L.State: "free"}).
+							AddGaugeDataPoint(metadata.M.MemcachedSlabChunks.Name(), parseInt(v))
+					case "total_pages":
+						slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabPages.Name(), parseInt(v))
+					case "mem_requested":
+						slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabMemoryRequested.Name(), parseInt(v))
+					}
+				}
+			}
+		}
+
+		if r.config.IncludeItemStats {
+			for slab, itemStats := range stats.Items {
+				slabMetrics := metrics.WithLabels(map[string]string{metadata.L.Slab: strconv.Itoa(slab)})
+				for k, v := range itemStats {
+					switch k {
+					case "number":
+						slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabItems.Name(), parseInt(v))
+					case "age":
+						slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabItemAge.Name(), parseInt(v))
+					case "evicted":
+						slabMetrics.AddSumDataPoint(metadata.M.MemcachedSlabEvictions.Name(), parseInt(v))
+					case "outofmemory":
+						slabMetrics.AddSumDataPoint(metadata.M.MemcachedSlabOutOfMemory.Name(), parseInt(v))
+					case "reclaimed":
+						slabMetrics.AddSumDataPoint(metadata.M.MemcachedSlabReclaimed.Name(), parseInt(v))
+					}
+				}
+			}
+		}
 	}
 
-	return metrics.Metrics.ResourceMetrics(), nil
+	rms := metrics.Metrics.ResourceMetrics()
+	if rms.Len() > 0 {
+		rms.At(0).Resource().Attributes().InsertString(serverAttribute, endpoint)
+	}
+	return rms, nil
 }
@@ -0,0 +1,295 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memcachedreceiver
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grobie/gomemcache/memcache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+)
+
+// fakeStats are the responses of the fake memcached server to the stats
+// commands.
+var fakeStats = map[string]string{
+	"stats": `STAT pid 1
+STAT uptime 3600
+STAT curr_connections 10
+STAT total_connections 152
+STAT get_hits 6571
+STAT get_misses 349
+STAT bytes 23587
+STAT curr_items 41
+`,
+	"stats slabs": `STAT 1:chunk_size 96
+STAT 1:chunks_per_page 10922
+STAT 1:total_pages 1
+STAT 1:total_chunks 10922
+STAT 1:used_chunks 29
+STAT 1:free_chunks 10893
+STAT 1:mem_requested 2523
+STAT 5:chunk_size 240
+STAT 5:chunks_per_page 4369
+STAT 5:total_pages 2
+STAT 5:total_chunks 8738
+STAT 5:used_chunks 12
+STAT 5:free_chunks 8726
+STAT 5:mem_requested 2640
+STAT active_slabs 2
+STAT total_malloced 3145728
+`,
+	"stats items": `STAT items:1:number 29
+STAT items:1:age 3412
+STAT items:1:evicted 3
+STAT items:1:outofmemory 0
+STAT items:1:reclaimed 7
+STAT items:5:number 12
+STAT items:5:age 127
+STAT items:5:evicted 0
+STAT items:5:outofmemory 1
+STAT items:5:reclaimed 0
+`,
+}
+
+// newFakeMemcached starts a server answering the stats commands of the
+// memcached text protocol and returns its address.
+func newFakeMemcached(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					command, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					response := strings.ReplaceAll(fakeStats[strings.TrimSpace(command)], "\n", "\r\n")
+					if _, err := conn.Write([]byte(response + "END\r\n")); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// closedEndpoint returns the address of a port nothing listens on.
+func closedEndpoint(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return endpoint
+}
+
+func TestScraper(t *testing.T) {
+	stats := map[string]int64{
+		"memcached.bytes":               23587,
+		"memcached.current_connections": 10,
+		"memcached.total_connections":   152,
+		"memcached.get_hits":            6571,
+		"memcached.get_misses":          349,
+	}
+	slabStats := map[string]int64{
+		"memcached.slab.chunk_size slab=1":        96,
+		"memcached.slab.chunk_size slab=5":        240,
+		"memcached.slab.chunks slab=1 state=used": 29,
+		"memcached.slab.chunks slab=1 state=free": 10893,
+		"memcached.slab.chunks slab=5 state=used": 12,
+		"memcached.slab.chunks slab=5 state=free": 8726,
+		"memcached.slab.pages slab=1":             1,
+		"memcached.slab.pages slab=5":             2,
+		"memcached.slab.memory_requested slab=1":  2523,
+		"memcached.slab.memory_requested slab=5":  2640,
+	}
+	itemStats := map[string]int64{
+		"memcached.slab.items slab=1":         29,
+		"memcached.slab.items slab=5":         12,
+		"memcached.slab.item_age slab=1":      3412,
+		"memcached.slab.item_age slab=5":      127,
+		"memcached.slab.evictions slab=1":     3,
+		"memcached.slab.evictions slab=5":     0,
+		"memcached.slab.out_of_memory slab=1": 0,
+		"memcached.slab.out_of_memory slab=5": 1,
+		"memcached.slab.reclaimed slab=1":     7,
+		"memcached.slab.reclaimed slab=5":     0,
+	}
+
+	tests := []struct {
+		name             string
+		includeSlabStats bool
+		includeItemStats bool
+		expected         []map[string]int64
+	}{
+		{"default", false, false, []map[string]int64{stats}},
+		{"slabs", true, false, []map[string]int64{stats, slabStats}},
+		{"items", false, true, []map[string]int64{stats, itemStats}},
+		{"slabs and items", true, true, []map[string]int64{stats, slabStats, itemStats}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			endpoint := newFakeMemcached(t)
+
+			sc := &memcachedScraper{
+				clients: map[string]*memcache.Client{},
+				logger:  zap.NewNop(),
+				config: &Config{
+					TCPAddr:          confignet.TCPAddr{Endpoint: endpoint},
+					Timeout:          10 * time.Second,
+					IncludeSlabStats: test.includeSlabStats,
+					IncludeItemStats: test.includeItemStats,
+				},
+			}
+			rms, err := sc.scrape(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, 1, rms.Len())
+			rm := rms.At(0)
+
+			server, ok := rm.Resource().Attributes().Get(serverAttribute)
+			require.True(t, ok)
+			require.Equal(t, endpoint, server.StringVal())
+
+			ilms := rm.InstrumentationLibraryMetrics()
+			require.Equal(t, 1, ilms.Len())
+
+			ilm := ilms.At(0)
+			ms := ilm.Metrics()
+
+			// The data points are keyed by the metric name followed by their
+			// sorted labels.
+			metricValues := map[string]int64{}
+
+			for i := 0; i < ms.Len(); i++ {
+				m := ms.At(i)
+
+				var dps pdata.IntDataPointSlice
+
+				switch m.DataType() {
+				case pdata.MetricDataTypeIntGauge:
+					dps = m.IntGauge().DataPoints()
+				case pdata.MetricDataTypeIntSum:
+					dps = m.IntSum().DataPoints()
+				}
+
+				for j := 0; j < dps.Len(); j++ {
+					var labels []string
+					dps.At(j).LabelsMap().Range(func(k string, v string) bool {
+						labels = append(labels, k+"="+v)
+						return true
+					})
+					sort.Strings(labels)
+					metricValues[strings.Join(append([]string{m.Name()}, labels...), " ")] = dps.At(j).Value()
+				}
+			}
+
+			expected := map[string]int64{}
+			for _, values := range test.expected {
+				for key, value := range values {
+					expected[key] = value
+				}
+			}
+			assert.Equal(t, expected, metricValues)
+		})
+	}
+}
+
+func TestScraperEndpoints(t *testing.T) {
+	endpoints := []string{newFakeMemcached(t), newFakeMemcached(t)}
+
+	sc := &memcachedScraper{
+		clients: map[string]*memcache.Client{},
+		logger:  zap.NewNop(),
+		config: &Config{
+			TCPAddr:   confignet.TCPAddr{Endpoint: "localhost:11211"},
+			Timeout:   10 * time.Second,
+			Endpoints: endpoints,
+		},
+	}
+	rms, err := sc.scrape(context.Background())
+	require.NoError(t, err)
+
+	// Every instance is reported as its own resource, and the endpoint is
+	// ignored.
+	require.Equal(t, 2, rms.Len())
+	for i, endpoint := range endpoints {
+		server, ok := rms.At(i).Resource().Attributes().Get(serverAttribute)
+		require.True(t, ok)
+		assert.Equal(t, endpoint, server.StringVal())
+		assert.Equal(t, 5, rms.At(i).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+	}
+}
+
+func TestScraperError(t *testing.T) {
+	t.Run("partial", func(t *testing.T) {
+		endpoint := newFakeMemcached(t)
+
+		sc := &memcachedScraper{
+			clients: map[string]*memcache.Client{},
+			logger:  zap.NewNop(),
+			config: &Config{
+				Timeout:   time.Second,
+				Endpoints: []string{closedEndpoint(t), endpoint},
+			},
+		}
+		rms, err := sc.scrape(context.Background())
+		require.Error(t, err)
+		require.True(t, scrapererror.IsPartialScrapeError(err))
+		// Only the metrics enabled are counted as failed.
+		assert.Equal(t, 5, err.(scrapererror.PartialScrapeError).Failed)
+
+		require.Equal(t, 1, rms.Len())
+		server, ok := rms.At(0).Resource().Attributes().Get(serverAttribute)
+		require.True(t, ok)
+		assert.Equal(t, endpoint, server.StringVal())
+		assert.Equal(t, 5, rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+	})
+
+	t.Run("all", func(t *testing.T) {
+		sc := &memcachedScraper{
+			clients: map[string]*memcache.Client{},
+			logger:  zap.NewNop(),
+			config: &Config{
+				TCPAddr: confignet.TCPAddr{Endpoint: closedEndpoint(t)},
+				Timeout: time.Second,
+			},
+		}
+		_, err := sc.scrape(context.Background())
+		require.Error(t, err)
+		require.False(t, scrapererror.IsPartialScrapeError(err))
+	})
+}
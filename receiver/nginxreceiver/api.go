@@ -0,0 +1,101 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxreceiver
+
+// plusConnections is the response of /connections.
+type plusConnections struct {
+	Accepted int64 `json:"accepted"`
+	Dropped  int64 `json:"dropped"`
+	Active   int64 `json:"active"`
+	Idle     int64 `json:"idle"`
+}
+
+// plusHTTPRequests is the response of /http/requests.
+type plusHTTPRequests struct {
+	Total   int64 `json:"total"`
+	Current int64 `json:"current"`
+}
+
+// plusSSL is the response of /ssl.
+type plusSSL struct {
+	Handshakes       int64 `json:"handshakes"`
+	HandshakesFailed int64 `json:"handshakes_failed"`
+	SessionReuses    int64 `json:"session_reuses"`
+}
+
+// plusResponses is the number of responses by class of status code.
+type plusResponses struct {
+	Responses1xx int64 `json:"1xx"`
+	Responses2xx int64 `json:"2xx"`
+	Responses3xx int64 `json:"3xx"`
+	Responses4xx int64 `json:"4xx"`
+	Responses5xx int64 `json:"5xx"`
+}
+
+// plusServerZone is a server zone of the response of /http/server_zones.
+type plusServerZone struct {
+	Processing int64         `json:"processing"`
+	Requests   int64         `json:"requests"`
+	Responses  plusResponses `json:"responses"`
+	Discarded  int64         `json:"discarded"`
+	Received   int64         `json:"received"`
+	Sent       int64         `json:"sent"`
+}
+
+// plusUpstream is an upstream of the response of /http/upstreams.
+type plusUpstream struct {
+	Peers     []plusPeer `json:"peers"`
+	Keepalive int64      `json:"keepalive"`
+	Zombies   int64      `json:"zombies"`
+}
+
+// plusPeer is a server of an upstream. The header and response times are
+// missing until the peer has served a request.
+type plusPeer struct {
+	Server       string        `json:"server"`
+	State        string        `json:"state"`
+	Active       int64         `json:"active"`
+	Requests     int64         `json:"requests"`
+	Responses    plusResponses `json:"responses"`
+	HeaderTime   *int64        `json:"header_time"`
+	ResponseTime *int64        `json:"response_time"`
+	Received     int64         `json:"received"`
+	Sent         int64         `json:"sent"`
+	Fails        int64         `json:"fails"`
+	Unavail      int64         `json:"unavail"`
+	HealthChecks struct {
+		Fails     int64 `json:"fails"`
+		Unhealthy int64 `json:"unhealthy"`
+	} `json:"health_checks"`
+}
+
+// plusCache is a cache of the response of /http/caches.
+type plusCache struct {
+	Size        int64           `json:"size"`
+	MaxSize     int64           `json:"max_size"`
+	Hit         plusCacheStatus `json:"hit"`
+	Stale       plusCacheStatus `json:"stale"`
+	Updating    plusCacheStatus `json:"updating"`
+	Revalidated plusCacheStatus `json:"revalidated"`
+	Miss        plusCacheStatus `json:"miss"`
+	Expired     plusCacheStatus `json:"expired"`
+	Bypass      plusCacheStatus `json:"bypass"`
+}
+
+// plusCacheStatus is the number of responses and bytes of a cache status.
+type plusCacheStatus struct {
+	Responses int64 `json:"responses"`
+	Bytes     int64 `json:"bytes"`
+}
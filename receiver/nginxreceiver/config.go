@@ -15,11 +15,35 @@
 package nginxreceiver
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
+const (
+	// modeStubStatus scrapes the status page of ngx_http_stub_status_module.
+	modeStubStatus = "stub_status"
+	// modeAPI scrapes the NGINX Plus REST API.
+	modeAPI = "api"
+)
+
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
+
+	// Mode is either stub_status, for Endpoint to be the URL of the stub
+	// status page, or api, for Endpoint to be the versioned URL of the NGINX
+	// Plus API, for instance http://localhost:8080/api/6.
+	Mode string `mapstructure:"mode"`
+}
+
+// Validate checks that the receiver configuration is valid.
+func (c *Config) Validate() error {
+	switch c.Mode {
+	case "", modeStubStatus, modeAPI:
+		return nil
+	default:
+		return fmt.Errorf("invalid mode %q, must be either %s or %s", c.Mode, modeStubStatus, modeAPI)
+	}
 }
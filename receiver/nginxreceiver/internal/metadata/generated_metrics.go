@@ -55,36 +55,126 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	NginxConnectionsAccepted MetricIntf
-	NginxConnectionsActive   MetricIntf
-	NginxConnectionsHandled  MetricIntf
-	NginxConnectionsReading  MetricIntf
-	NginxConnectionsWaiting  MetricIntf
-	NginxConnectionsWriting  MetricIntf
-	NginxRequests            MetricIntf
+	NginxCacheBytes                        MetricIntf
+	NginxCacheMaxSize                      MetricIntf
+	NginxCacheResponses                    MetricIntf
+	NginxCacheSize                         MetricIntf
+	NginxConnectionsAccepted               MetricIntf
+	NginxConnectionsActive                 MetricIntf
+	NginxConnectionsDropped                MetricIntf
+	NginxConnectionsHandled                MetricIntf
+	NginxConnectionsIdle                   MetricIntf
+	NginxConnectionsReading                MetricIntf
+	NginxConnectionsWaiting                MetricIntf
+	NginxConnectionsWriting                MetricIntf
+	NginxRequests                          MetricIntf
+	NginxRequestsCurrent                   MetricIntf
+	NginxServerZoneDiscarded               MetricIntf
+	NginxServerZoneProcessing              MetricIntf
+	NginxServerZoneReceived                MetricIntf
+	NginxServerZoneRequests                MetricIntf
+	NginxServerZoneResponses               MetricIntf
+	NginxServerZoneSent                    MetricIntf
+	NginxSslHandshakes                     MetricIntf
+	NginxSslHandshakesFailed               MetricIntf
+	NginxSslSessionReuses                  MetricIntf
+	NginxUpstreamKeepalive                 MetricIntf
+	NginxUpstreamPeerActive                MetricIntf
+	NginxUpstreamPeerFails                 MetricIntf
+	NginxUpstreamPeerHeaderTime            MetricIntf
+	NginxUpstreamPeerHealthChecksFails     MetricIntf
+	NginxUpstreamPeerHealthChecksUnhealthy MetricIntf
+	NginxUpstreamPeerReceived              MetricIntf
+	NginxUpstreamPeerRequests              MetricIntf
+	NginxUpstreamPeerResponseTime          MetricIntf
+	NginxUpstreamPeerResponses             MetricIntf
+	NginxUpstreamPeerSent                  MetricIntf
+	NginxUpstreamPeerState                 MetricIntf
+	NginxUpstreamPeerUnavailable           MetricIntf
+	NginxUpstreamZombies                   MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
+		"nginx.cache.bytes",
+		"nginx.cache.max_size",
+		"nginx.cache.responses",
+		"nginx.cache.size",
 		"nginx.connections_accepted",
 		"nginx.connections_active",
+		"nginx.connections_dropped",
 		"nginx.connections_handled",
+		"nginx.connections_idle",
 		"nginx.connections_reading",
 		"nginx.connections_waiting",
 		"nginx.connections_writing",
 		"nginx.requests",
+		"nginx.requests_current",
+		"nginx.server_zone.discarded",
+		"nginx.server_zone.processing",
+		"nginx.server_zone.received",
+		"nginx.server_zone.requests",
+		"nginx.server_zone.responses",
+		"nginx.server_zone.sent",
+		"nginx.ssl.handshakes",
+		"nginx.ssl.handshakes_failed",
+		"nginx.ssl.session_reuses",
+		"nginx.upstream.keepalive",
+		"nginx.upstream.peer.active",
+		"nginx.upstream.peer.fails",
+		"nginx.upstream.peer.header_time",
+		"nginx.upstream.peer.health_checks.fails",
+		"nginx.upstream.peer.health_checks.unhealthy",
+		"nginx.upstream.peer.received",
+		"nginx.upstream.peer.requests",
+		"nginx.upstream.peer.response_time",
+		"nginx.upstream.peer.responses",
+		"nginx.upstream.peer.sent",
+		"nginx.upstream.peer.state",
+		"nginx.upstream.peer.unavailable",
+		"nginx.upstream.zombies",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"nginx.connections_accepted": Metrics.NginxConnectionsAccepted,
-	"nginx.connections_active":   Metrics.NginxConnectionsActive,
-	"nginx.connections_handled":  Metrics.NginxConnectionsHandled,
-	"nginx.connections_reading":  Metrics.NginxConnectionsReading,
-	"nginx.connections_waiting":  Metrics.NginxConnectionsWaiting,
-	"nginx.connections_writing":  Metrics.NginxConnectionsWriting,
-	"nginx.requests":             Metrics.NginxRequests,
+	"nginx.cache.bytes":                           Metrics.NginxCacheBytes,
+	"nginx.cache.max_size":                        Metrics.NginxCacheMaxSize,
+	"nginx.cache.responses":                       Metrics.NginxCacheResponses,
+	"nginx.cache.size":                            Metrics.NginxCacheSize,
+	"nginx.connections_accepted":                  Metrics.NginxConnectionsAccepted,
+	"nginx.connections_active":                    Metrics.NginxConnectionsActive,
+	"nginx.connections_dropped":                   Metrics.NginxConnectionsDropped,
+	"nginx.connections_handled":                   Metrics.NginxConnectionsHandled,
+	"nginx.connections_idle":                      Metrics.NginxConnectionsIdle,
+	"nginx.connections_reading":                   Metrics.NginxConnectionsReading,
+	"nginx.connections_waiting":                   Metrics.NginxConnectionsWaiting,
+	"nginx.connections_writing":                   Metrics.NginxConnectionsWriting,
+	"nginx.requests":                              Metrics.NginxRequests,
+	"nginx.requests_current":                      Metrics.NginxRequestsCurrent,
+	"nginx.server_zone.discarded":                 Metrics.NginxServerZoneDiscarded,
+	"nginx.server_zone.processing":                Metrics.NginxServerZoneProcessing,
+	"nginx.server_zone.received":                  Metrics.NginxServerZoneReceived,
+	"nginx.server_zone.requests":                  Metrics.NginxServerZoneRequests,
+	"nginx.server_zone.responses":                 Metrics.NginxServerZoneResponses,
+	"nginx.server_zone.sent":                      Metrics.NginxServerZoneSent,
+	"nginx.ssl.handshakes":                        Metrics.NginxSslHandshakes,
+	"nginx.ssl.handshakes_failed":                 Metrics.NginxSslHandshakesFailed,
+	"nginx.ssl.session_reuses":                    Metrics.NginxSslSessionReuses,
+	"nginx.upstream.keepalive":                    Metrics.NginxUpstreamKeepalive,
+	"nginx.upstream.peer.active":                  Metrics.NginxUpstreamPeerActive,
+	"nginx.upstream.peer.fails":                   Metrics.NginxUpstreamPeerFails,
+	"nginx.upstream.peer.header_time":             Metrics.NginxUpstreamPeerHeaderTime,
+	"nginx.upstream.peer.health_checks.fails":     Metrics.NginxUpstreamPeerHealthChecksFails,
+	"nginx.upstream.peer.health_checks.unhealthy": Metrics.NginxUpstreamPeerHealthChecksUnhealthy,
+	"nginx.upstream.peer.received":                Metrics.NginxUpstreamPeerReceived,
+	"nginx.upstream.peer.requests":                Metrics.NginxUpstreamPeerRequests,
+	"nginx.upstream.peer.response_time":           Metrics.NginxUpstreamPeerResponseTime,
+	"nginx.upstream.peer.responses":               Metrics.NginxUpstreamPeerResponses,
+	"nginx.upstream.peer.sent":                    Metrics.NginxUpstreamPeerSent,
+	"nginx.upstream.peer.state":                   Metrics.NginxUpstreamPeerState,
+	"nginx.upstream.peer.unavailable":             Metrics.NginxUpstreamPeerUnavailable,
+	"nginx.upstream.zombies":                      Metrics.NginxUpstreamZombies,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -93,19 +183,89 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 
 func (m *metricStruct) FactoriesByName() map[string]func(pdata.Metric) {
 	return map[string]func(pdata.Metric){
-		Metrics.NginxConnectionsAccepted.Name(): Metrics.NginxConnectionsAccepted.Init,
-		Metrics.NginxConnectionsActive.Name():   Metrics.NginxConnectionsActive.Init,
-		Metrics.NginxConnectionsHandled.Name():  Metrics.NginxConnectionsHandled.Init,
-		Metrics.NginxConnectionsReading.Name():  Metrics.NginxConnectionsReading.Init,
-		Metrics.NginxConnectionsWaiting.Name():  Metrics.NginxConnectionsWaiting.Init,
-		Metrics.NginxConnectionsWriting.Name():  Metrics.NginxConnectionsWriting.Init,
-		Metrics.NginxRequests.Name():            Metrics.NginxRequests.Init,
+		Metrics.NginxCacheBytes.Name():                        Metrics.NginxCacheBytes.Init,
+		Metrics.NginxCacheMaxSize.Name():                      Metrics.NginxCacheMaxSize.Init,
+		Metrics.NginxCacheResponses.Name():                    Metrics.NginxCacheResponses.Init,
+		Metrics.NginxCacheSize.Name():                         Metrics.NginxCacheSize.Init,
+		Metrics.NginxConnectionsAccepted.Name():               Metrics.NginxConnectionsAccepted.Init,
+		Metrics.NginxConnectionsActive.Name():                 Metrics.NginxConnectionsActive.Init,
+		Metrics.NginxConnectionsDropped.Name():                Metrics.NginxConnectionsDropped.Init,
+		Metrics.NginxConnectionsHandled.Name():                Metrics.NginxConnectionsHandled.Init,
+		Metrics.NginxConnectionsIdle.Name():                   Metrics.NginxConnectionsIdle.Init,
+		Metrics.NginxConnectionsReading.Name():                Metrics.NginxConnectionsReading.Init,
+		Metrics.NginxConnectionsWaiting.Name():                Metrics.NginxConnectionsWaiting.Init,
+		Metrics.NginxConnectionsWriting.Name():                Metrics.NginxConnectionsWriting.Init,
+		Metrics.NginxRequests.Name():                          Metrics.NginxRequests.Init,
+		Metrics.NginxRequestsCurrent.Name():                   Metrics.NginxRequestsCurrent.Init,
+		Metrics.NginxServerZoneDiscarded.Name():               Metrics.NginxServerZoneDiscarded.Init,
+		Metrics.NginxServerZoneProcessing.Name():              Metrics.NginxServerZoneProcessing.Init,
+		Metrics.NginxServerZoneReceived.Name():                Metrics.NginxServerZoneReceived.Init,
+		Metrics.NginxServerZoneRequests.Name():                Metrics.NginxServerZoneRequests.Init,
+		Metrics.NginxServerZoneResponses.Name():               Metrics.NginxServerZoneResponses.Init,
+		Metrics.NginxServerZoneSent.Name():                    Metrics.NginxServerZoneSent.Init,
+		Metrics.NginxSslHandshakes.Name():                     Metrics.NginxSslHandshakes.Init,
+		Metrics.NginxSslHandshakesFailed.Name():               Metrics.NginxSslHandshakesFailed.Init,
+		Metrics.NginxSslSessionReuses.Name():                  Metrics.NginxSslSessionReuses.Init,
+		Metrics.NginxUpstreamKeepalive.Name():                 Metrics.NginxUpstreamKeepalive.Init,
+		Metrics.NginxUpstreamPeerActive.Name():                Metrics.NginxUpstreamPeerActive.Init,
+		Metrics.NginxUpstreamPeerFails.Name():                 Metrics.NginxUpstreamPeerFails.Init,
+		Metrics.NginxUpstreamPeerHeaderTime.Name():            Metrics.NginxUpstreamPeerHeaderTime.Init,
+		Metrics.NginxUpstreamPeerHealthChecksFails.Name():     Metrics.NginxUpstreamPeerHealthChecksFails.Init,
+		Metrics.NginxUpstreamPeerHealthChecksUnhealthy.Name(): Metrics.NginxUpstreamPeerHealthChecksUnhealthy.Init,
+		Metrics.NginxUpstreamPeerReceived.Name():              Metrics.NginxUpstreamPeerReceived.Init,
+		Metrics.NginxUpstreamPeerRequests.Name():              Metrics.NginxUpstreamPeerRequests.Init,
+		Metrics.NginxUpstreamPeerResponseTime.Name():          Metrics.NginxUpstreamPeerResponseTime.Init,
+		Metrics.NginxUpstreamPeerResponses.Name():             Metrics.NginxUpstreamPeerResponses.Init,
+		Metrics.NginxUpstreamPeerSent.Name():                  Metrics.NginxUpstreamPeerSent.Init,
+		Metrics.NginxUpstreamPeerState.Name():                 Metrics.NginxUpstreamPeerState.Init,
+		Metrics.NginxUpstreamPeerUnavailable.Name():           Metrics.NginxUpstreamPeerUnavailable.Init,
+		Metrics.NginxUpstreamZombies.Name():                   Metrics.NginxUpstreamZombies.Init,
 	}
 }
 
 // Metrics contains a set of methods for each metric that help with
 // manipulating those metrics.
 var Metrics = &metricStruct{
+	&metricImpl{
+		"nginx.cache.bytes",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.cache.bytes")
+			metric.SetDescription("The total number of bytes read from or written to the cache, by cache status. Only reported in api mode.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.cache.max_size",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.cache.max_size")
+			metric.SetDescription("The limit on the maximum size of the cache. Only reported in api mode.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.cache.responses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.cache.responses")
+			metric.SetDescription("The total number of responses read from or written to the cache, by cache status. Only reported in api mode.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.cache.size",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.cache.size")
+			metric.SetDescription("The current size of the cache. Only reported in api mode.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
 	&metricImpl{
 		"nginx.connections_accepted",
 		func(metric pdata.Metric) {
@@ -126,6 +286,17 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeIntGauge)
 		},
 	},
+	&metricImpl{
+		"nginx.connections_dropped",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.connections_dropped")
+			metric.SetDescription("The total number of dropped client connections. Only reported in api mode.")
+			metric.SetUnit("connections")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"nginx.connections_handled",
 		func(metric pdata.Metric) {
@@ -137,6 +308,15 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"nginx.connections_idle",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.connections_idle")
+			metric.SetDescription("The current number of idle client connections. Only reported in api mode.")
+			metric.SetUnit("connections")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
 	&metricImpl{
 		"nginx.connections_reading",
 		func(metric pdata.Metric) {
@@ -175,6 +355,254 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"nginx.requests_current",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.requests_current")
+			metric.SetDescription("The current number of client requests. Only reported in api mode.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.discarded",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.discarded")
+			metric.SetDescription("The total number of requests of the server zone completed without sending a response. Only reported in api mode.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.processing",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.processing")
+			metric.SetDescription("The current number of client requests being processed by the server zone. Only reported in api mode.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.received",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.received")
+			metric.SetDescription("The total number of bytes received from clients by the server zone. Only reported in api mode.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.requests",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.requests")
+			metric.SetDescription("The total number of client requests received by the server zone. Only reported in api mode.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.responses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.responses")
+			metric.SetDescription("The total number of responses sent to clients by the server zone, by class of status code. Only reported in api mode.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.sent",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.sent")
+			metric.SetDescription("The total number of bytes sent to clients by the server zone. Only reported in api mode.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.ssl.handshakes",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.ssl.handshakes")
+			metric.SetDescription("The total number of successful SSL handshakes. Only reported in api mode.")
+			metric.SetUnit("handshakes")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.ssl.handshakes_failed",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.ssl.handshakes_failed")
+			metric.SetDescription("The total number of failed SSL handshakes. Only reported in api mode.")
+			metric.SetUnit("handshakes")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.ssl.session_reuses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.ssl.session_reuses")
+			metric.SetDescription("The total number of session reuses during SSL handshakes. Only reported in api mode.")
+			metric.SetUnit("handshakes")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.keepalive",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.keepalive")
+			metric.SetDescription("The current number of idle keepalive connections of the upstream. Only reported in api mode.")
+			metric.SetUnit("connections")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.active",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.active")
+			metric.SetDescription("The current number of active connections to the upstream peer. Only reported in api mode.")
+			metric.SetUnit("connections")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.fails",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.fails")
+			metric.SetDescription("The total number of unsuccessful attempts to communicate with the upstream peer. Only reported in api mode.")
+			metric.SetUnit("attempts")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.header_time",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.header_time")
+			metric.SetDescription("The average time to get the response header from the upstream peer. Only reported in api mode.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.health_checks.fails",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.health_checks.fails")
+			metric.SetDescription("The number of failed health checks of the upstream peer. Only reported in api mode.")
+			metric.SetUnit("checks")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.health_checks.unhealthy",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.health_checks.unhealthy")
+			metric.SetDescription("The number of times the upstream peer became unhealthy. Only reported in api mode.")
+			metric.SetUnit("times")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.received",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.received")
+			metric.SetDescription("The total number of bytes received from the upstream peer. Only reported in api mode.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.requests",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.requests")
+			metric.SetDescription("The total number of client requests forwarded to the upstream peer. Only reported in api mode.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.response_time",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.response_time")
+			metric.SetDescription("The average time to get the full response from the upstream peer. Only reported in api mode.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.responses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.responses")
+			metric.SetDescription("The total number of responses obtained from the upstream peer, by class of status code. Only reported in api mode.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.sent",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.sent")
+			metric.SetDescription("The total number of bytes sent to the upstream peer. Only reported in api mode.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.state",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.state")
+			metric.SetDescription("Whether the upstream peer is currently in the state, 1 if it is and 0 otherwise. Only reported in api mode.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.unavailable",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.unavailable")
+			metric.SetDescription("The number of times the upstream peer became unavailable for client requests because of reaching max_fails. Only reported in api mode.")
+			metric.SetUnit("times")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.zombies",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.zombies")
+			metric.SetDescription("The current number of servers removed from the upstream but still processing active client requests. Only reported in api mode.")
+			metric.SetUnit("servers")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
 }
 
 // M contains a set of methods for each metric that help with
@@ -183,7 +611,26 @@ var M = Metrics
 
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
-}{}
+	// CacheStatus (The cache status of the responses, one of hit, stale, updating, revalidated, miss, expired or bypass)
+	CacheStatus string
+	// Peer (The address of the upstream peer)
+	Peer string
+	// State (The state of the upstream peer, one of up, draining, down, unavail, checking or unhealthy)
+	State string
+	// StatusCode (The class of the HTTP status code, one of 1xx, 2xx, 3xx, 4xx or 5xx)
+	StatusCode string
+	// Upstream (The name of the upstream)
+	Upstream string
+	// Zone (The name of the server zone, or of the shared memory zone of the cache)
+	Zone string
+}{
+	"cache_status",
+	"peer",
+	"state",
+	"status_code",
+	"upstream",
+	"zone",
+}
 
 // L contains the possible metric labels that can be used. L is an alias for
 // Labels.
@@ -21,13 +21,19 @@ import (
 	"github.com/nginxinc/nginx-prometheus-exporter/client"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/simple"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/jsonclient"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
 )
 
+// upstreamPeerStates are the states an upstream peer can be in.
+var upstreamPeerStates = []string{"up", "draining", "down", "unavail", "checking", "unhealthy"}
+
 type nginxScraper struct {
-	client *client.NginxClient
+	client     *client.NginxClient
+	plusClient *jsonclient.Client
 
 	logger *zap.Logger
 	cfg    *Config
@@ -44,32 +50,47 @@ func newNginxScraper(
 }
 
 func (r *nginxScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/nginx",
+	}
+
+	var err error
+	if r.cfg.Mode == modeAPI {
+		err = r.scrapeAPI(ctx, &metrics)
+	} else {
+		err = r.scrapeStubStatus(&metrics)
+	}
+	if err != nil && !scrapererror.IsPartialScrapeError(err) {
+		return pdata.ResourceMetricsSlice{}, err
+	}
+
+	return metrics.Metrics.ResourceMetrics(), err
+}
+
+// scrapeStubStatus adds the metrics of the stub status page.
+func (r *nginxScraper) scrapeStubStatus(metrics *simple.Metrics) error {
 	// Init client in scrape method in case there are transient errors in the
 	// constructor.
 	if r.client == nil {
 		httpClient, err := r.cfg.ToClient()
 		if err != nil {
-			return pdata.ResourceMetricsSlice{}, err
+			return err
 		}
 
 		r.client, err = client.NewNginxClient(httpClient, r.cfg.HTTPClientSettings.Endpoint)
 		if err != nil {
 			r.client = nil
-			return pdata.ResourceMetricsSlice{}, err
+			return err
 		}
 	}
 
-	metrics := simple.Metrics{
-		Metrics:                    pdata.NewMetrics(),
-		Timestamp:                  time.Now(),
-		MetricFactoriesByName:      metadata.M.FactoriesByName(),
-		InstrumentationLibraryName: "otelcol/nginx",
-	}
-
 	stats, err := r.client.GetStubStats()
 	if err != nil {
 		r.logger.Error("Failed to fetch nginx stats", zap.Error(err))
-		return pdata.ResourceMetricsSlice{}, err
+		return err
 	}
 
 	metrics.AddSumDataPoint(metadata.M.NginxRequests.Name(), stats.Requests)
@@ -80,5 +101,148 @@ func (r *nginxScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice,
 	metrics.AddGaugeDataPoint(metadata.M.NginxConnectionsWriting.Name(), stats.Connections.Writing)
 	metrics.AddGaugeDataPoint(metadata.M.NginxConnectionsWaiting.Name(), stats.Connections.Waiting)
 
-	return metrics.Metrics.ResourceMetrics(), nil
+	return nil
+}
+
+// scrapeAPI adds the metrics of the NGINX Plus API. Scraping fails if the
+// connections can't be fetched, while failing to fetch the other
+// statistics only fails their metrics.
+func (r *nginxScraper) scrapeAPI(ctx context.Context, metrics *simple.Metrics) error {
+	if r.plusClient == nil {
+		httpClient, err := r.cfg.ToClient()
+		if err != nil {
+			return err
+		}
+		r.plusClient = jsonclient.New(httpClient, r.cfg.Endpoint, "", "")
+	}
+
+	var connections plusConnections
+	if err := r.plusClient.Get(ctx, "/connections", &connections); err != nil {
+		r.logger.Error("Failed to fetch nginx connections", zap.Error(err))
+		return err
+	}
+	metrics.AddSumDataPoint(metadata.M.NginxConnectionsAccepted.Name(), connections.Accepted)
+	metrics.AddSumDataPoint(metadata.M.NginxConnectionsDropped.Name(), connections.Dropped)
+	metrics.AddGaugeDataPoint(metadata.M.NginxConnectionsActive.Name(), connections.Active)
+	metrics.AddGaugeDataPoint(metadata.M.NginxConnectionsIdle.Name(), connections.Idle)
+
+	var scrapeErrors scrapererror.ScrapeErrors
+
+	var requests plusHTTPRequests
+	if err := r.plusClient.Get(ctx, "/http/requests", &requests); err != nil {
+		r.logger.Error("Failed to fetch nginx requests", zap.Error(err))
+		scrapeErrors.AddPartial(2, err)
+	} else {
+		metrics.AddSumDataPoint(metadata.M.NginxRequests.Name(), requests.Total)
+		metrics.AddGaugeDataPoint(metadata.M.NginxRequestsCurrent.Name(), requests.Current)
+	}
+
+	var ssl plusSSL
+	if err := r.plusClient.Get(ctx, "/ssl", &ssl); err != nil {
+		r.logger.Error("Failed to fetch nginx SSL statistics", zap.Error(err))
+		scrapeErrors.AddPartial(3, err)
+	} else {
+		metrics.AddSumDataPoint(metadata.M.NginxSslHandshakes.Name(), ssl.Handshakes)
+		metrics.AddSumDataPoint(metadata.M.NginxSslHandshakesFailed.Name(), ssl.HandshakesFailed)
+		metrics.AddSumDataPoint(metadata.M.NginxSslSessionReuses.Name(), ssl.SessionReuses)
+	}
+
+	var serverZones map[string]plusServerZone
+	if err := r.plusClient.Get(ctx, "/http/server_zones", &serverZones); err != nil {
+		r.logger.Error("Failed to fetch nginx server zones", zap.Error(err))
+		scrapeErrors.AddPartial(6, err)
+	}
+	for name, zone := range serverZones {
+		zoneMetrics := metrics.WithLabels(map[string]string{metadata.L.Zone: name})
+		zoneMetrics.AddGaugeDataPoint(metadata.M.NginxServerZoneProcessing.Name(), zone.Processing)
+		zoneMetrics.AddSumDataPoint(metadata.M.NginxServerZoneRequests.Name(), zone.Requests)
+		addResponses(zoneMetrics, metadata.M.NginxServerZoneResponses, zone.Responses)
+		zoneMetrics.AddSumDataPoint(metadata.M.NginxServerZoneDiscarded.Name(), zone.Discarded)
+		zoneMetrics.AddSumDataPoint(metadata.M.NginxServerZoneReceived.Name(), zone.Received)
+		zoneMetrics.AddSumDataPoint(metadata.M.NginxServerZoneSent.Name(), zone.Sent)
+	}
+
+	var upstreams map[string]plusUpstream
+	if err := r.plusClient.Get(ctx, "/http/upstreams", &upstreams); err != nil {
+		r.logger.Error("Failed to fetch nginx upstreams", zap.Error(err))
+		scrapeErrors.AddPartial(14, err)
+	}
+	for name, upstream := range upstreams {
+		upstreamMetrics := metrics.WithLabels(map[string]string{metadata.L.Upstream: name})
+		upstreamMetrics.AddGaugeDataPoint(metadata.M.NginxUpstreamKeepalive.Name(), upstream.Keepalive)
+		upstreamMetrics.AddGaugeDataPoint(metadata.M.NginxUpstreamZombies.Name(), upstream.Zombies)
+
+		for _, peer := range upstream.Peers {
+			peerMetrics := upstreamMetrics.WithLabels(map[string]string{metadata.L.Peer: peer.Server})
+			for _, state := range upstreamPeerStates {
+				var value int64
+				if peer.State == state {
+					value = 1
+				}
+				peerMetrics.WithLabels(map[string]string{metadata.L.State: state}).
+					AddGaugeDataPoint(metadata.M.NginxUpstreamPeerState.Name(), value)
+			}
+			peerMetrics.AddGaugeDataPoint(metadata.M.NginxUpstreamPeerActive.Name(), peer.Active)
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerRequests.Name(), peer.Requests)
+			addResponses(peerMetrics, metadata.M.NginxUpstreamPeerResponses, peer.Responses)
+			if peer.HeaderTime != nil {
+				peerMetrics.AddGaugeDataPoint(metadata.M.NginxUpstreamPeerHeaderTime.Name(), *peer.HeaderTime)
+			}
+			if peer.ResponseTime != nil {
+				peerMetrics.AddGaugeDataPoint(metadata.M.NginxUpstreamPeerResponseTime.Name(), *peer.ResponseTime)
+			}
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerReceived.Name(), peer.Received)
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerSent.Name(), peer.Sent)
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerFails.Name(), peer.Fails)
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerUnavailable.Name(), peer.Unavail)
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerHealthChecksFails.Name(), peer.HealthChecks.Fails)
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerHealthChecksUnhealthy.Name(), peer.HealthChecks.Unhealthy)
+		}
+	}
+
+	var caches map[string]plusCache
+	if err := r.plusClient.Get(ctx, "/http/caches", &caches); err != nil {
+		r.logger.Error("Failed to fetch nginx caches", zap.Error(err))
+		scrapeErrors.AddPartial(4, err)
+	}
+	for name, cache := range caches {
+		cacheMetrics := metrics.WithLabels(map[string]string{metadata.L.Zone: name})
+		cacheMetrics.AddGaugeDataPoint(metadata.M.NginxCacheSize.Name(), cache.Size)
+		cacheMetrics.AddGaugeDataPoint(metadata.M.NginxCacheMaxSize.Name(), cache.MaxSize)
+		for _, s := range []struct {
+			status string
+			stats  plusCacheStatus
+		}{
+			{"hit", cache.Hit},
+			{"stale", cache.Stale},
+			{"updating", cache.Updating},
+			{"revalidated", cache.Revalidated},
+			{"miss", cache.Miss},
+			{"expired", cache.Expired},
+			{"bypass", cache.Bypass},
+		} {
+			statusMetrics := cacheMetrics.WithLabels(map[string]string{metadata.L.CacheStatus: s.status})
+			statusMetrics.AddSumDataPoint(metadata.M.NginxCacheResponses.Name(), s.stats.Responses)
+			statusMetrics.AddSumDataPoint(metadata.M.NginxCacheBytes.Name(), s.stats.Bytes)
+		}
+	}
+
+	return scrapeErrors.Combine()
+}
+
+// addResponses adds a data point for every class of status code.
+func addResponses(metrics *simple.Metrics, metric metadata.MetricIntf, responses plusResponses) {
+	for _, r := range []struct {
+		statusCode string
+		value      int64
+	}{
+		{"1xx", responses.Responses1xx},
+		{"2xx", responses.Responses2xx},
+		{"3xx", responses.Responses3xx},
+		{"4xx", responses.Responses4xx},
+		{"5xx", responses.Responses5xx},
+	} {
+		metrics.WithLabels(map[string]string{metadata.L.StatusCode: r.statusCode}).
+			AddSumDataPoint(metric.Name(), r.value)
+	}
 }
@@ -17,13 +17,19 @@ package nginxreceiver
 import (
 	"context"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 )
 
@@ -119,3 +125,182 @@ func TestScraperError(t *testing.T) {
 		require.Equal(t, errors.New("failed to parse response body \"Bad status page\": invalid input \"Bad status page\""), err)
 	})
 }
+
+// newPlusAPIMock serves the recorded responses of the NGINX Plus API under
+// /api/6, except for the paths that fail with the given status code.
+func newPlusAPIMock(t *testing.T, failures map[string]int) *httptest.Server {
+	responses := map[string][]byte{}
+	for path, file := range map[string]string{
+		"/api/6/connections":       "connections.json",
+		"/api/6/http/requests":     "http_requests.json",
+		"/api/6/ssl":               "ssl.json",
+		"/api/6/http/server_zones": "server_zones.json",
+		"/api/6/http/upstreams":    "upstreams.json",
+		"/api/6/http/caches":       "caches.json",
+	} {
+		response, err := ioutil.ReadFile(filepath.Join("testdata", "api", file))
+		require.NoError(t, err)
+		responses[path] = response
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if statusCode, ok := failures[req.URL.Path]; ok {
+			rw.WriteHeader(statusCode)
+			return
+		}
+		if response, ok := responses[req.URL.Path]; ok {
+			rw.WriteHeader(200)
+			_, _ = rw.Write(response)
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+}
+
+func TestScraperAPI(t *testing.T) {
+	nginxMock := newPlusAPIMock(t, nil)
+	defer nginxMock.Close()
+
+	for _, endpoint := range []string{nginxMock.URL + "/api/6", nginxMock.URL + "/api/6/"} {
+		t.Run(endpoint, func(t *testing.T) {
+			sc := newNginxScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: endpoint,
+				},
+				Mode: modeAPI,
+			})
+			rms, err := sc.scrape(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, 1, rms.Len())
+			rm := rms.At(0)
+
+			ilms := rm.InstrumentationLibraryMetrics()
+			require.Equal(t, 1, ilms.Len())
+
+			ilm := ilms.At(0)
+			ms := ilm.Metrics()
+
+			// The data points are keyed by the metric name followed by their
+			// sorted labels.
+			values := make(map[string]int64, 87)
+
+			for i := 0; i < ms.Len(); i++ {
+				m := ms.At(i)
+
+				var dps pdata.IntDataPointSlice
+
+				switch m.DataType() {
+				case pdata.MetricDataTypeIntGauge:
+					dps = m.IntGauge().DataPoints()
+				case pdata.MetricDataTypeIntSum:
+					dps = m.IntSum().DataPoints()
+				}
+
+				for j := 0; j < dps.Len(); j++ {
+					var labels []string
+					dps.At(j).LabelsMap().Range(func(k string, v string) bool {
+						labels = append(labels, k+"="+v)
+						return true
+					})
+					sort.Strings(labels)
+					values[strings.Join(append([]string{m.Name()}, labels...), " ")] = dps.At(j).Value()
+				}
+			}
+			require.Len(t, values, 87)
+
+			// peer returns the key of the data point of an upstream peer
+			// metric, whose other labels sort between peer and upstream.
+			peer := func(metric string, address string, labels ...string) string {
+				return strings.Join(append(append([]string{metric, "peer=" + address}, labels...), "upstream=trac-backend"), " ")
+			}
+			for key, value := range map[string]int64{
+				"nginx.connections_accepted":                                              4968119,
+				"nginx.connections_dropped":                                               3,
+				"nginx.connections_active":                                                5,
+				"nginx.connections_idle":                                                  117,
+				"nginx.requests":                                                          10624511,
+				"nginx.requests_current":                                                  4,
+				"nginx.ssl.handshakes":                                                    79572,
+				"nginx.ssl.handshakes_failed":                                             21025,
+				"nginx.ssl.session_reuses":                                                15762,
+				"nginx.server_zone.processing zone=hg.nginx.org":                          1,
+				"nginx.server_zone.requests zone=trac.nginx.org":                          534912,
+				"nginx.server_zone.responses status_code=5xx zone=hg.nginx.org":           86,
+				"nginx.server_zone.discarded zone=hg.nginx.org":                           23,
+				"nginx.server_zone.sent zone=trac.nginx.org":                              14235467821,
+				"nginx.upstream.keepalive upstream=trac-backend":                          8,
+				"nginx.upstream.zombies upstream=trac-backend":                            0,
+				peer("nginx.upstream.peer.state", "10.0.0.1:8080", "state=up"):            1,
+				peer("nginx.upstream.peer.state", "10.0.0.1:8080", "state=unhealthy"):     0,
+				peer("nginx.upstream.peer.state", "10.0.0.2:8080", "state=up"):            0,
+				peer("nginx.upstream.peer.state", "10.0.0.2:8080", "state=unhealthy"):     1,
+				peer("nginx.upstream.peer.active", "10.0.0.1:8080"):                       2,
+				peer("nginx.upstream.peer.requests", "10.0.0.1:8080"):                     534912,
+				peer("nginx.upstream.peer.responses", "10.0.0.1:8080", "status_code=4xx"): 23654,
+				peer("nginx.upstream.peer.header_time", "10.0.0.1:8080"):                  21,
+				peer("nginx.upstream.peer.response_time", "10.0.0.1:8080"):                87,
+				peer("nginx.upstream.peer.received", "10.0.0.1:8080"):                     14235467821,
+				peer("nginx.upstream.peer.fails", "10.0.0.1:8080"):                        4,
+				peer("nginx.upstream.peer.unavailable", "10.0.0.1:8080"):                  1,
+				peer("nginx.upstream.peer.health_checks.fails", "10.0.0.2:8080"):          7125,
+				peer("nginx.upstream.peer.health_checks.unhealthy", "10.0.0.2:8080"):      1,
+				"nginx.cache.size zone=http_cache":                                        530915328,
+				"nginx.cache.max_size zone=http_cache":                                    536870912,
+				"nginx.cache.responses cache_status=hit zone=http_cache":                  254032,
+				"nginx.cache.bytes cache_status=miss zone=http_cache":                     53841943822,
+			} {
+				if assert.Contains(t, values, key) {
+					assert.Equal(t, value, values[key], key)
+				}
+			}
+
+			// The peer that hasn't served any request has no header and
+			// response times.
+			assert.NotContains(t, values, peer("nginx.upstream.peer.header_time", "10.0.0.2:8080"))
+			assert.NotContains(t, values, peer("nginx.upstream.peer.response_time", "10.0.0.2:8080"))
+		})
+	}
+}
+
+func TestScraperAPIError(t *testing.T) {
+	t.Run("connections", func(t *testing.T) {
+		nginxMock := newPlusAPIMock(t, map[string]int{"/api/6/connections": 404})
+		defer nginxMock.Close()
+
+		sc := newNginxScraper(zap.NewNop(), &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: nginxMock.URL + "/api/6",
+			},
+			Mode: modeAPI,
+		})
+		_, err := sc.scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response from /connections, got 404")
+		require.False(t, scrapererror.IsPartialScrapeError(err))
+	})
+
+	t.Run("caches", func(t *testing.T) {
+		nginxMock := newPlusAPIMock(t, map[string]int{"/api/6/http/caches": 500})
+		defer nginxMock.Close()
+
+		sc := newNginxScraper(zap.NewNop(), &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: nginxMock.URL + "/api/6",
+			},
+			Mode: modeAPI,
+		})
+		rms, err := sc.scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response from /http/caches, got 500")
+		require.True(t, scrapererror.IsPartialScrapeError(err))
+
+		// The metrics of the other endpoints are still reported.
+		require.Equal(t, 1, rms.Len())
+		ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+		var names []string
+		for i := 0; i < ms.Len(); i++ {
+			names = append(names, ms.At(i).Name())
+		}
+		require.Contains(t, names, "nginx.upstream.peer.state")
+		require.NotContains(t, names, "nginx.cache.size")
+	})
+}
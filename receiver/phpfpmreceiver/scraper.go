@@ -0,0 +1,148 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package phpfpmreceiver
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/jsonclient"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/phpfpmreceiver/internal/metadata"
+)
+
+// poolStatus is the full status of a pool exported as JSON.
+type poolStatus struct {
+	Pool               string          `json:"pool"`
+	StartSince         int64           `json:"start since"`
+	AcceptedConn       int64           `json:"accepted conn"`
+	ListenQueue        int64           `json:"listen queue"`
+	MaxListenQueue     int64           `json:"max listen queue"`
+	ListenQueueLen     int64           `json:"listen queue len"`
+	IdleProcesses      int64           `json:"idle processes"`
+	ActiveProcesses    int64           `json:"active processes"`
+	MaxActiveProcesses int64           `json:"max active processes"`
+	MaxChildrenReached int64           `json:"max children reached"`
+	SlowRequests       int64           `json:"slow requests"`
+	Processes          []processStatus `json:"processes"`
+}
+
+// processStatus is the status of a process of the pool, only exported with
+// the full query flag.
+type processStatus struct {
+	PID               int64 `json:"pid"`
+	Requests          int64 `json:"requests"`
+	RequestDuration   int64 `json:"request duration"`
+	LastRequestMemory int64 `json:"last request memory"`
+}
+
+type phpfpmScraper struct {
+	client     *jsonclient.Client
+	statusPath string
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newPHPFPMScraper(
+	logger *zap.Logger,
+	cfg *Config,
+) *phpfpmScraper {
+	return &phpfpmScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *phpfpmScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	// Init client in scrape method in case there are transient errors in the
+	// constructor.
+	if r.client == nil {
+		u, err := url.Parse(r.cfg.Endpoint)
+		if err != nil {
+			return pdata.ResourceMetricsSlice{}, err
+		}
+		// The status page is exported as JSON when the json query flag is
+		// set, along with the status of every process when the full query
+		// flag is set too.
+		flags := []string{"json"}
+		if r.cfg.IncludeProcessMetrics {
+			flags = append(flags, "full")
+		}
+		query := u.Query()
+		for _, flag := range flags {
+			if _, ok := query[flag]; !ok {
+				if u.RawQuery != "" {
+					u.RawQuery += "&"
+				}
+				u.RawQuery += flag
+			}
+		}
+
+		httpClient, err := r.cfg.ToClient()
+		if err != nil {
+			return pdata.ResourceMetricsSlice{}, err
+		}
+
+		r.client = jsonclient.New(httpClient, (&url.URL{Scheme: u.Scheme, User: u.User, Host: u.Host}).String(), "", "")
+		r.statusPath = u.RequestURI()
+	}
+
+	var status poolStatus
+	if err := r.client.Get(ctx, r.statusPath, &status); err != nil {
+		r.logger.Error("Failed to fetch PHP-FPM status", zap.Error(err))
+		return pdata.ResourceMetricsSlice{}, err
+	}
+
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/phpfpm",
+	}
+	poolMetrics := metrics.WithLabels(map[string]string{metadata.L.Pool: status.Pool})
+
+	poolMetrics.AddSumDataPoint(metadata.M.PhpfpmUptime.Name(), status.StartSince)
+	poolMetrics.AddSumDataPoint(metadata.M.PhpfpmConnectionsAccepted.Name(), status.AcceptedConn)
+	poolMetrics.AddGaugeDataPoint(metadata.M.PhpfpmListenQueueCurrent.Name(), status.ListenQueue)
+	poolMetrics.AddGaugeDataPoint(metadata.M.PhpfpmListenQueueMax.Name(), status.MaxListenQueue)
+	poolMetrics.AddGaugeDataPoint(metadata.M.PhpfpmListenQueueSize.Name(), status.ListenQueueLen)
+	poolMetrics.WithLabels(map[string]string{metadata.L.ProcessState: "active"}).
+		AddGaugeDataPoint(metadata.M.PhpfpmProcesses.Name(), status.ActiveProcesses)
+	poolMetrics.WithLabels(map[string]string{metadata.L.ProcessState: "idle"}).
+		AddGaugeDataPoint(metadata.M.PhpfpmProcesses.Name(), status.IdleProcesses)
+	poolMetrics.AddGaugeDataPoint(metadata.M.PhpfpmProcessesMaxActive.Name(), status.MaxActiveProcesses)
+	poolMetrics.AddSumDataPoint(metadata.M.PhpfpmMaxChildrenReached.Name(), status.MaxChildrenReached)
+	poolMetrics.AddSumDataPoint(metadata.M.PhpfpmSlowRequests.Name(), status.SlowRequests)
+
+	if !r.cfg.IncludeProcessMetrics {
+		return metrics.Metrics.ResourceMetrics(), nil
+	}
+	for _, process := range status.Processes {
+		processMetrics := poolMetrics.WithLabels(map[string]string{
+			metadata.L.Pid: strconv.FormatInt(process.PID, 10),
+		})
+		processMetrics.AddSumDataPoint(metadata.M.PhpfpmProcessRequests.Name(), process.Requests)
+		processMetrics.AddGaugeDataPoint(metadata.M.PhpfpmProcessLastRequestDuration.Name(), process.RequestDuration)
+		processMetrics.AddGaugeDataPoint(metadata.M.PhpfpmProcessLastRequestMemory.Name(), process.LastRequestMemory)
+	}
+
+	return metrics.Metrics.ResourceMetrics(), nil
+}
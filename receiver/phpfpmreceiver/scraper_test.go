@@ -0,0 +1,180 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package phpfpmreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestScraper(t *testing.T) {
+	status, err := ioutil.ReadFile(filepath.Join("testdata", "status.json"))
+	require.NoError(t, err)
+
+	var query string
+	phpfpmMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/status" {
+			query = req.URL.RawQuery
+			rw.WriteHeader(200)
+			_, _ = rw.Write(status)
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer phpfpmMock.Close()
+
+	poolMetrics := map[string]int64{
+		"phpfpm.uptime pool=www":                         3722,
+		"phpfpm.connections.accepted pool=www":           1538,
+		"phpfpm.listen_queue.current pool=www":           0,
+		"phpfpm.listen_queue.max pool=www":               4,
+		"phpfpm.listen_queue.size pool=www":              511,
+		"phpfpm.processes pool=www process_state=active": 1,
+		"phpfpm.processes pool=www process_state=idle":   2,
+		"phpfpm.processes.max_active pool=www":           5,
+		"phpfpm.max_children_reached pool=www":           1,
+		"phpfpm.slow_requests pool=www":                  2,
+	}
+	processMetrics := map[string]int64{
+		"phpfpm.process.requests pid=21 pool=www":              533,
+		"phpfpm.process.requests pid=22 pool=www":              512,
+		"phpfpm.process.requests pid=38 pool=www":              493,
+		"phpfpm.process.last_request.duration pid=21 pool=www": 1207,
+		"phpfpm.process.last_request.duration pid=22 pool=www": 152,
+		"phpfpm.process.last_request.duration pid=38 pool=www": 25013,
+		"phpfpm.process.last_request.memory pid=21 pool=www":   2097152,
+		"phpfpm.process.last_request.memory pid=22 pool=www":   0,
+		"phpfpm.process.last_request.memory pid=38 pool=www":   4194304,
+	}
+	allMetrics := map[string]int64{}
+	for _, m := range []map[string]int64{poolMetrics, processMetrics} {
+		for k, v := range m {
+			allMetrics[k] = v
+		}
+	}
+
+	// The json query flag is added when missing, as well as the full query
+	// flag when the process metrics are enabled.
+	testcases := []struct {
+		endpoint              string
+		includeProcessMetrics bool
+		query                 string
+		metrics               map[string]int64
+	}{
+		{endpoint: "/status", query: "json", metrics: poolMetrics},
+		{endpoint: "/status?json", query: "json", metrics: poolMetrics},
+		{endpoint: "/status?json&full", query: "json&full", metrics: poolMetrics},
+		{endpoint: "/status", includeProcessMetrics: true, query: "json&full", metrics: allMetrics},
+		{endpoint: "/status?json", includeProcessMetrics: true, query: "json&full", metrics: allMetrics},
+		{endpoint: "/status?json&full", includeProcessMetrics: true, query: "json&full", metrics: allMetrics},
+	}
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s include_process_metrics=%t", tc.endpoint, tc.includeProcessMetrics), func(t *testing.T) {
+			sc := newPHPFPMScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: phpfpmMock.URL + tc.endpoint,
+				},
+				IncludeProcessMetrics: tc.includeProcessMetrics,
+			})
+			rms, err := sc.scrape(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.query, query)
+
+			require.Equal(t, 1, rms.Len())
+			rm := rms.At(0)
+
+			ilms := rm.InstrumentationLibraryMetrics()
+			require.Equal(t, 1, ilms.Len())
+
+			ilm := ilms.At(0)
+			ms := ilm.Metrics()
+
+			// The data points are keyed by the metric name followed by their
+			// sorted labels.
+			metricValues := make(map[string]int64, len(tc.metrics))
+
+			for i := 0; i < ms.Len(); i++ {
+				m := ms.At(i)
+
+				var dps pdata.IntDataPointSlice
+
+				switch m.DataType() {
+				case pdata.MetricDataTypeIntGauge:
+					dps = m.IntGauge().DataPoints()
+				case pdata.MetricDataTypeIntSum:
+					dps = m.IntSum().DataPoints()
+				}
+
+				for j := 0; j < dps.Len(); j++ {
+					var labels []string
+					dps.At(j).LabelsMap().Range(func(k string, v string) bool {
+						labels = append(labels, k+"="+v)
+						return true
+					})
+					sort.Strings(labels)
+					metricValues[strings.Join(append([]string{m.Name()}, labels...), " ")] = dps.At(j).Value()
+				}
+			}
+
+			require.Equal(t, tc.metrics, metricValues)
+		})
+	}
+}
+
+func TestScraperError(t *testing.T) {
+	phpfpmMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/status" {
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte(`Bad status page`))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer phpfpmMock.Close()
+
+	t.Run("404", func(t *testing.T) {
+		sc := newPHPFPMScraper(zap.NewNop(), &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: phpfpmMock.URL + "/badpath",
+			},
+		})
+
+		_, err := sc.scrape(context.Background())
+		require.Equal(t, errors.New("expected 200 response from /badpath?json, got 404"), err)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		sc := newPHPFPMScraper(zap.NewNop(), &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: phpfpmMock.URL + "/status",
+			},
+		})
+		_, err := sc.scrape(context.Background())
+		require.EqualError(t, err, `failed to parse response body from /status?json: invalid character 'B' looking for beginning of value`)
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmqreceiver
+
+// overview is the response of /api/overview.
+type overview struct {
+	MessageStats messageStats `json:"message_stats"`
+	QueueTotals  struct {
+		MessagesReady          int64 `json:"messages_ready"`
+		MessagesUnacknowledged int64 `json:"messages_unacknowledged"`
+	} `json:"queue_totals"`
+	ObjectTotals struct {
+		Connections int64 `json:"connections"`
+		Channels    int64 `json:"channels"`
+		Queues      int64 `json:"queues"`
+		Consumers   int64 `json:"consumers"`
+	} `json:"object_totals"`
+}
+
+// queue is a queue of the response of /api/queues.
+type queue struct {
+	Name                   string       `json:"name"`
+	VHost                  string       `json:"vhost"`
+	MessagesReady          int64        `json:"messages_ready"`
+	MessagesUnacknowledged int64        `json:"messages_unacknowledged"`
+	Consumers              int64        `json:"consumers"`
+	Memory                 int64        `json:"memory"`
+	MessageStats           messageStats `json:"message_stats"`
+}
+
+// node is a node of the response of /api/nodes. The statistics of a node
+// that isn't running are missing.
+type node struct {
+	Name          string `json:"name"`
+	Running       bool   `json:"running"`
+	MemUsed       *int64 `json:"mem_used"`
+	MemLimit      *int64 `json:"mem_limit"`
+	MemAlarm      *bool  `json:"mem_alarm"`
+	DiskFree      *int64 `json:"disk_free"`
+	DiskFreeLimit *int64 `json:"disk_free_limit"`
+	DiskFreeAlarm *bool  `json:"disk_free_alarm"`
+	FDUsed        *int64 `json:"fd_used"`
+	FDTotal       *int64 `json:"fd_total"`
+}
+
+// messageStats are the message counters and their rates. They are missing
+// until the first message goes through.
+type messageStats struct {
+	Publish           *int64       `json:"publish"`
+	PublishDetails    *rateDetails `json:"publish_details"`
+	DeliverGet        *int64       `json:"deliver_get"`
+	DeliverGetDetails *rateDetails `json:"deliver_get_details"`
+	Ack               *int64       `json:"ack"`
+	AckDetails        *rateDetails `json:"ack_details"`
+}
+
+// rateDetails is the rate of a message counter.
+type rateDetails struct {
+	Rate float64 `json:"rate"`
+}
@@ -0,0 +1,62 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmqreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	confighttp.HTTPClientSettings           `mapstructure:",squash"`
+
+	// Username and Password authenticate requests to the management API
+	// with basic auth.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// IncludeQueues is a regular expression matching the names of the queues
+	// to report. All queues are reported when empty.
+	IncludeQueues string `mapstructure:"include_queues"`
+
+	// ExcludeQueues is a regular expression matching the names of the queues
+	// not to report, even if they match IncludeQueues.
+	ExcludeQueues string `mapstructure:"exclude_queues"`
+}
+
+// Validate checks that the receiver configuration is valid.
+func (c *Config) Validate() error {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", c.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("endpoint must be an http or https URL")
+	}
+
+	if _, err := regexp.Compile(c.IncludeQueues); err != nil {
+		return fmt.Errorf("invalid include_queues: %w", err)
+	}
+	if _, err := regexp.Compile(c.ExcludeQueues); err != nil {
+		return fmt.Errorf("invalid exclude_queues: %w", err)
+	}
+	return nil
+}
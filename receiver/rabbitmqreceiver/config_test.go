@@ -0,0 +1,40 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmqreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+func TestValidate(t *testing.T) {
+	newConfig := func(endpoint string, includeQueues string, excludeQueues string) *Config {
+		return &Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: endpoint,
+			},
+			IncludeQueues: includeQueues,
+			ExcludeQueues: excludeQueues,
+		}
+	}
+
+	require.NoError(t, newConfig("http://localhost:15672", "", "").Validate())
+	require.NoError(t, newConfig("https://localhost:15671", "^orders$", `^amq\.`).Validate())
+	require.EqualError(t, newConfig("localhost:15672", "", "").Validate(), "endpoint must be an http or https URL")
+	require.EqualError(t, newConfig("http://localhost:15672", "(", "").Validate(), "invalid include_queues: error parsing regexp: missing closing ): `(`")
+	require.EqualError(t, newConfig("http://localhost:15672", "", "[").Validate(), "invalid exclude_queues: error parsing regexp: missing closing ]: `[`")
+}
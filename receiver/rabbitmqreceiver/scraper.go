@@ -0,0 +1,219 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmqreceiver
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/jsonclient"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqreceiver/internal/metadata"
+)
+
+type rabbitmqScraper struct {
+	client        *jsonclient.Client
+	includeQueues *regexp.Regexp
+	excludeQueues *regexp.Regexp
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newRabbitMQScraper(
+	logger *zap.Logger,
+	cfg *Config,
+) *rabbitmqScraper {
+	return &rabbitmqScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// scrape reports the statistics of the cluster, of its queues and of its
+// nodes. Scraping fails if the overview of the cluster can't be fetched,
+// while failing to fetch the queues or the nodes only fails their metrics.
+func (r *rabbitmqScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	// Init client in scrape method in case there are transient errors in the
+	// constructor.
+	if r.client == nil {
+		var err error
+		if r.cfg.IncludeQueues != "" {
+			if r.includeQueues, err = regexp.Compile(r.cfg.IncludeQueues); err != nil {
+				return pdata.ResourceMetricsSlice{}, err
+			}
+		}
+		if r.cfg.ExcludeQueues != "" {
+			if r.excludeQueues, err = regexp.Compile(r.cfg.ExcludeQueues); err != nil {
+				return pdata.ResourceMetricsSlice{}, err
+			}
+		}
+
+		httpClient, err := r.cfg.ToClient()
+		if err != nil {
+			return pdata.ResourceMetricsSlice{}, err
+		}
+		r.client = jsonclient.New(httpClient, r.cfg.Endpoint, r.cfg.Username, r.cfg.Password)
+	}
+
+	var overview overview
+	if err := r.client.Get(ctx, "/api/overview", &overview); err != nil {
+		r.logger.Error("Failed to fetch RabbitMQ overview", zap.Error(err))
+		return pdata.ResourceMetricsSlice{}, err
+	}
+
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/rabbitmq",
+	}
+
+	metrics.AddGaugeDataPoint(metadata.M.RabbitmqConnections.Name(), overview.ObjectTotals.Connections)
+	metrics.AddGaugeDataPoint(metadata.M.RabbitmqChannels.Name(), overview.ObjectTotals.Channels)
+	metrics.AddGaugeDataPoint(metadata.M.RabbitmqQueues.Name(), overview.ObjectTotals.Queues)
+	metrics.AddGaugeDataPoint(metadata.M.RabbitmqConsumers.Name(), overview.ObjectTotals.Consumers)
+	addMessages(&metrics, metadata.M.RabbitmqMessages, overview.QueueTotals.MessagesReady, overview.QueueTotals.MessagesUnacknowledged)
+	addMessageStats(&metrics, overview.MessageStats, messageStatsMetrics{
+		published:    metadata.M.RabbitmqMessagesPublished,
+		delivered:    metadata.M.RabbitmqMessagesDelivered,
+		acknowledged: metadata.M.RabbitmqMessagesAcknowledged,
+		publishRate:  metadata.M.RabbitmqMessagesPublishRate,
+		deliverRate:  metadata.M.RabbitmqMessagesDeliverRate,
+		ackRate:      metadata.M.RabbitmqMessagesAckRate,
+	})
+
+	var scrapeErrors scrapererror.ScrapeErrors
+
+	var queues []queue
+	if err := r.client.Get(ctx, "/api/queues", &queues); err != nil {
+		r.logger.Error("Failed to fetch RabbitMQ queues", zap.Error(err))
+		scrapeErrors.AddPartial(9, err)
+	}
+	for _, q := range queues {
+		if !r.reportQueue(q.Name) {
+			continue
+		}
+
+		queueMetrics := metrics.WithLabels(map[string]string{
+			metadata.L.Vhost: q.VHost,
+			metadata.L.Queue: q.Name,
+		})
+		addMessages(queueMetrics, metadata.M.RabbitmqQueueMessages, q.MessagesReady, q.MessagesUnacknowledged)
+		queueMetrics.AddGaugeDataPoint(metadata.M.RabbitmqQueueConsumers.Name(), q.Consumers)
+		queueMetrics.AddGaugeDataPoint(metadata.M.RabbitmqQueueMemory.Name(), q.Memory)
+		addMessageStats(queueMetrics, q.MessageStats, messageStatsMetrics{
+			published:    metadata.M.RabbitmqQueueMessagesPublished,
+			delivered:    metadata.M.RabbitmqQueueMessagesDelivered,
+			acknowledged: metadata.M.RabbitmqQueueMessagesAcknowledged,
+			publishRate:  metadata.M.RabbitmqQueueMessagesPublishRate,
+			deliverRate:  metadata.M.RabbitmqQueueMessagesDeliverRate,
+			ackRate:      metadata.M.RabbitmqQueueMessagesAckRate,
+		})
+	}
+
+	var nodes []node
+	if err := r.client.Get(ctx, "/api/nodes", &nodes); err != nil {
+		r.logger.Error("Failed to fetch RabbitMQ nodes", zap.Error(err))
+		scrapeErrors.AddPartial(9, err)
+	}
+	for _, n := range nodes {
+		nodeMetrics := metrics.WithLabels(map[string]string{metadata.L.Node: n.Name})
+		nodeMetrics.AddGaugeDataPoint(metadata.M.RabbitmqNodeRunning.Name(), boolToInt(n.Running))
+		addOptionalGauge(nodeMetrics, metadata.M.RabbitmqNodeMemoryUsed, n.MemUsed)
+		addOptionalGauge(nodeMetrics, metadata.M.RabbitmqNodeMemoryLimit, n.MemLimit)
+		if n.MemAlarm != nil {
+			nodeMetrics.AddGaugeDataPoint(metadata.M.RabbitmqNodeMemoryAlarm.Name(), boolToInt(*n.MemAlarm))
+		}
+		addOptionalGauge(nodeMetrics, metadata.M.RabbitmqNodeDiskFree, n.DiskFree)
+		addOptionalGauge(nodeMetrics, metadata.M.RabbitmqNodeDiskFreeLimit, n.DiskFreeLimit)
+		if n.DiskFreeAlarm != nil {
+			nodeMetrics.AddGaugeDataPoint(metadata.M.RabbitmqNodeDiskAlarm.Name(), boolToInt(*n.DiskFreeAlarm))
+		}
+		addOptionalGauge(nodeMetrics, metadata.M.RabbitmqNodeFdUsed, n.FDUsed)
+		addOptionalGauge(nodeMetrics, metadata.M.RabbitmqNodeFdTotal, n.FDTotal)
+	}
+
+	return metrics.Metrics.ResourceMetrics(), scrapeErrors.Combine()
+}
+
+// reportQueue returns whether the metrics of the queue are reported
+// according to the queue filters.
+func (r *rabbitmqScraper) reportQueue(name string) bool {
+	if r.includeQueues != nil && !r.includeQueues.MatchString(name) {
+		return false
+	}
+	return r.excludeQueues == nil || !r.excludeQueues.MatchString(name)
+}
+
+// messageStatsMetrics are the metrics the message counters and their rates
+// are reported as.
+type messageStatsMetrics struct {
+	published    metadata.MetricIntf
+	delivered    metadata.MetricIntf
+	acknowledged metadata.MetricIntf
+	publishRate  metadata.MetricIntf
+	deliverRate  metadata.MetricIntf
+	ackRate      metadata.MetricIntf
+}
+
+// addMessageStats adds a data point for every message counter and rate that
+// is present.
+func addMessageStats(metrics *simple.Metrics, stats messageStats, m messageStatsMetrics) {
+	for _, c := range []struct {
+		count   *int64
+		details *rateDetails
+		counter metadata.MetricIntf
+		rate    metadata.MetricIntf
+	}{
+		{stats.Publish, stats.PublishDetails, m.published, m.publishRate},
+		{stats.DeliverGet, stats.DeliverGetDetails, m.delivered, m.deliverRate},
+		{stats.Ack, stats.AckDetails, m.acknowledged, m.ackRate},
+	} {
+		if c.count != nil {
+			metrics.AddSumDataPoint(c.counter.Name(), *c.count)
+		}
+		if c.details != nil {
+			metrics.AddDGaugeDataPoint(c.rate.Name(), c.details.Rate)
+		}
+	}
+}
+
+// addMessages adds a data point for the ready and the unacknowledged
+// messages.
+func addMessages(metrics *simple.Metrics, metric metadata.MetricIntf, ready int64, unacknowledged int64) {
+	metrics.WithLabels(map[string]string{metadata.L.MessageState: "ready"}).
+		AddGaugeDataPoint(metric.Name(), ready)
+	metrics.WithLabels(map[string]string{metadata.L.MessageState: "unacknowledged"}).
+		AddGaugeDataPoint(metric.Name(), unacknowledged)
+}
+
+func addOptionalGauge(metrics *simple.Metrics, metric metadata.MetricIntf, value *int64) {
+	if value != nil {
+		metrics.AddGaugeDataPoint(metric.Name(), *value)
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
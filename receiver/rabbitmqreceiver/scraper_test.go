@@ -0,0 +1,281 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmqreceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+)
+
+// newManagementAPIMock serves the recorded responses of the management API
+// to the guest user, except for the paths that fail with the given status
+// code.
+func newManagementAPIMock(t *testing.T, failures map[string]int) *httptest.Server {
+	responses := map[string][]byte{}
+	for path, file := range map[string]string{
+		"/api/overview": "overview.json",
+		"/api/queues":   "queues.json",
+		"/api/nodes":    "nodes.json",
+	} {
+		response, err := ioutil.ReadFile(filepath.Join("testdata", file))
+		require.NoError(t, err)
+		responses[path] = response
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if username, password, ok := req.BasicAuth(); !ok || username != "guest" || password != "guest" {
+			rw.WriteHeader(401)
+			return
+		}
+		if statusCode, ok := failures[req.URL.Path]; ok {
+			rw.WriteHeader(statusCode)
+			return
+		}
+		if response, ok := responses[req.URL.Path]; ok {
+			rw.WriteHeader(200)
+			_, _ = rw.Write(response)
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+}
+
+func newTestScraper(endpoint string) *rabbitmqScraper {
+	return newRabbitMQScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: endpoint,
+		},
+		Username: "guest",
+		Password: "guest",
+	})
+}
+
+func TestScraper(t *testing.T) {
+	rabbitmqMock := newManagementAPIMock(t, nil)
+	defer rabbitmqMock.Close()
+
+	for _, endpoint := range []string{rabbitmqMock.URL, rabbitmqMock.URL + "/"} {
+		t.Run(endpoint, func(t *testing.T) {
+			rms, err := newTestScraper(endpoint).scrape(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, 1, rms.Len())
+			rm := rms.At(0)
+
+			ilms := rm.InstrumentationLibraryMetrics()
+			require.Equal(t, 1, ilms.Len())
+
+			ilm := ilms.At(0)
+			ms := ilm.Metrics()
+
+			require.Equal(t, 29, ms.Len())
+
+			// The data points are keyed by the metric name followed by their
+			// sorted labels.
+			values := make(map[string]float64, 46)
+			dataPointKey := func(name string, labelsMap pdata.StringMap) string {
+				var labels []string
+				labelsMap.Range(func(k string, v string) bool {
+					labels = append(labels, k+"="+v)
+					return true
+				})
+				sort.Strings(labels)
+				return strings.Join(append([]string{name}, labels...), " ")
+			}
+
+			for i := 0; i < ms.Len(); i++ {
+				m := ms.At(i)
+
+				if m.DataType() == pdata.MetricDataTypeDoubleGauge {
+					dps := m.DoubleGauge().DataPoints()
+					for j := 0; j < dps.Len(); j++ {
+						values[dataPointKey(m.Name(), dps.At(j).LabelsMap())] = dps.At(j).Value()
+					}
+					continue
+				}
+
+				var dps pdata.IntDataPointSlice
+
+				switch m.DataType() {
+				case pdata.MetricDataTypeIntGauge:
+					dps = m.IntGauge().DataPoints()
+				case pdata.MetricDataTypeIntSum:
+					dps = m.IntSum().DataPoints()
+				}
+
+				for j := 0; j < dps.Len(); j++ {
+					values[dataPointKey(m.Name(), dps.At(j).LabelsMap())] = float64(dps.At(j).Value())
+				}
+			}
+			require.Len(t, values, 46)
+
+			for key, value := range map[string]float64{
+				"rabbitmq.connections":                                                3,
+				"rabbitmq.channels":                                                   6,
+				"rabbitmq.queues":                                                     3,
+				"rabbitmq.consumers":                                                  4,
+				"rabbitmq.messages message_state=ready":                               8,
+				"rabbitmq.messages message_state=unacknowledged":                      5,
+				"rabbitmq.messages.published":                                         1468,
+				"rabbitmq.messages.delivered":                                         1460,
+				"rabbitmq.messages.acknowledged":                                      1455,
+				"rabbitmq.messages.publish_rate":                                      2.4,
+				"rabbitmq.messages.deliver_rate":                                      2.2,
+				"rabbitmq.messages.ack_rate":                                          2.0,
+				"rabbitmq.queue.messages message_state=ready queue=orders vhost=shop": 8,
+				"rabbitmq.queue.messages message_state=unacknowledged queue=orders vhost=shop": 5,
+				"rabbitmq.queue.consumers queue=orders vhost=shop":                             3,
+				"rabbitmq.queue.memory queue=orders vhost=shop":                                55528,
+				"rabbitmq.queue.messages.published queue=orders vhost=shop":                    1456,
+				"rabbitmq.queue.messages.delivered queue=orders vhost=shop":                    1448,
+				"rabbitmq.queue.messages.acknowledged queue=orders vhost=shop":                 1443,
+				"rabbitmq.queue.messages.publish_rate queue=orders vhost=shop":                 2.4,
+				"rabbitmq.queue.messages.deliver_rate queue=orders vhost=shop":                 2.2,
+				"rabbitmq.queue.messages.ack_rate queue=orders vhost=shop":                     2.0,
+				"rabbitmq.queue.memory queue=refunds vhost=shop":                               10616,
+				"rabbitmq.node.running node=rabbit@rabbitmq-0":                                 1,
+				"rabbitmq.node.memory.used node=rabbit@rabbitmq-0":                             132255744,
+				"rabbitmq.node.memory.limit node=rabbit@rabbitmq-0":                            3301338316,
+				"rabbitmq.node.memory.alarm node=rabbit@rabbitmq-0":                            0,
+				"rabbitmq.node.disk.free node=rabbit@rabbitmq-0":                               48263770112,
+				"rabbitmq.node.disk.free_limit node=rabbit@rabbitmq-0":                         50000000,
+				"rabbitmq.node.disk.alarm node=rabbit@rabbitmq-0":                              0,
+				"rabbitmq.node.fd.used node=rabbit@rabbitmq-0":                                 38,
+				"rabbitmq.node.fd.total node=rabbit@rabbitmq-0":                                1048576,
+				"rabbitmq.node.running node=rabbit@rabbitmq-1":                                 0,
+			} {
+				if assert.Contains(t, values, key) {
+					assert.Equal(t, value, values[key], key)
+				}
+			}
+
+			// A queue no message went through has no message counters, and a
+			// node that isn't running only reports that it isn't.
+			assert.NotContains(t, values, "rabbitmq.queue.messages.published queue=refunds vhost=shop")
+			assert.NotContains(t, values, "rabbitmq.node.memory.used node=rabbit@rabbitmq-1")
+		})
+	}
+}
+
+func TestScraperQueueFilters(t *testing.T) {
+	rabbitmqMock := newManagementAPIMock(t, nil)
+	defer rabbitmqMock.Close()
+
+	for _, tt := range []struct {
+		name          string
+		includeQueues string
+		excludeQueues string
+		queues        []string
+	}{
+		{
+			name:   "all",
+			queues: []string{"amq.gen-JtXv4Pm0_pUSoRSxNtBYgg", "orders", "refunds"},
+		},
+		{
+			name:          "include",
+			includeQueues: "^(orders|refunds)$",
+			queues:        []string{"orders", "refunds"},
+		},
+		{
+			name:          "exclude",
+			excludeQueues: `^amq\.`,
+			queues:        []string{"orders", "refunds"},
+		},
+		{
+			name:          "include and exclude",
+			includeQueues: "^(orders|refunds)$",
+			excludeQueues: "^refunds$",
+			queues:        []string{"orders"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := newTestScraper(rabbitmqMock.URL)
+			sc.cfg.IncludeQueues = tt.includeQueues
+			sc.cfg.ExcludeQueues = tt.excludeQueues
+			rms, err := sc.scrape(context.Background())
+			require.NoError(t, err)
+
+			var queues []string
+			ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+			for i := 0; i < ms.Len(); i++ {
+				if ms.At(i).Name() != "rabbitmq.queue.memory" {
+					continue
+				}
+				dps := ms.At(i).IntGauge().DataPoints()
+				for j := 0; j < dps.Len(); j++ {
+					queue, _ := dps.At(j).LabelsMap().Get("queue")
+					queues = append(queues, queue)
+				}
+			}
+			sort.Strings(queues)
+			require.Equal(t, tt.queues, queues)
+		})
+	}
+}
+
+func TestScraperError(t *testing.T) {
+	t.Run("unauthorized", func(t *testing.T) {
+		rabbitmqMock := newManagementAPIMock(t, nil)
+		defer rabbitmqMock.Close()
+
+		sc := newTestScraper(rabbitmqMock.URL)
+		sc.cfg.Password = "wrong"
+		_, err := sc.scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response from /api/overview, got 401")
+		require.False(t, scrapererror.IsPartialScrapeError(err))
+	})
+
+	t.Run("overview", func(t *testing.T) {
+		rabbitmqMock := newManagementAPIMock(t, map[string]int{"/api/overview": 500})
+		defer rabbitmqMock.Close()
+
+		_, err := newTestScraper(rabbitmqMock.URL).scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response from /api/overview, got 500")
+		require.False(t, scrapererror.IsPartialScrapeError(err))
+	})
+
+	t.Run("nodes", func(t *testing.T) {
+		rabbitmqMock := newManagementAPIMock(t, map[string]int{"/api/nodes": 500})
+		defer rabbitmqMock.Close()
+
+		rms, err := newTestScraper(rabbitmqMock.URL).scrape(context.Background())
+		require.EqualError(t, err, "expected 200 response from /api/nodes, got 500")
+		require.True(t, scrapererror.IsPartialScrapeError(err))
+
+		// The metrics of the overview and of the queues are still reported.
+		require.Equal(t, 1, rms.Len())
+		ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+		var names []string
+		for i := 0; i < ms.Len(); i++ {
+			names = append(names, ms.At(i).Name())
+		}
+		require.Len(t, names, 20)
+		require.Contains(t, names, "rabbitmq.queue.memory")
+		require.NotContains(t, names, "rabbitmq.node.running")
+	})
+}
@@ -84,6 +84,16 @@ type Config struct {
 	// ResourceAttributes is a map of default resource attributes to add to each resource
 	// object received by this receiver from dynamically created receivers.
 	ResourceAttributes resourceAttributes `mapstructure:"resource_attributes"`
+	// Discovery configures creating receivers from the annotations of discovered endpoints.
+	Discovery DiscoveryConfig `mapstructure:"discovery"`
+}
+
+// DiscoveryConfig defines how receivers are created from endpoint annotations.
+type DiscoveryConfig struct {
+	// Enabled turns on creating receivers from endpoint annotations such as
+	// io.opentelemetry.discovery.metrics/receiver. Annotations allow anyone able
+	// to deploy a pod to start arbitrary receivers so this is disabled by default.
+	Enabled bool `mapstructure:"enabled"`
 }
 
 func (cfg *Config) Unmarshal(componentParser *config.Parser) error {
@@ -16,6 +16,7 @@ package receivercreator
 
 import (
 	"context"
+	"errors"
 	"path"
 	"testing"
 
@@ -69,7 +70,7 @@ func exampleCreatorFactory(t *testing.T) (*mockHostFactories, *config.Config) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 3)
 
 	return &mockHostFactories{Host: componenttest.NewNopHost(), factories: factories}, cfg
 }
@@ -93,6 +94,10 @@ func TestLoadConfig(t *testing.T) {
 		endpointConfigKey: "localhost:12345",
 	}, r1.receiverTemplates["nop/1"].config)
 	assert.Equal(t, []config.Type{"mock_observer"}, r1.WatchObservers)
+	assert.False(t, r1.Discovery.Enabled)
+
+	r2 := cfg.Receivers[config.NewIDWithName("receiver_creator", "discovery")].(*Config)
+	assert.True(t, r2.Discovery.Enabled)
 }
 
 type nopWithEndpointConfig struct {
@@ -100,6 +105,13 @@ type nopWithEndpointConfig struct {
 	Endpoint                string `mapstructure:"endpoint"`
 }
 
+func (cfg *nopWithEndpointConfig) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	return nil
+}
+
 type nopWithEndpointFactory struct {
 	component.ReceiverFactory
 }
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cast"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	// discoveryPrefix is the prefix of the annotations read when discovery is enabled.
+	discoveryPrefix = "io.opentelemetry.discovery"
+	// discoveryReceiverKey is the annotation suffix naming the receiver to start (ie <receiver type>/<id>).
+	discoveryReceiverKey = "receiver"
+	// discoveryConfigKey is the annotation suffix holding the YAML config of the receiver.
+	discoveryConfigKey = "config"
+)
+
+// dataType is a telemetry data type a discovered receiver is started for.
+type dataType string
+
+const (
+	logsDataType    dataType = "logs"
+	metricsDataType dataType = "metrics"
+	tracesDataType  dataType = "traces"
+)
+
+var dataTypes = []dataType{logsDataType, metricsDataType, tracesDataType}
+
+// discoveredReceiver is a receiver requested through the annotations of an endpoint.
+type discoveredReceiver struct {
+	receiverConfig
+	// dataType is the only data type the receiver's telemetry is forwarded for.
+	dataType dataType
+}
+
+// endpointAnnotations returns the annotations to read discovery settings from and
+// the prefix of the keys that apply to the given endpoint. Pod endpoints use
+// io.opentelemetry.discovery.<data type>/<key> while port endpoints use
+// io.opentelemetry.discovery.<data type>.<port>/<key>.
+func endpointAnnotations(e observer.Endpoint) (map[string]string, func(dataType) string) {
+	switch details := e.Details.(type) {
+	case *observer.Pod:
+		return details.Annotations, func(dt dataType) string {
+			return fmt.Sprintf("%s.%s/", discoveryPrefix, dt)
+		}
+	case *observer.Port:
+		return details.Pod.Annotations, func(dt dataType) string {
+			return fmt.Sprintf("%s.%s.%s/", discoveryPrefix, dt, strconv.Itoa(int(details.Port)))
+		}
+	}
+	return nil, nil
+}
+
+// discoverReceivers returns the receivers requested through the annotations of the given
+// endpoint. When a receiver template with the same name is configured its config is used
+// as the base that the annotation config is merged into.
+func discoverReceivers(e observer.Endpoint, templates map[string]receiverTemplate) ([]discoveredReceiver, error) {
+	annotations, prefix := endpointAnnotations(e)
+	if len(annotations) == 0 {
+		return nil, nil
+	}
+
+	var discovered []discoveredReceiver
+	for _, dt := range dataTypes {
+		name, ok := annotations[prefix(dt)+discoveryReceiverKey]
+		if !ok || name == "" {
+			continue
+		}
+
+		template, err := newReceiverTemplate(name, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s receiver %q: %v", dt, name, err)
+		}
+
+		cfg := userConfigMap{}
+		if existing, ok := templates[name]; ok {
+			cfg = mergeConfigMaps(cfg, existing.config)
+		}
+
+		if raw, ok := annotations[prefix(dt)+discoveryConfigKey]; ok {
+			annotationCfg := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(raw), &annotationCfg); err != nil {
+				return nil, fmt.Errorf("unable to parse %s config of receiver %q: %v", dt, name, err)
+			}
+			cfg = mergeConfigMaps(cfg, toStringMaps(annotationCfg).(map[string]interface{}))
+		}
+
+		template.config = cfg
+		discovered = append(discovered, discoveredReceiver{
+			receiverConfig: template.receiverConfig,
+			dataType:       dt,
+		})
+	}
+
+	// Keep a stable start order.
+	sort.Slice(discovered, func(i, j int) bool {
+		return discovered[i].id.String() < discovered[j].id.String()
+	})
+	return discovered, nil
+}
+
+// mergeConfigMaps returns dst with the values of src merged in. Nested maps are
+// merged recursively, other values from src replace the ones in dst.
+func mergeConfigMaps(dst, src map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := out[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			out[k] = mergeConfigMaps(dstMap, srcMap)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// toStringMaps converts the map[interface{}]interface{} values produced by the YAML
+// decoder into map[string]interface{} so they can be merged and expanded.
+func toStringMaps(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[cast.ToString(k)] = toStringMaps(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[k] = toStringMaps(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = toStringMaps(item)
+		}
+		return out
+	}
+	return v
+}
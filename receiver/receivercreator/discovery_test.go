@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestDiscoverReceivers(t *testing.T) {
+	templates := map[string]receiverTemplate{
+		"redis": {
+			receiverConfig: receiverConfig{id: config.MustIDFromString("redis"), config: userConfigMap{"collection_interval": "10s", "password": "secret"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		endpoint    observer.Endpoint
+		annotations map[string]string
+		want        []discoveredReceiver
+		wantErr     string
+	}{
+		{
+			name:        "no annotations",
+			endpoint:    podEndpoint,
+			annotations: nil,
+			want:        nil,
+		},
+		{
+			name:     "pod metrics and logs",
+			endpoint: podEndpoint,
+			annotations: map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver": "redis",
+				"io.opentelemetry.discovery.metrics/config":   "collection_interval: 30s",
+				"io.opentelemetry.discovery.logs/receiver":    "filelog/pod",
+				"io.opentelemetry.discovery.logs/config":      "include: [/var/log/pods/*.log]",
+			},
+			want: []discoveredReceiver{
+				{
+					receiverConfig: receiverConfig{
+						id:     config.MustIDFromString("filelog/pod"),
+						config: userConfigMap{"include": []interface{}{"/var/log/pods/*.log"}},
+					},
+					dataType: logsDataType,
+				},
+				{
+					receiverConfig: receiverConfig{
+						id:     config.MustIDFromString("redis"),
+						config: userConfigMap{"collection_interval": "30s", "password": "secret"},
+					},
+					dataType: metricsDataType,
+				},
+			},
+		},
+		{
+			name:     "port specific annotations",
+			endpoint: portEndpoint,
+			annotations: map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver":     "redis",
+				"io.opentelemetry.discovery.traces.1234/receiver": "jaeger",
+			},
+			want: []discoveredReceiver{
+				{
+					receiverConfig: receiverConfig{
+						id:     config.MustIDFromString("jaeger"),
+						config: userConfigMap{},
+					},
+					dataType: tracesDataType,
+				},
+			},
+		},
+		{
+			name:     "invalid config",
+			endpoint: podEndpoint,
+			annotations: map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver": "redis",
+				"io.opentelemetry.discovery.metrics/config":   "[not a map",
+			},
+			wantErr: `unable to parse metrics config of receiver "redis"`,
+		},
+		{
+			name:     "invalid receiver name",
+			endpoint: podEndpoint,
+			annotations: map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver": "/redis",
+			},
+			wantErr: `invalid metrics receiver "/redis"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.endpoint
+			switch details := e.Details.(type) {
+			case *observer.Pod:
+				p := *details
+				p.Annotations = tt.annotations
+				e.Details = &p
+			case *observer.Port:
+				p := *details
+				p.Pod.Annotations = tt.annotations
+				e.Details = &p
+			}
+
+			got, err := discoverReceivers(e, templates)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMergeConfigMaps(t *testing.T) {
+	dst := map[string]interface{}{
+		"a": "1",
+		"nested": map[string]interface{}{
+			"b": "2",
+			"c": "3",
+		},
+	}
+	src := map[string]interface{}{
+		"nested": map[string]interface{}{
+			"c": "4",
+		},
+		"d": "5",
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"a": "1",
+		"nested": map[string]interface{}{
+			"b": "2",
+			"c": "4",
+		},
+		"d": "5",
+	}, mergeConfigMaps(dst, src))
+	// dst is left untouched.
+	assert.Equal(t, "3", dst["nested"].(map[string]interface{})["c"])
+}
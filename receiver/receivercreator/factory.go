@@ -16,8 +16,10 @@ package receivercreator
 
 import (
 	"context"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -37,7 +39,9 @@ func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithLogs(createLogsReceiver),
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithTraces(createTracesReceiver))
 }
 
 func createDefaultConfig() config.Receiver {
@@ -59,11 +63,65 @@ func createDefaultConfig() config.Receiver {
 	}
 }
 
+func createLogsReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r := getOrCreateReceiver(params, cfg.(*Config))
+	r.nextConsumers.logs = consumer
+	return r, nil
+}
+
 func createMetricsReceiver(
 	ctx context.Context,
 	params component.ReceiverCreateParams,
 	cfg config.Receiver,
 	consumer consumer.Metrics,
 ) (component.MetricsReceiver, error) {
-	return newReceiverCreator(params, cfg.(*Config), consumer)
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r := getOrCreateReceiver(params, cfg.(*Config))
+	r.nextConsumers.metrics = consumer
+	return r, nil
 }
+
+func createTracesReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg config.Receiver,
+	consumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r := getOrCreateReceiver(params, cfg.(*Config))
+	r.nextConsumers.traces = consumer
+	return r, nil
+}
+
+// getOrCreateReceiver returns the receiver_creator for the given config, creating it
+// on first use. A single receiver_creator watches the observers for all the pipelines
+// it is part of so that each subreceiver is only started once per endpoint.
+func getOrCreateReceiver(params component.ReceiverCreateParams, cfg *Config) *receiverCreator {
+	receiversMu.Lock()
+	defer receiversMu.Unlock()
+
+	r, ok := receivers[cfg]
+	if !ok {
+		r = newReceiverCreator(params, cfg)
+		receivers[cfg] = r
+	}
+	return r
+}
+
+// receivers holds the receiver_creator instance created for each config.
+var (
+	receiversMu sync.Mutex
+	receivers   = map[*Config]*receiverCreator{}
+)
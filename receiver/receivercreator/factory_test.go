@@ -38,8 +38,14 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 
-	mReceiver, err := factory.CreateTracesReceiver(context.Background(), params, cfg, nil)
-	assert.Error(t, err)
-	assert.ErrorIs(t, err, componenterror.ErrDataTypeIsNotSupported)
-	assert.Nil(t, mReceiver)
+	lReceiver, err := factory.CreateLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err, "receiver creation failed")
+	assert.Same(t, tReceiver, lReceiver, "receiver_creator is not shared between pipelines")
+
+	trReceiver, err := factory.CreateTracesReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err, "receiver creation failed")
+	assert.Same(t, tReceiver, trReceiver, "receiver_creator is not shared between pipelines")
+
+	_, err = factory.CreateTracesReceiver(context.Background(), params, cfg, nil)
+	assert.ErrorIs(t, err, componenterror.ErrNilNextConsumer)
 }
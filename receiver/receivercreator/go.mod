@@ -19,6 +19,7 @@ require (
 	go.opentelemetry.io/collector v0.26.1-0.20210513162346-453d1d0dd603
 	go.uber.org/zap v1.16.0
 	gopkg.in/ini.v1 v1.57.0 // indirect
+	gopkg.in/yaml.v2 v2.4.0
 )
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer => ../../extension/observer
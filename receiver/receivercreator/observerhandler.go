@@ -18,7 +18,6 @@ import (
 	"fmt"
 	"sync"
 
-	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.uber.org/zap"
 
@@ -36,8 +35,8 @@ type observerHandler struct {
 	logger *zap.Logger
 	// receiversByEndpointID is a map of endpoint IDs to a receiver instance.
 	receiversByEndpointID receiverMap
-	// nextConsumer is the receiver_creator's own consumer
-	nextConsumer consumer.Metrics
+	// nextConsumers are the receiver_creator's own consumers
+	nextConsumers consumers
 	// runner starts and stops receiver instances.
 	runner runner
 }
@@ -76,7 +75,24 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 			continue
 		}
 
-		for _, template := range obs.config.receiverTemplates {
+		// Templates replaced by a receiver requested through annotations are not
+		// started again from their rule.
+		discoveredNames := map[string]bool{}
+		if obs.config.Discovery.Enabled {
+			discovered, err := discoverReceivers(e, obs.config.receiverTemplates)
+			if err != nil {
+				obs.logger.Error("unable to discover receivers from annotations", zap.String("endpoint_id", string(e.ID)), zap.Error(err))
+			}
+			for _, d := range discovered {
+				discoveredNames[d.id.String()] = true
+				obs.startReceiver(d.receiverConfig, e, env, obs.consumersFor(d.dataType))
+			}
+		}
+
+		for name, template := range obs.config.receiverTemplates {
+			if discoveredNames[name] {
+				continue
+			}
 			if matches, err := template.rule.eval(env); err != nil {
 				obs.logger.Error("failed matching rule", zap.String("rule", template.Rule), zap.Error(err))
 				continue
@@ -84,62 +100,80 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 				continue
 			}
 
-			obs.logger.Info("starting receiver",
-				zap.String("name", template.id.String()),
-				zap.String("endpoint", e.Target),
-				zap.String("endpoint_id", string(e.ID)))
-
-			resolvedConfig, err := expandMap(template.config, env)
-			if err != nil {
-				obs.logger.Error("unable to resolve template config", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
-
-			discoveredConfig := userConfigMap{}
+			obs.startReceiver(template.receiverConfig, e, env, obs.nextConsumers)
+		}
+	}
+}
 
-			// If user didn't set endpoint set to default value.
-			if _, ok := resolvedConfig[endpointConfigKey]; !ok {
-				discoveredConfig[endpointConfigKey] = e.Target
-			}
+// consumersFor returns the next consumers restricted to the given data type.
+func (obs *observerHandler) consumersFor(dt dataType) consumers {
+	switch dt {
+	case logsDataType:
+		return consumers{logs: obs.nextConsumers.logs}
+	case metricsDataType:
+		return consumers{metrics: obs.nextConsumers.metrics}
+	case tracesDataType:
+		return consumers{traces: obs.nextConsumers.traces}
+	}
+	return consumers{}
+}
 
-			resolvedDiscoveredConfig, err := expandMap(discoveredConfig, env)
+// startReceiver starts a receiver instance for the given endpoint and keeps track of it.
+func (obs *observerHandler) startReceiver(receiver receiverConfig, e observer.Endpoint, env observer.EndpointEnv, nextConsumers consumers) {
+	obs.logger.Info("starting receiver",
+		zap.String("name", receiver.id.String()),
+		zap.String("endpoint", e.Target),
+		zap.String("endpoint_id", string(e.ID)))
+
+	resolvedConfig, err := expandMap(receiver.config, env)
+	if err != nil {
+		obs.logger.Error("unable to resolve template config", zap.String("receiver", receiver.id.String()), zap.Error(err))
+		return
+	}
 
-			if err != nil {
-				obs.logger.Error("unable to resolve discovered config", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
+	discoveredConfig := userConfigMap{}
 
-			// Adds default and/or configured resource attributes (e.g. k8s.pod.uid) to resources
-			// as telemetry is emitted.
-			resourceEnhancer, err := newResourceEnhancer(
-				obs.config.ResourceAttributes,
-				env,
-				e,
-				obs.nextConsumer,
-			)
+	// If user didn't set endpoint set to default value.
+	if _, ok := resolvedConfig[endpointConfigKey]; !ok {
+		discoveredConfig[endpointConfigKey] = e.Target
+	}
 
-			if err != nil {
-				obs.logger.Error("failed creating resource enhancer", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
+	resolvedDiscoveredConfig, err := expandMap(discoveredConfig, env)
 
-			rcvr, err := obs.runner.start(
-				receiverConfig{
-					id:     template.id,
-					config: resolvedConfig,
-				},
-				resolvedDiscoveredConfig,
-				resourceEnhancer,
-			)
+	if err != nil {
+		obs.logger.Error("unable to resolve discovered config", zap.String("receiver", receiver.id.String()), zap.Error(err))
+		return
+	}
 
-			if err != nil {
-				obs.logger.Error("failed to start receiver", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
+	// Adds default and/or configured resource attributes (e.g. k8s.pod.uid) to resources
+	// as telemetry is emitted.
+	resourceEnhancer, err := newResourceEnhancer(
+		obs.config.ResourceAttributes,
+		env,
+		e,
+		nextConsumers,
+	)
+
+	if err != nil {
+		obs.logger.Error("failed creating resource enhancer", zap.String("receiver", receiver.id.String()), zap.Error(err))
+		return
+	}
 
-			obs.receiversByEndpointID.Put(e.ID, rcvr)
-		}
+	rcvr, err := obs.runner.start(
+		receiverConfig{
+			id:     receiver.id,
+			config: resolvedConfig,
+		},
+		resolvedDiscoveredConfig,
+		resourceEnhancer,
+	)
+
+	if err != nil {
+		obs.logger.Error("failed to start receiver", zap.String("receiver", receiver.id.String()), zap.Error(err))
+		return
 	}
+
+	obs.receiversByEndpointID.Put(e.ID, rcvr)
 }
 
 // OnRemove responds to endpoint removal notifications.
@@ -21,7 +21,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
-	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
@@ -34,7 +34,7 @@ type mockRunner struct {
 func (run *mockRunner) start(
 	receiver receiverConfig,
 	discoveredConfig userConfigMap,
-	nextConsumer consumer.Metrics,
+	nextConsumer *resourceEnhancer,
 ) (component.Receiver, error) {
 	args := run.Called(receiver, discoveredConfig, nextConsumer)
 	return args.Get(0).(component.Receiver), args.Error(1)
@@ -161,3 +161,51 @@ func TestDynamicConfig(t *testing.T) {
 
 	runner.AssertExpectations(t)
 }
+
+func TestOnAddDiscovery(t *testing.T) {
+	runner := &mockRunner{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Discovery.Enabled = true
+	cfg.receiverTemplates = map[string]receiverTemplate{
+		"name/1": {
+			receiverConfig: receiverConfig{id: config.MustIDFromString("name/1"), config: userConfigMap{"foo": "bar", "nested": map[string]interface{}{"a": "1"}}},
+			Rule:           `type == "pod"`,
+			rule:           newRuleOrPanic(`type == "pod"`),
+		},
+	}
+	handler := &observerHandler{
+		config:                cfg,
+		logger:                zap.NewNop(),
+		receiversByEndpointID: receiverMap{},
+		nextConsumers:         consumers{logs: consumertest.NewNop(), metrics: consumertest.NewNop()},
+		runner:                runner,
+	}
+
+	annotatedPod := pod
+	annotatedPod.Annotations = map[string]string{
+		"io.opentelemetry.discovery.metrics/receiver": "name/1",
+		"io.opentelemetry.discovery.metrics/config":   "endpoint: '`endpoint`:6379'\nnested:\n  b: 2",
+	}
+
+	runner.On(
+		"start",
+		receiverConfig{
+			id: config.MustIDFromString("name/1"),
+			config: userConfigMap{
+				"foo":             "bar",
+				endpointConfigKey: "localhost:6379",
+				"nested":          map[string]interface{}{"a": "1", "b": 2},
+			},
+		},
+		userConfigMap{},
+		mock.MatchedBy(func(re *resourceEnhancer) bool {
+			return re.metrics != nil && re.logs == nil && re.traces == nil
+		}),
+	).Return(&nopWithEndpointReceiver{}, nil)
+
+	handler.OnAdd([]observer.Endpoint{{ID: "pod-1", Target: "localhost", Details: &annotatedPod}})
+
+	runner.AssertExpectations(t)
+	// The template is replaced by the annotation receiver so only one is started.
+	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
+}
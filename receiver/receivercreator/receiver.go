@@ -17,38 +17,42 @@ package receivercreator
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
-	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
 )
 
-var _ component.MetricsReceiver = (*receiverCreator)(nil)
+var (
+	_ component.LogsReceiver    = (*receiverCreator)(nil)
+	_ component.MetricsReceiver = (*receiverCreator)(nil)
+	_ component.TracesReceiver  = (*receiverCreator)(nil)
+)
 
-// receiverCreator implements consumer.Metrics.
+// receiverCreator starts and stops subreceivers for discovered endpoints. The same
+// instance is shared by all the pipelines a receiver_creator config is used in.
 type receiverCreator struct {
 	params          component.ReceiverCreateParams
 	cfg             *Config
-	nextConsumer    consumer.Metrics
+	nextConsumers   consumers
 	observerHandler observerHandler
+
+	startOnce    sync.Once
+	startErr     error
+	shutdownOnce sync.Once
+	shutdownErr  error
 }
 
 // newReceiverCreator creates the receiver_creator with the given parameters.
-func newReceiverCreator(params component.ReceiverCreateParams, cfg *Config, nextConsumer consumer.Metrics) (component.MetricsReceiver, error) {
-	if nextConsumer == nil {
-		return nil, componenterror.ErrNilNextConsumer
-	}
-
-	r := &receiverCreator{
-		params:       params,
-		cfg:          cfg,
-		nextConsumer: nextConsumer,
+// Next consumers are registered by the factory for each pipeline type.
+func newReceiverCreator(params component.ReceiverCreateParams, cfg *Config) *receiverCreator {
+	return &receiverCreator{
+		params: params,
+		cfg:    cfg,
 	}
-	return r, nil
 }
 
 // loggingHost provides a safer version of host that logs errors instead of exiting the process.
@@ -64,13 +68,21 @@ func (h *loggingHost) ReportFatalError(err error) {
 
 var _ component.Host = (*loggingHost)(nil)
 
-// Start receiver_creator.
+// Start receiver_creator. It is called once per pipeline type but only starts watching
+// the observers the first time.
 func (rc *receiverCreator) Start(_ context.Context, host component.Host) error {
+	rc.startOnce.Do(func() {
+		rc.startErr = rc.start(host)
+	})
+	return rc.startErr
+}
+
+func (rc *receiverCreator) start(host component.Host) error {
 	rc.observerHandler = observerHandler{
 		config:                rc.cfg,
 		logger:                rc.params.Logger,
 		receiversByEndpointID: receiverMap{},
-		nextConsumer:          rc.nextConsumer,
+		nextConsumers:         rc.nextConsumers,
 		runner: &receiverRunner{
 			params:      rc.params,
 			idNamespace: rc.cfg.ID(),
@@ -115,5 +127,8 @@ func (rc *receiverCreator) Start(_ context.Context, host component.Host) error {
 
 // Shutdown stops the receiver_creator and all its receivers started at runtime.
 func (rc *receiverCreator) Shutdown(context.Context) error {
-	return rc.observerHandler.shutdown()
+	rc.shutdownOnce.Do(func() {
+		rc.shutdownErr = rc.observerHandler.shutdown()
+	})
+	return rc.shutdownErr
 }
@@ -93,7 +93,7 @@ func TestMockedEndToEnd(t *testing.T) {
 
 	// Test that we can send metrics.
 	for _, receiver := range dyn.observerHandler.receiversByEndpointID.Values() {
-		example := receiver.(*nopWithEndpointReceiver)
+		example := receiver.(*wrappedReceiver).metrics.(*nopWithEndpointReceiver)
 		md := internaldata.OCToMetrics(
 			&commonpb.Node{
 				ServiceInfo: &commonpb.ServiceInfo{Name: "dynamictest"},
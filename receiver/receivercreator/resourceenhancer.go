@@ -24,21 +24,33 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
 )
 
-var _ consumer.Metrics = (*resourceEnhancer)(nil)
+var (
+	_ consumer.Logs    = (*resourceEnhancer)(nil)
+	_ consumer.Metrics = (*resourceEnhancer)(nil)
+	_ consumer.Traces  = (*resourceEnhancer)(nil)
+)
+
+// consumers are the next consumers of the receiver_creator for each data type.
+// A nil consumer means receiver_creator is not part of a pipeline of that type.
+type consumers struct {
+	logs    consumer.Logs
+	metrics consumer.Metrics
+	traces  consumer.Traces
+}
 
 // resourceEnhancer adds additional resource attribute entries
 // from the given endpoint environment. The added attributes vary based on the type
 // of the endpoint.
 type resourceEnhancer struct {
-	nextConsumer consumer.Metrics
-	attrs        map[string]string
+	consumers
+	attrs map[string]string
 }
 
 func newResourceEnhancer(
 	resources resourceAttributes,
 	env observer.EndpointEnv,
 	endpoint observer.Endpoint,
-	nextConsumer consumer.Metrics,
+	nextConsumers consumers,
 ) (*resourceEnhancer, error) {
 	attrs := map[string]string{}
 
@@ -57,8 +69,8 @@ func newResourceEnhancer(
 	}
 
 	return &resourceEnhancer{
-		nextConsumer: nextConsumer,
-		attrs:        attrs,
+		consumers: nextConsumers,
+		attrs:     attrs,
 	}, nil
 }
 
@@ -66,16 +78,37 @@ func (r *resourceEnhancer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
 
+func (r *resourceEnhancer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	rl := ld.ResourceLogs()
+	for i := 0; i < rl.Len(); i++ {
+		r.enhance(rl.At(i).Resource())
+	}
+
+	return r.logs.ConsumeLogs(ctx, ld)
+}
+
 func (r *resourceEnhancer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
 	rm := md.ResourceMetrics()
 	for i := 0; i < rm.Len(); i++ {
-		rms := rm.At(i)
-		attrs := rms.Resource().Attributes()
+		r.enhance(rm.At(i).Resource())
+	}
 
-		for attr, val := range r.attrs {
-			attrs.InsertString(attr, val)
-		}
+	return r.metrics.ConsumeMetrics(ctx, md)
+}
+
+func (r *resourceEnhancer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	rs := td.ResourceSpans()
+	for i := 0; i < rs.Len(); i++ {
+		r.enhance(rs.At(i).Resource())
 	}
 
-	return r.nextConsumer.ConsumeMetrics(ctx, md)
+	return r.traces.ConsumeTraces(ctx, td)
+}
+
+// enhance inserts the precomputed attributes into the given resource.
+func (r *resourceEnhancer) enhance(res pdata.Resource) {
+	attrs := res.Attributes()
+	for attr, val := range r.attrs {
+		attrs.InsertString(attr, val)
+	}
 }
@@ -20,7 +20,6 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 
@@ -42,7 +41,7 @@ func Test_newResourceEnhancer(t *testing.T) {
 		resources    resourceAttributes
 		env          observer.EndpointEnv
 		endpoint     observer.Endpoint
-		nextConsumer consumer.Metrics
+		nextConsumer consumers
 	}
 	tests := []struct {
 		name    string
@@ -56,10 +55,10 @@ func Test_newResourceEnhancer(t *testing.T) {
 				resources:    cfg.ResourceAttributes,
 				env:          podEnv,
 				endpoint:     podEndpoint,
-				nextConsumer: &consumertest.MetricsSink{},
+				nextConsumer: consumers{metrics: &consumertest.MetricsSink{}},
 			},
 			want: &resourceEnhancer{
-				nextConsumer: &consumertest.MetricsSink{},
+				consumers: consumers{metrics: &consumertest.MetricsSink{}},
 				attrs: map[string]string{
 					"k8s.pod.uid":        "uid-1",
 					"k8s.pod.name":       "pod-1",
@@ -74,10 +73,10 @@ func Test_newResourceEnhancer(t *testing.T) {
 				resources:    cfg.ResourceAttributes,
 				env:          portEnv,
 				endpoint:     portEndpoint,
-				nextConsumer: &consumertest.MetricsSink{},
+				nextConsumer: consumers{metrics: &consumertest.MetricsSink{}},
 			},
 			want: &resourceEnhancer{
-				nextConsumer: &consumertest.MetricsSink{},
+				consumers: consumers{metrics: &consumertest.MetricsSink{}},
 				attrs: map[string]string{
 					"k8s.pod.uid":        "uid-1",
 					"k8s.pod.name":       "pod-1",
@@ -98,10 +97,10 @@ func Test_newResourceEnhancer(t *testing.T) {
 				}(),
 				env:          podEnv,
 				endpoint:     podEndpoint,
-				nextConsumer: nil,
+				nextConsumer: consumers{},
 			},
 			want: &resourceEnhancer{
-				nextConsumer: nil,
+				consumers: consumers{},
 				attrs: map[string]string{
 					"k8s.pod.uid":        "uid-1",
 					"k8s.namespace.name": "default",
@@ -119,7 +118,7 @@ func Test_newResourceEnhancer(t *testing.T) {
 				}(),
 				env:          podEnv,
 				endpoint:     podEndpoint,
-				nextConsumer: nil,
+				nextConsumer: consumers{},
 			},
 			want:    nil,
 			wantErr: true,
@@ -186,8 +185,8 @@ func Test_resourceEnhancer_ConsumeMetrics(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &resourceEnhancer{
-				nextConsumer: tt.fields.nextConsumer,
-				attrs:        tt.fields.attrs,
+				consumers: consumers{metrics: tt.fields.nextConsumer},
+				attrs:     tt.fields.attrs,
 			}
 			if err := r.ConsumeMetrics(tt.args.ctx, tt.args.md); (err != nil) != tt.wantErr {
 				t.Errorf("ConsumeMetrics() error = %v, wantErr %v", err, tt.wantErr)
@@ -202,3 +201,28 @@ func Test_resourceEnhancer_ConsumeMetrics(t *testing.T) {
 		})
 	}
 }
+
+func Test_resourceEnhancer_ConsumeLogsAndTraces(t *testing.T) {
+	logsSink := &consumertest.LogsSink{}
+	tracesSink := &consumertest.TracesSink{}
+	r := &resourceEnhancer{
+		consumers: consumers{logs: logsSink, traces: tracesSink},
+		attrs:     map[string]string{"key1": "value1"},
+	}
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	require.NoError(t, r.ConsumeLogs(context.Background(), ld))
+	require.Len(t, logsSink.AllLogs(), 1)
+	val, ok := logsSink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("key1")
+	require.True(t, ok)
+	require.Equal(t, "value1", val.StringVal())
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	require.NoError(t, r.ConsumeTraces(context.Background(), td))
+	require.Len(t, tracesSink.AllTraces(), 1)
+	val, ok = tracesSink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get("key1")
+	require.True(t, ok)
+	require.Equal(t, "value1", val.StringVal())
+}
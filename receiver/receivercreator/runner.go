@@ -16,19 +16,21 @@ package receivercreator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cast"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configloader"
-	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 // runner starts and stops receiver instances.
 type runner interface {
 	// start a receiver instance from its static config and discovered config.
-	start(receiver receiverConfig, discoveredConfig userConfigMap, nextConsumer consumer.Metrics) (component.Receiver, error)
+	start(receiver receiverConfig, discoveredConfig userConfigMap, nextConsumer *resourceEnhancer) (component.Receiver, error)
 	// shutdown a receiver.
 	shutdown(rcvr component.Receiver) error
 }
@@ -43,10 +45,12 @@ type receiverRunner struct {
 var _ runner = (*receiverRunner)(nil)
 
 // start a receiver instance from its static config and discovered config.
+// A subreceiver is created for every data type supported by both the
+// subreceiver and the pipelines receiver_creator is part of.
 func (run *receiverRunner) start(
 	receiver receiverConfig,
 	discoveredConfig userConfigMap,
-	nextConsumer consumer.Metrics,
+	nextConsumer *resourceEnhancer,
 ) (component.Receiver, error) {
 	factory := run.host.GetFactory(component.KindReceiver, receiver.id.Type())
 
@@ -103,14 +107,107 @@ func (run *receiverRunner) loadRuntimeReceiverConfig(
 	// Sets dynamically created receiver to something like receiver_creator/1/redis{endpoint="localhost:6380"}.
 	// TODO: Need to make sure this is unique (just endpoint is probably not totally sufficient).
 	receiverConfig.SetIDName(fmt.Sprintf("%s/%s{endpoint=%q}", receiver.id.Name(), run.idNamespace, cast.ToString(mergedConfig.Get(endpointConfigKey))))
+
+	// Configs coming from endpoint annotations are not checked by the collector
+	// at startup so validate them before the receiver is created.
+	if v, ok := receiverConfig.(validatable); ok {
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config for receiver %v: %v", receiverConfig.ID(), err)
+		}
+	}
 	return receiverConfig, nil
 }
 
-// createRuntimeReceiver creates a receiver that is discovered at runtime.
+// validatable is implemented by receiver configs that can check their own values.
+type validatable interface {
+	Validate() error
+}
+
+// createRuntimeReceiver creates a receiver that is discovered at runtime. It creates
+// one subreceiver per data type that has a next consumer, skipping the data types
+// the factory does not support.
 func (run *receiverRunner) createRuntimeReceiver(
 	factory component.ReceiverFactory,
 	cfg config.Receiver,
-	nextConsumer consumer.Metrics,
-) (component.MetricsReceiver, error) {
-	return factory.CreateMetricsReceiver(context.Background(), run.params, cfg, nextConsumer)
+	nextConsumer *resourceEnhancer,
+) (*wrappedReceiver, error) {
+	ctx := context.Background()
+	wr := &wrappedReceiver{}
+	var err error
+
+	if nextConsumer.logs != nil {
+		if wr.logs, err = factory.CreateLogsReceiver(ctx, run.params, cfg, nextConsumer); err != nil && !errors.Is(err, componenterror.ErrDataTypeIsNotSupported) {
+			return nil, err
+		}
+	}
+	if nextConsumer.metrics != nil {
+		if wr.metrics, err = factory.CreateMetricsReceiver(ctx, run.params, cfg, nextConsumer); err != nil && !errors.Is(err, componenterror.ErrDataTypeIsNotSupported) {
+			return nil, err
+		}
+	}
+	if nextConsumer.traces != nil {
+		if wr.traces, err = factory.CreateTracesReceiver(ctx, run.params, cfg, nextConsumer); err != nil && !errors.Is(err, componenterror.ErrDataTypeIsNotSupported) {
+			return nil, err
+		}
+	}
+
+	if wr.logs == nil && wr.metrics == nil && wr.traces == nil {
+		return nil, fmt.Errorf("receiver %v does not support any of the data types of the receiver_creator pipelines", cfg.ID())
+	}
+
+	return wr, nil
+}
+
+var _ component.Receiver = (*wrappedReceiver)(nil)
+
+// wrappedReceiver groups the subreceivers created for each data type from
+// the same config so they can be started and stopped together.
+type wrappedReceiver struct {
+	logs    component.LogsReceiver
+	metrics component.MetricsReceiver
+	traces  component.TracesReceiver
+}
+
+func (w *wrappedReceiver) receivers() []component.Receiver {
+	var rcvrs []component.Receiver
+	if w.logs != nil {
+		rcvrs = append(rcvrs, w.logs)
+	}
+	if w.metrics != nil {
+		rcvrs = append(rcvrs, w.metrics)
+	}
+	if w.traces != nil {
+		rcvrs = append(rcvrs, w.traces)
+	}
+	return rcvrs
+}
+
+// Start all subreceivers. Receivers whose factory returns the same instance
+// for several data types are expected to handle multiple Start calls. If a
+// subreceiver fails to start, the ones already started are shut down.
+func (w *wrappedReceiver) Start(ctx context.Context, host component.Host) error {
+	rcvrs := w.receivers()
+	for i, rcvr := range rcvrs {
+		if err := rcvr.Start(ctx, host); err != nil {
+			errs := []error{err}
+			for _, started := range rcvrs[:i] {
+				if err := started.Shutdown(ctx); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return consumererror.Combine(errs)
+		}
+	}
+	return nil
+}
+
+// Shutdown all subreceivers.
+func (w *wrappedReceiver) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, rcvr := range w.receivers() {
+		if err := rcvr.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
 }
@@ -15,12 +15,17 @@
 package receivercreator
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.uber.org/zap"
 )
 
@@ -42,9 +47,60 @@ func Test_loadAndCreateRuntimeReceiver(t *testing.T) {
 
 	// Test that metric receiver can be created from loaded config.
 	t.Run("test create receiver from loaded config", func(t *testing.T) {
-		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig, nil)
+		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig, &resourceEnhancer{consumers: consumers{metrics: consumertest.NewNop()}})
 		require.NoError(t, err)
 		assert.NotNil(t, recvr)
-		assert.IsType(t, &nopWithEndpointReceiver{}, recvr)
+		assert.IsType(t, &nopWithEndpointReceiver{}, recvr.metrics)
+		assert.Nil(t, recvr.logs)
+		assert.Nil(t, recvr.traces)
 	})
+
+	t.Run("test create receiver for every pipeline type", func(t *testing.T) {
+		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig, &resourceEnhancer{consumers: consumers{
+			logs:    consumertest.NewNop(),
+			metrics: consumertest.NewNop(),
+			traces:  consumertest.NewNop(),
+		}})
+		require.NoError(t, err)
+		assert.NotNil(t, recvr.logs)
+		assert.NotNil(t, recvr.metrics)
+		assert.NotNil(t, recvr.traces)
+	})
+}
+
+func Test_loadRuntimeReceiverConfigValidates(t *testing.T) {
+	run := &receiverRunner{params: component.ReceiverCreateParams{Logger: zap.NewNop()}, idNamespace: config.NewIDWithName(typeStr, "1")}
+	template, err := newReceiverTemplate("nop/1", nil)
+	require.NoError(t, err)
+
+	_, err = run.loadRuntimeReceiverConfig(&nopWithEndpointFactory{}, template.receiverConfig, userConfigMap{
+		endpointConfigKey: "",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "endpoint must be set")
+}
+
+func TestWrappedReceiverStartFailure(t *testing.T) {
+	var shutdown []string
+	newReceiver := func(name string, startErr error) component.Component {
+		return componenthelper.New(
+			componenthelper.WithStart(func(context.Context, component.Host) error {
+				return startErr
+			}),
+			componenthelper.WithShutdown(func(context.Context) error {
+				shutdown = append(shutdown, name)
+				return nil
+			}),
+		)
+	}
+
+	wr := &wrappedReceiver{
+		logs:    &nopWithEndpointReceiver{Component: newReceiver("logs", nil)},
+		metrics: &nopWithEndpointReceiver{Component: newReceiver("metrics", errors.New("port in use"))},
+		traces:  &nopWithEndpointReceiver{Component: newReceiver("traces", nil)},
+	}
+	err := wr.Start(context.Background(), componenttest.NewNopHost())
+	require.EqualError(t, err, "port in use")
+	// Only the receivers started before the failing one are shut down.
+	assert.Equal(t, []string{"logs"}, shutdown)
 }
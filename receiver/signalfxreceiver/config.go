@@ -27,4 +27,8 @@ type Config struct {
 	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	splunk.AccessTokenPassthroughConfig `mapstructure:",squash"`
+
+	// MetadataExporters is the list of exporters to which dimension updates
+	// received on the /v2/dimension API are forwarded.
+	MetadataExporters []string `mapstructure:"metadata_exporters"`
 }
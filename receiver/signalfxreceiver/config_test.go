@@ -57,6 +57,7 @@ func TestLoadConfig(t *testing.T) {
 			AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
 				AccessTokenPassthrough: true,
 			},
+			MetadataExporters: []string{"nop"},
 		})
 
 	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "tls")].(*Config)
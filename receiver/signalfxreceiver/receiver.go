@@ -31,31 +31,38 @@ import (
 	"go.opencensus.io/trace"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
 )
 
 const (
 	defaultServerTimeout = 20 * time.Second
 
-	responseOK                      = "OK"
-	responseInvalidMethod           = "Only \"POST\" method is supported"
-	responseInvalidContentType      = "\"Content-Type\" must be \"application/x-protobuf\""
-	responseInvalidEncoding         = "\"Content-Encoding\" must be \"gzip\" or empty"
-	responseErrGzipReader           = "Error on gzip body"
-	responseErrReadBody             = "Failed to read message body"
-	responseErrUnmarshalBody        = "Failed to unmarshal message body"
-	responseErrNextConsumer         = "Internal Server Error"
-	responseErrLogsNotConfigured    = "Log pipeline has not been configured to handle events"
-	responseErrMetricsNotConfigured = "Metric pipeline has not been configured to handle datapoints"
+	responseOK                       = "OK"
+	responseInvalidMethod            = "Only \"POST\" method is supported"
+	responseInvalidContentType       = "\"Content-Type\" must be \"application/x-protobuf\""
+	responseInvalidPatchMethod       = "Only \"PATCH\" method is supported"
+	responseInvalidJSONContentType   = "\"Content-Type\" must be \"application/json\""
+	responseInvalidEncoding          = "\"Content-Encoding\" must be \"gzip\" or empty"
+	responseErrGzipReader            = "Error on gzip body"
+	responseErrReadBody              = "Failed to read message body"
+	responseErrUnmarshalBody         = "Failed to unmarshal message body"
+	responseErrNextConsumer          = "Internal Server Error"
+	responseErrLogsNotConfigured     = "Log pipeline has not been configured to handle events"
+	responseErrMetricsNotConfigured  = "Metric pipeline has not been configured to handle datapoints"
+	responseErrMetadataNotConfigured = "No metadata exporter has been configured to handle dimension updates"
 
 	// Centralizing some HTTP and related string constants.
 	protobufContentType       = "application/x-protobuf"
+	jsonContentType           = "application/json"
 	gzipEncoding              = "gzip"
 	httpContentTypeHeader     = "Content-Type"
 	httpContentEncodingHeader = "Content-Encoding"
@@ -64,16 +71,19 @@ const (
 var (
 	errEmptyEndpoint = errors.New("empty endpoint")
 
-	okRespBody               = initJSONResponse(responseOK)
-	invalidMethodRespBody    = initJSONResponse(responseInvalidMethod)
-	invalidContentRespBody   = initJSONResponse(responseInvalidContentType)
-	invalidEncodingRespBody  = initJSONResponse(responseInvalidEncoding)
-	errGzipReaderRespBody    = initJSONResponse(responseErrGzipReader)
-	errReadBodyRespBody      = initJSONResponse(responseErrReadBody)
-	errUnmarshalBodyRespBody = initJSONResponse(responseErrUnmarshalBody)
-	errNextConsumerRespBody  = initJSONResponse(responseErrNextConsumer)
-	errLogsNotConfigured     = initJSONResponse(responseErrLogsNotConfigured)
-	errMetricsNotConfigured  = initJSONResponse(responseErrMetricsNotConfigured)
+	okRespBody                 = initJSONResponse(responseOK)
+	invalidMethodRespBody      = initJSONResponse(responseInvalidMethod)
+	invalidContentRespBody     = initJSONResponse(responseInvalidContentType)
+	invalidPatchMethodRespBody = initJSONResponse(responseInvalidPatchMethod)
+	invalidJSONContentRespBody = initJSONResponse(responseInvalidJSONContentType)
+	invalidEncodingRespBody    = initJSONResponse(responseInvalidEncoding)
+	errGzipReaderRespBody      = initJSONResponse(responseErrGzipReader)
+	errReadBodyRespBody        = initJSONResponse(responseErrReadBody)
+	errUnmarshalBodyRespBody   = initJSONResponse(responseErrUnmarshalBody)
+	errNextConsumerRespBody    = initJSONResponse(responseErrNextConsumer)
+	errLogsNotConfigured       = initJSONResponse(responseErrLogsNotConfigured)
+	errMetricsNotConfigured    = initJSONResponse(responseErrMetricsNotConfigured)
+	errMetadataNotConfigured   = initJSONResponse(responseErrMetadataNotConfigured)
 )
 
 // sfxReceiver implements the component.MetricsReceiver for SignalFx metric protocol.
@@ -84,6 +94,8 @@ type sfxReceiver struct {
 	metricsConsumer consumer.Metrics
 	logsConsumer    consumer.Logs
 	server          *http.Server
+
+	metadataExporters []metadata.MetadataExporter
 }
 
 var _ component.MetricsReceiver = (*sfxReceiver)(nil)
@@ -126,6 +138,10 @@ func (r *sfxReceiver) Start(_ context.Context, host component.Host) error {
 		return componenterror.ErrNilNextConsumer
 	}
 
+	if err := r.setupMetadataExporters(host.GetExporters()[config.MetricsDataType]); err != nil {
+		return err
+	}
+
 	// set up the listener
 	ln, err := r.config.HTTPServerSettings.ToListener()
 	if err != nil {
@@ -135,6 +151,9 @@ func (r *sfxReceiver) Start(_ context.Context, host component.Host) error {
 	mx := mux.NewRouter()
 	mx.HandleFunc("/v2/datapoint", r.handleDatapointReq)
 	mx.HandleFunc("/v2/event", r.handleEventReq)
+	// The SignalFx Smart Agent appends "/_/sfxagent" to the dimension path.
+	mx.HandleFunc("/v2/dimension/{key}/{value}", r.handleDimensionReq)
+	mx.HandleFunc("/v2/dimension/{key}/{value}/_/sfxagent", r.handleDimensionReq)
 
 	r.server = r.config.HTTPServerSettings.ToServer(mx)
 
@@ -310,6 +329,77 @@ func (r *sfxReceiver) handleEventReq(resp http.ResponseWriter, req *http.Request
 	r.writeResponse(ctx, resp, err)
 }
 
+func (r *sfxReceiver) handleDimensionReq(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	if len(r.metadataExporters) == 0 {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errMetadataNotConfigured, nil)
+		return
+	}
+
+	if req.Method != http.MethodPatch {
+		r.failRequest(ctx, resp, http.StatusBadRequest, invalidPatchMethodRespBody, nil)
+		return
+	}
+
+	if req.Header.Get(httpContentTypeHeader) != jsonContentType {
+		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, invalidJSONContentRespBody, nil)
+		return
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errReadBodyRespBody, err)
+		return
+	}
+
+	update := &dimensionUpdate{}
+	if err = json.Unmarshal(body, update); err != nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errUnmarshalBodyRespBody, err)
+		return
+	}
+
+	vars := mux.Vars(req)
+	metadataUpdates := []*metadata.MetadataUpdate{
+		signalFxDimensionToMetadataUpdate(vars["key"], vars["value"], update),
+	}
+
+	var errs []error
+	for _, exporter := range r.metadataExporters {
+		if err = exporter.ConsumeMetadata(metadataUpdates); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	r.writeResponse(ctx, resp, consumererror.Combine(errs))
+}
+
+// setupMetadataExporters looks up the configured metadata exporters among the
+// exporters of the metrics pipelines.
+func (r *sfxReceiver) setupMetadataExporters(exporters map[config.ComponentID]component.Exporter) error {
+	var out []metadata.MetadataExporter
+	for _, name := range r.config.MetadataExporters {
+		found := false
+		for id, exp := range exporters {
+			if id.String() != name {
+				continue
+			}
+			me, ok := exp.(metadata.MetadataExporter)
+			if !ok {
+				return fmt.Errorf("%s exporter does not implement MetadataExporter", name)
+			}
+			out = append(out, me)
+			found = true
+		}
+		if !found {
+			return fmt.Errorf("%s exporter is not in collector config", name)
+		}
+	}
+
+	r.metadataExporters = out
+	return nil
+}
+
 func (r *sfxReceiver) failRequest(
 	ctx context.Context,
 	resp http.ResponseWriter,
@@ -27,9 +27,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/mux"
 	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -46,6 +48,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter"
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
 )
 
 func Test_signalfxeceiver_New(t *testing.T) {
@@ -537,6 +540,172 @@ func Test_sfxReceiver_handleEventReq(t *testing.T) {
 	}
 }
 
+func Test_sfxReceiver_handleDimensionReq(t *testing.T) {
+	config := (NewFactory()).CreateDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+
+	newDimensionReq := func(method, contentType string, body io.Reader) *http.Request {
+		req := httptest.NewRequest(method, "http://localhost/v2/dimension/host/host-1/_/sfxagent", body)
+		req.Header.Set("Content-Type", contentType)
+		return mux.SetURLVars(req, map[string]string{"key": "host", "value": "host-1"})
+	}
+	dimensionBody := `{"customProperties":{"env":"prod","owner":null},"tags":["active"],"tagsToRemove":["inactive"]}`
+
+	tests := []struct {
+		name             string
+		req              *http.Request
+		skipRegistration bool
+		exporterErr      error
+		assertResponse   func(t *testing.T, status int, body string, updates []*metadata.MetadataUpdate)
+	}{
+		{
+			name:             "no_metadata_exporter",
+			req:              newDimensionReq("PATCH", "application/json", strings.NewReader(dimensionBody)),
+			skipRegistration: true,
+			assertResponse: func(t *testing.T, status int, body string, updates []*metadata.MetadataUpdate) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, responseErrMetadataNotConfigured, body)
+			},
+		},
+		{
+			name: "incorrect_method",
+			req:  newDimensionReq("POST", "application/json", strings.NewReader(dimensionBody)),
+			assertResponse: func(t *testing.T, status int, body string, updates []*metadata.MetadataUpdate) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, responseInvalidPatchMethod, body)
+				assert.Empty(t, updates)
+			},
+		},
+		{
+			name: "incorrect_content_type",
+			req:  newDimensionReq("PATCH", "application/x-protobuf", strings.NewReader(dimensionBody)),
+			assertResponse: func(t *testing.T, status int, body string, updates []*metadata.MetadataUpdate) {
+				assert.Equal(t, http.StatusUnsupportedMediaType, status)
+				assert.Equal(t, responseInvalidJSONContentType, body)
+				assert.Empty(t, updates)
+			},
+		},
+		{
+			name: "fail_to_read_body",
+			req: func() *http.Request {
+				req := newDimensionReq("PATCH", "application/json", nil)
+				req.Body = badReqBody{}
+				return req
+			}(),
+			assertResponse: func(t *testing.T, status int, body string, updates []*metadata.MetadataUpdate) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, responseErrReadBody, body)
+			},
+		},
+		{
+			name: "bad_data_in_body",
+			req:  newDimensionReq("PATCH", "application/json", strings.NewReader("{")),
+			assertResponse: func(t *testing.T, status int, body string, updates []*metadata.MetadataUpdate) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, responseErrUnmarshalBody, body)
+				assert.Empty(t, updates)
+			},
+		},
+		{
+			name:        "exporter_error",
+			req:         newDimensionReq("PATCH", "application/json", strings.NewReader(dimensionBody)),
+			exporterErr: errors.New("buffer full"),
+			assertResponse: func(t *testing.T, status int, body string, updates []*metadata.MetadataUpdate) {
+				assert.Equal(t, http.StatusInternalServerError, status)
+				assert.Equal(t, responseErrNextConsumer, body)
+			},
+		},
+		{
+			name: "update_forwarded",
+			req:  newDimensionReq("PATCH", "application/json", strings.NewReader(dimensionBody)),
+			assertResponse: func(t *testing.T, status int, body string, updates []*metadata.MetadataUpdate) {
+				assert.Equal(t, http.StatusOK, status)
+				assert.Equal(t, responseOK, body)
+				assert.Equal(t, []*metadata.MetadataUpdate{{
+					ResourceIDKey: "host",
+					ResourceID:    "host-1",
+					MetadataDelta: metadata.MetadataDelta{
+						MetadataToAdd:    map[string]string{"active": ""},
+						MetadataToRemove: map[string]string{"inactive": ""},
+						MetadataToUpdate: map[string]string{"env": "prod", "owner": ""},
+					},
+				}}, updates)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp := &mockMetadataExporter{err: tt.exporterErr}
+			rcv := newReceiver(zap.NewNop(), *config)
+			if !tt.skipRegistration {
+				rcv.metadataExporters = []metadata.MetadataExporter{exp}
+			}
+
+			w := httptest.NewRecorder()
+			rcv.handleDimensionReq(w, tt.req)
+
+			resp := w.Result()
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			var bodyStr string
+			assert.NoError(t, json.Unmarshal(respBytes, &bodyStr))
+
+			tt.assertResponse(t, resp.StatusCode, bodyStr, exp.updates)
+		})
+	}
+}
+
+func Test_sfxReceiver_setupMetadataExporters(t *testing.T) {
+	exporters := map[config.ComponentID]component.Exporter{
+		config.MustIDFromString("nop/withoutmetadata"): nopExporter{},
+		config.MustIDFromString("nop/withmetadata"):    &mockMetadataExporter{},
+	}
+
+	tests := []struct {
+		name              string
+		metadataExporters []string
+		wantErr           bool
+		wantCount         int
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:              "with_metadata",
+			metadataExporters: []string{"nop/withmetadata"},
+			wantCount:         1,
+		},
+		{
+			name:              "without_metadata",
+			metadataExporters: []string{"nop/withoutmetadata"},
+			wantErr:           true,
+		},
+		{
+			name:              "not_configured",
+			metadataExporters: []string{"nop/missing"},
+			wantErr:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := (NewFactory()).CreateDefaultConfig().(*Config)
+			cfg.MetadataExporters = tt.metadataExporters
+			rcv := newReceiver(zap.NewNop(), *cfg)
+
+			err := rcv.setupMetadataExporters(exporters)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, rcv.metadataExporters, tt.wantCount)
+		})
+	}
+}
+
 func Test_sfxReceiver_TLS(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	cfg := createDefaultConfig().(*Config)
@@ -838,3 +1007,28 @@ func newAssertNoErrorHost(t *testing.T) component.Host {
 func (aneh *assertNoErrorHost) ReportFatalError(err error) {
 	assert.NoError(aneh, err)
 }
+
+type nopExporter struct{}
+
+var _ component.Exporter = (*nopExporter)(nil)
+
+func (nopExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (nopExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+type mockMetadataExporter struct {
+	nopExporter
+	updates []*metadata.MetadataUpdate
+	err     error
+}
+
+var _ metadata.MetadataExporter = (*mockMetadataExporter)(nil)
+
+func (m *mockMetadataExporter) ConsumeMetadata(updates []*metadata.MetadataUpdate) error {
+	m.updates = append(m.updates, updates...)
+	return m.err
+}
@@ -0,0 +1,62 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxreceiver
+
+import (
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+)
+
+// dimensionUpdate is the body of a SignalFx dimension update request
+// (PATCH /v2/dimension/{key}/{value}), as sent by the SignalFx Smart Agent
+// and by the dimension client of the SignalFx exporter.
+type dimensionUpdate struct {
+	// CustomProperties are the properties to set on the dimension, a nil
+	// value removes the property.
+	CustomProperties map[string]*string `json:"customProperties"`
+	Tags             []string           `json:"tags"`
+	TagsToRemove     []string           `json:"tagsToRemove"`
+}
+
+// signalFxDimensionToMetadataUpdate converts a SignalFx dimension update to
+// a metadata.MetadataUpdate. Tags are added or removed with an empty value,
+// while properties are always updates, a removal being an update to an
+// empty value. This is the representation the SignalFx exporter turns back
+// into the same dimension update.
+func signalFxDimensionToMetadataUpdate(key, value string, update *dimensionUpdate) *metadata.MetadataUpdate {
+	mu := &metadata.MetadataUpdate{
+		ResourceIDKey: key,
+		ResourceID:    metadata.ResourceID(value),
+		MetadataDelta: metadata.MetadataDelta{
+			MetadataToAdd:    map[string]string{},
+			MetadataToRemove: map[string]string{},
+			MetadataToUpdate: map[string]string{},
+		},
+	}
+
+	for property, val := range update.CustomProperties {
+		if val == nil {
+			mu.MetadataToUpdate[property] = ""
+		} else {
+			mu.MetadataToUpdate[property] = *val
+		}
+	}
+	for _, tag := range update.Tags {
+		mu.MetadataToAdd[tag] = ""
+	}
+	for _, tag := range update.TagsToRemove {
+		mu.MetadataToRemove[tag] = ""
+	}
+	return mu
+}
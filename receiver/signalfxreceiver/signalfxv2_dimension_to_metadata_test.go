@@ -0,0 +1,57 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+)
+
+func TestSignalFxDimensionToMetadataUpdate(t *testing.T) {
+	prod := "prod"
+	update := &dimensionUpdate{
+		CustomProperties: map[string]*string{
+			"env":   &prod,
+			"owner": nil,
+		},
+		Tags:         []string{"active"},
+		TagsToRemove: []string{"inactive"},
+	}
+
+	assert.Equal(t, &metadata.MetadataUpdate{
+		ResourceIDKey: "host",
+		ResourceID:    "host-1",
+		MetadataDelta: metadata.MetadataDelta{
+			MetadataToAdd:    map[string]string{"active": ""},
+			MetadataToRemove: map[string]string{"inactive": ""},
+			MetadataToUpdate: map[string]string{"env": "prod", "owner": ""},
+		},
+	}, signalFxDimensionToMetadataUpdate("host", "host-1", update))
+}
+
+func TestSignalFxDimensionToMetadataUpdateEmpty(t *testing.T) {
+	assert.Equal(t, &metadata.MetadataUpdate{
+		ResourceIDKey: "host",
+		ResourceID:    "host-1",
+		MetadataDelta: metadata.MetadataDelta{
+			MetadataToAdd:    map[string]string{},
+			MetadataToRemove: map[string]string{},
+			MetadataToUpdate: map[string]string{},
+		},
+	}, signalFxDimensionToMetadataUpdate("host", "host-1", &dimensionUpdate{}))
+}
@@ -0,0 +1,120 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ackTracker keeps the indexer acknowledgement status of the requests of each channel.
+// Ack IDs are sequential per channel, only the last maxAcks IDs of a channel are kept.
+// At most maxChannels channels are tracked, channels unused for idleTimeout are
+// forgotten to make room for new ones.
+type ackTracker struct {
+	sync.Mutex
+	maxAcks     uint64
+	maxChannels int
+	idleTimeout time.Duration
+	channels    map[string]*channelAcks
+	now         func() time.Time
+}
+
+type channelAcks struct {
+	nextID   uint64
+	acked    map[uint64]bool
+	lastUsed time.Time
+}
+
+func newAckTracker(cfg AckConfig) *ackTracker {
+	return &ackTracker{
+		maxAcks:     uint64(cfg.MaxAcksPerChannel),
+		maxChannels: cfg.MaxChannels,
+		idleTimeout: cfg.ChannelIdleTimeout,
+		channels:    map[string]*channelAcks{},
+		now:         time.Now,
+	}
+}
+
+// newAck returns the next ack ID of the channel, not acknowledged yet. It
+// returns false if the channel is new and too many channels are in use.
+func (t *ackTracker) newAck(channel string) (uint64, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	now := t.now()
+	c, ok := t.channels[channel]
+	if !ok {
+		if len(t.channels) >= t.maxChannels {
+			t.expireIdleChannels(now)
+		}
+		if len(t.channels) >= t.maxChannels {
+			return 0, false
+		}
+		c = &channelAcks{acked: map[uint64]bool{}}
+		t.channels[channel] = c
+	}
+	c.lastUsed = now
+	id := c.nextID
+	c.nextID++
+	if id >= t.maxAcks {
+		// Forget the ID falling out of the window so the channel stays bounded.
+		delete(c.acked, id-t.maxAcks)
+	}
+	return id, true
+}
+
+// expireIdleChannels forgets the channels unused for longer than idleTimeout.
+func (t *ackTracker) expireIdleChannels(now time.Time) {
+	for channel, c := range t.channels {
+		if now.Sub(c.lastUsed) > t.idleTimeout {
+			delete(t.channels, channel)
+		}
+	}
+}
+
+// ack marks the ack ID of the channel as acknowledged.
+func (t *ackTracker) ack(channel string, id uint64) {
+	t.Lock()
+	defer t.Unlock()
+
+	c, ok := t.channels[channel]
+	if !ok || id >= c.nextID || id+t.maxAcks < c.nextID {
+		return
+	}
+	c.acked[id] = true
+}
+
+// query returns the status of the given ack IDs of the channel, keyed by ID.
+// Acknowledged IDs are forgotten once returned.
+func (t *ackTracker) query(channel string, ids []uint64) map[string]bool {
+	t.Lock()
+	defer t.Unlock()
+
+	result := make(map[string]bool, len(ids))
+	c := t.channels[channel]
+	if c != nil {
+		c.lastUsed = t.now()
+	}
+	for _, id := range ids {
+		acked := c != nil && c.acked[id]
+		if acked {
+			delete(c.acked, id)
+		}
+		result[strconv.FormatUint(id, 10)] = acked
+	}
+	return result
+}
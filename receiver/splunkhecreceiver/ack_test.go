@@ -0,0 +1,81 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAckTracker(t *testing.T) {
+	tracker := newAckTracker(AckConfig{MaxAcksPerChannel: 2, MaxChannels: 10, ChannelIdleTimeout: time.Minute})
+
+	assertNewAck(t, tracker, "a", 0)
+	assertNewAck(t, tracker, "a", 1)
+	assertNewAck(t, tracker, "b", 0)
+
+	tracker.ack("a", 1)
+	tracker.ack("b", 0)
+	// Unknown channels and IDs are ignored
+	tracker.ack("c", 0)
+	tracker.ack("a", 5)
+
+	assert.Equal(t, map[string]bool{"0": false, "1": true}, tracker.query("a", []uint64{0, 1}))
+	assert.Equal(t, map[string]bool{"1": false}, tracker.query("a", []uint64{1}))
+	assert.Equal(t, map[string]bool{"0": false}, tracker.query("c", []uint64{0}))
+	assert.Equal(t, map[string]bool{}, tracker.query("a", nil))
+
+	// Only the last 2 IDs of a channel are kept
+	tracker.ack("a", 0)
+	tracker.newAck("a")
+	tracker.newAck("a")
+	assert.Equal(t, map[string]bool{"0": false}, tracker.query("a", []uint64{0}))
+	tracker.ack("a", 1)
+	assert.Equal(t, map[string]bool{"1": false}, tracker.query("a", []uint64{1}))
+	assert.Equal(t, map[string]bool{"0": true}, tracker.query("b", []uint64{0}))
+}
+
+func TestAckTrackerMaxChannels(t *testing.T) {
+	now := time.Unix(0, 0)
+	tracker := newAckTracker(AckConfig{MaxAcksPerChannel: 2, MaxChannels: 2, ChannelIdleTimeout: time.Minute})
+	tracker.now = func() time.Time { return now }
+
+	assertNewAck(t, tracker, "a", 0)
+	assertNewAck(t, tracker, "b", 0)
+	// Known channels can still be used once the limit is reached
+	assertNewAck(t, tracker, "a", 1)
+	_, ok := tracker.newAck("c")
+	assert.False(t, ok)
+
+	// Querying a channel keeps it in use
+	now = now.Add(50 * time.Second)
+	tracker.query("b", []uint64{0})
+	now = now.Add(20 * time.Second)
+	_, ok = tracker.newAck("c")
+	assert.True(t, ok)
+	_, ok = tracker.newAck("d")
+	assert.False(t, ok)
+	// "a" was expired to make room for "c", its IDs start over
+	now = now.Add(2 * time.Minute)
+	assertNewAck(t, tracker, "a", 0)
+}
+
+func assertNewAck(t *testing.T, tracker *ackTracker, channel string, expected uint64) {
+	id, ok := tracker.newAck(channel)
+	assert.True(t, ok)
+	assert.Equal(t, expected, id)
+}
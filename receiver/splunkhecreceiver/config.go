@@ -15,9 +15,11 @@
 package splunkhecreceiver
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/gobwas/glob"
 	"go.opentelemetry.io/collector/config"
@@ -35,6 +37,31 @@ type Config struct {
 	// Path we will listen on, defaults to `*` (anything matches)
 	Path     string `mapstructure:"path"`
 	pathGlob glob.Glob
+	// RawPath is the path of the raw endpoint receiving newline-delimited log
+	// events, defaults to `/services/collector/raw`
+	RawPath string `mapstructure:"raw_path"`
+	// HealthPath is the path of the health endpoint, defaults to `/services/collector/health`
+	HealthPath string `mapstructure:"health_path"`
+	// Ack configures the indexer acknowledgement of the received events
+	Ack AckConfig `mapstructure:"ack"`
+}
+
+// AckConfig defines the configuration of the indexer acknowledgement.
+type AckConfig struct {
+	// Enabled requires requests to set a channel and returns an ack ID for each
+	// request, acknowledged once the events were accepted by the next consumer.
+	Enabled bool `mapstructure:"enabled"`
+	// Path of the ack endpoint, defaults to `/services/collector/ack`
+	Path string `mapstructure:"path"`
+	// MaxAcksPerChannel is the number of ack IDs kept for each channel until
+	// they are queried, older ones are reported as not acknowledged.
+	MaxAcksPerChannel int `mapstructure:"max_acks_per_channel"`
+	// MaxChannels is the number of channels whose ack IDs are kept. Requests
+	// on new channels are refused while that many channels are in use.
+	MaxChannels int `mapstructure:"max_channels"`
+	// ChannelIdleTimeout is the time after which a channel that received no
+	// request nor ack query is forgotten, along with its ack IDs.
+	ChannelIdleTimeout time.Duration `mapstructure:"channel_idle_timeout"`
 }
 
 // initialize and initialize the configuration
@@ -48,6 +75,15 @@ func (c *Config) initialize() error {
 		return err
 	}
 	c.pathGlob = glob
+	if c.Ack.Enabled && c.Ack.MaxAcksPerChannel <= 0 {
+		return errors.New("ack max_acks_per_channel must be greater than 0")
+	}
+	if c.Ack.Enabled && c.Ack.MaxChannels <= 0 {
+		return errors.New("ack max_channels must be greater than 0")
+	}
+	if c.Ack.Enabled && c.Ack.ChannelIdleTimeout <= 0 {
+		return errors.New("ack channel_idle_timeout must be greater than 0")
+	}
 	_, err = extractPortFromEndpoint(c.Endpoint)
 	return err
 }
@@ -17,6 +17,7 @@ package splunkhecreceiver
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -77,6 +78,30 @@ func TestInvalidPathSpaces(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestInvalidMaxAcksPerChannel(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.Ack.Enabled = true
+	c.Ack.MaxAcksPerChannel = 0
+	err := c.initialize()
+	assert.EqualError(t, err, "ack max_acks_per_channel must be greater than 0")
+}
+
+func TestInvalidMaxChannels(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.Ack.Enabled = true
+	c.Ack.MaxChannels = 0
+	err := c.initialize()
+	assert.EqualError(t, err, "ack max_channels must be greater than 0")
+}
+
+func TestInvalidChannelIdleTimeout(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.Ack.Enabled = true
+	c.Ack.ChannelIdleTimeout = 0
+	err := c.initialize()
+	assert.EqualError(t, err, "ack channel_idle_timeout must be greater than 0")
+}
+
 func TestCreateValidEndpoint(t *testing.T) {
 	endpoint, err := extractPortFromEndpoint("localhost:123")
 	assert.NoError(t, err)
@@ -129,7 +154,16 @@ func TestLoadConfig(t *testing.T) {
 			AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
 				AccessTokenPassthrough: true,
 			},
-			Path: "/foo",
+			Path:       "/foo",
+			RawPath:    "/raw",
+			HealthPath: "/health",
+			Ack: AckConfig{
+				Enabled:            true,
+				Path:               "/ack",
+				MaxAcksPerChannel:  100,
+				MaxChannels:        50,
+				ChannelIdleTimeout: 5 * time.Minute,
+			},
 		})
 
 	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "tls")].(*Config)
@@ -148,6 +182,14 @@ func TestLoadConfig(t *testing.T) {
 			AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
 				AccessTokenPassthrough: false,
 			},
-			Path: "",
+			Path:       "",
+			RawPath:    "/services/collector/raw",
+			HealthPath: "/services/collector/health",
+			Ack: AckConfig{
+				Path:               "/services/collector/ack",
+				MaxAcksPerChannel:  10000,
+				MaxChannels:        1000,
+				ChannelIdleTimeout: 10 * time.Minute,
+			},
 		})
 }
@@ -16,6 +16,7 @@ package splunkhecreceiver
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -34,6 +35,15 @@ const (
 
 	// Default endpoints to bind to.
 	defaultEndpoint = ":8088"
+
+	// Default paths of the Splunk HEC endpoints.
+	defaultRawPath    = "/services/collector/raw"
+	defaultHealthPath = "/services/collector/health"
+	defaultAckPath    = "/services/collector/ack"
+
+	defaultMaxAcksPerChannel  = 10000
+	defaultMaxChannels        = 1000
+	defaultChannelIdleTimeout = 10 * time.Minute
 )
 
 // NewFactory creates a factory for SignalFx receiver.
@@ -54,6 +64,14 @@ func createDefaultConfig() config.Receiver {
 		},
 		AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{},
 		Path:                         "",
+		RawPath:                      defaultRawPath,
+		HealthPath:                   defaultHealthPath,
+		Ack: AckConfig{
+			Path:               defaultAckPath,
+			MaxAcksPerChannel:  defaultMaxAcksPerChannel,
+			MaxChannels:        defaultMaxChannels,
+			ChannelIdleTimeout: defaultChannelIdleTimeout,
+		},
 	}
 }
 
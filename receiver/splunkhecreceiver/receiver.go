@@ -15,13 +15,16 @@
 package splunkhecreceiver
 
 import (
+	"bufio"
 	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -49,16 +52,35 @@ const (
 	responseErrInternalServerError    = "Internal Server Error"
 	responseErrUnsupportedMetricEvent = "Unsupported metric event"
 	responseErrUnsupportedLogEvent    = "Unsupported log event"
+	responseErrDataChannelMissing     = "Data channel is missing"
+	responseErrAckDisabled            = "ACK is disabled"
+	responseErrServerBusy             = "Server is busy"
+	responseSuccess                   = "Success"
+	responseHealthy                   = "HEC is healthy"
+
+	// Splunk HEC response codes.
+	hecCodeSuccess    = 0
+	hecCodeServerBusy = 9
+	hecCodeHealthy    = 17
 
 	// Centralizing some HTTP and related string constants.
 	gzipEncoding              = "gzip"
 	httpContentEncodingHeader = "Content-Encoding"
+	httpRequestChannelHeader  = "X-Splunk-Request-Channel"
+
+	// Query parameters of the raw and ack endpoints.
+	queryChannel    = "channel"
+	queryHost       = "host"
+	querySource     = "source"
+	querySourceType = "sourcetype"
+	queryIndex      = "index"
 )
 
 var (
 	errNilNextMetricsConsumer = errors.New("nil metricsConsumer")
 	errNilNextLogsConsumer    = errors.New("nil logsConsumer")
 	errEmptyEndpoint          = errors.New("empty endpoint")
+	errTooManyChannels        = errors.New("too many channels in use")
 
 	okRespBody                = initJSONResponse(responseOK)
 	notFoundRespBody          = initJSONResponse(responseNotFound)
@@ -69,8 +91,29 @@ var (
 	errInternalServerError    = initJSONResponse(responseErrInternalServerError)
 	errUnsupportedMetricEvent = initJSONResponse(responseErrUnsupportedMetricEvent)
 	errUnsupportedLogEvent    = initJSONResponse(responseErrUnsupportedLogEvent)
+	errDataChannelMissing     = initJSONResponse(responseErrDataChannelMissing)
+	errAckDisabled            = initJSONResponse(responseErrAckDisabled)
+	healthyRespBody           = initHECResponse(hecResponse{Text: responseHealthy, Code: hecCodeHealthy})
+	errServerBusyRespBody     = initHECResponse(hecResponse{Text: responseErrServerBusy, Code: hecCodeServerBusy})
 )
 
+// hecResponse is the response body of the Splunk HEC endpoints returning a status code.
+type hecResponse struct {
+	Text  string  `json:"text"`
+	Code  int     `json:"code"`
+	AckID *uint64 `json:"ackId,omitempty"`
+}
+
+// ackRequest is the request body of the ack endpoint.
+type ackRequest struct {
+	Acks []uint64 `json:"acks"`
+}
+
+// ackResponse is the response body of the ack endpoint.
+type ackResponse struct {
+	Acks map[string]bool `json:"acks"`
+}
+
 // splunkReceiver implements the component.MetricsReceiver for Splunk HEC metric protocol.
 type splunkReceiver struct {
 	sync.Mutex
@@ -79,6 +122,8 @@ type splunkReceiver struct {
 	logsConsumer    consumer.Logs
 	metricsConsumer consumer.Metrics
 	server          *http.Server
+	// acks tracks the indexer acknowledgements, nil when disabled.
+	acks *ackTracker
 }
 
 var _ component.MetricsReceiver = (*splunkReceiver)(nil)
@@ -109,6 +154,9 @@ func NewMetricsReceiver(
 			WriteTimeout:      defaultServerTimeout,
 		},
 	}
+	if config.Ack.Enabled {
+		r.acks = newAckTracker(config.Ack)
+	}
 
 	return r, nil
 }
@@ -139,6 +187,9 @@ func NewLogsReceiver(
 			WriteTimeout:      defaultServerTimeout,
 		},
 	}
+	if config.Ack.Enabled {
+		r.acks = newAckTracker(config.Ack)
+	}
 
 	return r, nil
 }
@@ -158,6 +209,16 @@ func (r *splunkReceiver) Start(_ context.Context, host component.Host) error {
 	}
 
 	mx := mux.NewRouter()
+	// The Splunk HEC endpoints take precedence over the events path.
+	if r.config.HealthPath != "" {
+		mx.NewRoute().Path(r.config.HealthPath).HandlerFunc(r.handleHealthReq)
+	}
+	if r.config.Ack.Path != "" {
+		mx.NewRoute().Path(r.config.Ack.Path).HandlerFunc(r.handleAckReq)
+	}
+	if r.config.RawPath != "" {
+		mx.NewRoute().Path(r.config.RawPath).HandlerFunc(r.handleRawReq)
+	}
 	mx.NewRoute().HandlerFunc(r.handleReq)
 
 	r.server = r.config.HTTPServerSettings.ToServer(mx)
@@ -187,13 +248,15 @@ func (r *splunkReceiver) Shutdown(context.Context) error {
 	return err
 }
 
-func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request) {
-
-	transport := "http"
+func (r *splunkReceiver) transport() string {
 	if r.config.TLSSetting != nil {
-		transport = "https"
+		return "https"
 	}
+	return "http"
+}
 
+func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request) {
+	transport := r.transport()
 	ctx := obsreport.ReceiverContext(req.Context(), r.config.ID(), transport)
 	if r.logsConsumer == nil {
 		ctx = obsreport.StartMetricsReceiveOp(ctx, r.config.ID(), transport)
@@ -204,27 +267,16 @@ func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	if req.Method != http.MethodPost {
-		r.failRequest(ctx, resp, http.StatusBadRequest, invalidMethodRespBody, nil)
+	bodyReader, ok := r.bodyReader(ctx, resp, req)
+	if !ok {
 		return
 	}
 
-	encoding := req.Header.Get(httpContentEncodingHeader)
-	if encoding != "" && encoding != gzipEncoding {
-		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, invalidEncodingRespBody, nil)
+	channel, ok := r.requestChannel(ctx, resp, req)
+	if !ok {
 		return
 	}
 
-	bodyReader := req.Body
-	if encoding == gzipEncoding {
-		var err error
-		bodyReader, err = gzip.NewReader(bodyReader)
-		if err != nil {
-			r.failRequest(ctx, resp, http.StatusBadRequest, errGzipReaderRespBody, err)
-			return
-		}
-	}
-
 	if req.ContentLength == 0 {
 		resp.Write(okRespBody)
 		return
@@ -254,10 +306,139 @@ func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request)
 		events = append(events, &msg)
 	}
 	if r.logsConsumer != nil {
-		r.consumeLogs(ctx, events, resp, req)
+		r.consumeLogs(ctx, events, resp, req, channel)
 	} else {
-		r.consumeMetrics(ctx, events, resp, req)
+		r.consumeMetrics(ctx, events, resp, req, channel)
+	}
+}
+
+// handleRawReq handles the raw endpoint, turning each line of the body into a log
+// event with the host, source, sourcetype and index of the query parameters.
+func (r *splunkReceiver) handleRawReq(resp http.ResponseWriter, req *http.Request) {
+	ctx := obsreport.ReceiverContext(req.Context(), r.config.ID(), r.transport())
+	if r.logsConsumer == nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errUnsupportedLogEvent, nil)
+		return
+	}
+
+	bodyReader, ok := r.bodyReader(ctx, resp, req)
+	if !ok {
+		return
+	}
+
+	channel, ok := r.requestChannel(ctx, resp, req)
+	if !ok {
+		return
+	}
+
+	if req.ContentLength == 0 {
+		resp.Write(okRespBody)
+		return
 	}
+
+	query := req.URL.Query()
+	var events []*splunk.Event
+	br := bufio.NewReader(bodyReader)
+	for {
+		line, err := br.ReadString('\n')
+		if text := strings.TrimRight(line, "\r\n"); text != "" {
+			events = append(events, &splunk.Event{
+				Host:       query.Get(queryHost),
+				Source:     query.Get(querySource),
+				SourceType: query.Get(querySourceType),
+				Index:      query.Get(queryIndex),
+				Event:      text,
+			})
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			r.failRequest(ctx, resp, http.StatusBadRequest, errUnmarshalBodyRespBody, err)
+			return
+		}
+	}
+
+	r.consumeLogs(ctx, events, resp, req, channel)
+}
+
+// handleAckReq returns the indexer acknowledgement status of the ack IDs of a channel.
+func (r *splunkReceiver) handleAckReq(resp http.ResponseWriter, req *http.Request) {
+	ctx := obsreport.ReceiverContext(req.Context(), r.config.ID(), r.transport())
+	if r.acks == nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errAckDisabled, nil)
+		return
+	}
+
+	bodyReader, ok := r.bodyReader(ctx, resp, req)
+	if !ok {
+		return
+	}
+
+	channel, ok := r.requestChannel(ctx, resp, req)
+	if !ok {
+		return
+	}
+
+	var ackReq ackRequest
+	if err := json.NewDecoder(bodyReader).Decode(&ackReq); err != nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errUnmarshalBodyRespBody, err)
+		return
+	}
+
+	respBody, err := json.Marshal(ackResponse{Acks: r.acks.query(channel, ackReq.Acks)})
+	if err != nil {
+		r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, err)
+		return
+	}
+	resp.WriteHeader(http.StatusOK)
+	resp.Write(respBody)
+}
+
+// handleHealthReq reports the receiver as healthy as long as it is serving requests.
+func (r *splunkReceiver) handleHealthReq(resp http.ResponseWriter, _ *http.Request) {
+	resp.WriteHeader(http.StatusOK)
+	resp.Write(healthyRespBody)
+}
+
+// bodyReader checks the method and the encoding of the request and returns its
+// body. It fails the request and returns false if they are not supported.
+func (r *splunkReceiver) bodyReader(ctx context.Context, resp http.ResponseWriter, req *http.Request) (io.Reader, bool) {
+	if req.Method != http.MethodPost {
+		r.failRequest(ctx, resp, http.StatusBadRequest, invalidMethodRespBody, nil)
+		return nil, false
+	}
+
+	encoding := req.Header.Get(httpContentEncodingHeader)
+	if encoding != "" && encoding != gzipEncoding {
+		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, invalidEncodingRespBody, nil)
+		return nil, false
+	}
+
+	if encoding == gzipEncoding {
+		gzipReader, err := gzip.NewReader(req.Body)
+		if err != nil {
+			r.failRequest(ctx, resp, http.StatusBadRequest, errGzipReaderRespBody, err)
+			return nil, false
+		}
+		return gzipReader, true
+	}
+	return req.Body, true
+}
+
+// requestChannel returns the channel of the request, from its header or its query.
+// When indexer acknowledgement is enabled the channel is required, the request is
+// failed and false returned if it is missing.
+func (r *splunkReceiver) requestChannel(ctx context.Context, resp http.ResponseWriter, req *http.Request) (string, bool) {
+	channel := req.Header.Get(httpRequestChannelHeader)
+	if channel == "" {
+		channel = req.URL.Query().Get(queryChannel)
+	}
+	if r.acks != nil && channel == "" {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errDataChannelMissing, nil)
+		return "", false
+	}
+	return channel, true
 }
 
 func (r *splunkReceiver) createResourceCustomizer(req *http.Request) func(pdata.Resource) {
@@ -271,35 +452,69 @@ func (r *splunkReceiver) createResourceCustomizer(req *http.Request) func(pdata.
 	return func(resource pdata.Resource) {}
 }
 
-func (r *splunkReceiver) consumeMetrics(ctx context.Context, events []*splunk.Event, resp http.ResponseWriter, req *http.Request) {
+func (r *splunkReceiver) consumeMetrics(ctx context.Context, events []*splunk.Event, resp http.ResponseWriter, req *http.Request, channel string) {
 	md, _ := SplunkHecToMetricsData(r.logger, events, r.createResourceCustomizer(req))
 
+	ackID, ok := r.newAck(ctx, resp, channel)
+	if !ok {
+		obsreport.EndMetricsReceiveOp(ctx, typeStr, len(events), errTooManyChannels)
+		return
+	}
 	decodeErr := r.metricsConsumer.ConsumeMetrics(ctx, md)
 	obsreport.EndMetricsReceiveOp(ctx, typeStr, len(events), decodeErr)
 
 	if decodeErr != nil {
 		r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, decodeErr)
 	} else {
-		resp.WriteHeader(http.StatusAccepted)
-		resp.Write(okRespBody)
+		r.writeAccepted(resp, channel, ackID)
 	}
 }
 
-func (r *splunkReceiver) consumeLogs(ctx context.Context, events []*splunk.Event, resp http.ResponseWriter, req *http.Request) {
+func (r *splunkReceiver) consumeLogs(ctx context.Context, events []*splunk.Event, resp http.ResponseWriter, req *http.Request, channel string) {
 	ld, err := SplunkHecToLogData(r.logger, events, r.createResourceCustomizer(req))
 	if err != nil {
 		r.failRequest(ctx, resp, http.StatusBadRequest, errUnmarshalBodyRespBody, err)
 		return
 	}
 
+	ackID, ok := r.newAck(ctx, resp, channel)
+	if !ok {
+		return
+	}
 	decodeErr := r.logsConsumer.ConsumeLogs(ctx, ld)
 
 	if decodeErr != nil {
 		r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, decodeErr)
 	} else {
+		r.writeAccepted(resp, channel, ackID)
+	}
+}
+
+// newAck returns a new ack ID of the channel when indexer acknowledgement is enabled.
+// The request is failed and false returned if the channel can't be tracked.
+func (r *splunkReceiver) newAck(ctx context.Context, resp http.ResponseWriter, channel string) (uint64, bool) {
+	if r.acks == nil {
+		return 0, true
+	}
+	ackID, ok := r.acks.newAck(channel)
+	if !ok {
+		r.failRequest(ctx, resp, http.StatusServiceUnavailable, errServerBusyRespBody, errTooManyChannels)
+	}
+	return ackID, ok
+}
+
+// writeAccepted responds to a request whose events were accepted by the next consumer.
+// When indexer acknowledgement is enabled the ack ID is acknowledged and returned.
+func (r *splunkReceiver) writeAccepted(resp http.ResponseWriter, channel string, ackID uint64) {
+	if r.acks == nil {
 		resp.WriteHeader(http.StatusAccepted)
 		resp.Write(okRespBody)
+		return
 	}
+
+	r.acks.ack(channel, ackID)
+	resp.WriteHeader(http.StatusAccepted)
+	resp.Write(initHECResponse(hecResponse{Text: responseSuccess, Code: hecCodeSuccess, AckID: &ackID}))
 }
 
 func (r *splunkReceiver) failRequest(
@@ -344,6 +559,15 @@ func (r *splunkReceiver) failRequest(
 	)
 }
 
+func initHECResponse(r hecResponse) []byte {
+	respBody, err := json.Marshal(r)
+	if err != nil {
+		// The response only contains basic types so it cannot fail.
+		panic(err)
+	}
+	return respBody
+}
+
 func initJSONResponse(s string) []byte {
 	respBody, err := json.Marshal(s)
 	if err != nil {
@@ -39,6 +39,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/testutil"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
@@ -734,6 +735,274 @@ func Test_Metrics_splunkhecReceiver_IndexSourceTypePassthrough(t *testing.T) {
 	}
 }
 
+func Test_splunkhecReceiver_handleRawReq(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	config.initialize()
+
+	tests := []struct {
+		name           string
+		req            *http.Request
+		assertResponse func(t *testing.T, status int, body string)
+		assertSink     func(t *testing.T, sink *consumertest.LogsSink)
+	}{
+		{
+			name: "incorrect_method",
+			req:  httptest.NewRequest("GET", "http://localhost/services/collector/raw", nil),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, responseInvalidMethod, body)
+			},
+		},
+		{
+			name: "empty_body",
+			req:  httptest.NewRequest("POST", "http://localhost/services/collector/raw", bytes.NewReader(nil)),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusOK, status)
+				assert.Equal(t, responseOK, body)
+			},
+		},
+		{
+			name: "lines_accepted",
+			req: httptest.NewRequest("POST",
+				"http://localhost/services/collector/raw?host=myhost&source=mysource&sourcetype=mysourcetype&index=myindex",
+				bytes.NewReader([]byte("first line\r\n\nsecond line"))),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusAccepted, status)
+				assert.Equal(t, responseOK, body)
+			},
+			assertSink: func(t *testing.T, sink *consumertest.LogsSink) {
+				require.Len(t, sink.AllLogs(), 1)
+				logs := sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+				require.Equal(t, 2, logs.Len())
+				assert.Equal(t, "first line", logs.At(0).Body().StringVal())
+				assert.Equal(t, "second line", logs.At(1).Body().StringVal())
+				assert.Equal(t, "mysourcetype", logs.At(0).Name())
+				attrs := logs.At(0).Attributes()
+				for key, value := range map[string]string{
+					conventions.AttributeHostName:    "myhost",
+					conventions.AttributeServiceName: "mysource",
+					splunk.SourcetypeLabel:           "mysourcetype",
+					splunk.IndexLabel:                "myindex",
+				} {
+					attr, ok := attrs.Get(key)
+					require.True(t, ok, key)
+					assert.Equal(t, value, attr.StringVal())
+				}
+			},
+		},
+		{
+			name: "gzipped_lines_accepted",
+			req: func() *http.Request {
+				var buf bytes.Buffer
+				gzipWriter := gzip.NewWriter(&buf)
+				_, err := gzipWriter.Write([]byte("first line\nsecond line\n"))
+				require.NoError(t, err)
+				require.NoError(t, gzipWriter.Close())
+
+				req := httptest.NewRequest("POST", "http://localhost/services/collector/raw", &buf)
+				req.Header.Set("Content-Encoding", "gzip")
+				return req
+			}(),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusAccepted, status)
+				assert.Equal(t, responseOK, body)
+			},
+			assertSink: func(t *testing.T, sink *consumertest.LogsSink) {
+				assert.Equal(t, 2, sink.LogRecordsCount())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			rcv, err := NewLogsReceiver(zap.NewNop(), *config, sink)
+			assert.NoError(t, err)
+
+			r := rcv.(*splunkReceiver)
+			w := httptest.NewRecorder()
+			r.handleRawReq(w, tt.req)
+
+			resp := w.Result()
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			var bodyStr string
+			assert.NoError(t, json.Unmarshal(respBytes, &bodyStr))
+
+			tt.assertResponse(t, resp.StatusCode, bodyStr)
+			if tt.assertSink != nil {
+				tt.assertSink(t, sink)
+			}
+		})
+	}
+}
+
+func Test_splunkhecReceiver_handleRawReq_metrics(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	config.initialize()
+	rcv, err := NewMetricsReceiver(zap.NewNop(), *config, new(consumertest.MetricsSink))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "http://localhost/services/collector/raw", bytes.NewReader([]byte("line")))
+	rcv.(*splunkReceiver).handleRawReq(w, req)
+
+	resp := w.Result()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, errUnsupportedLogEvent, respBytes)
+}
+
+func Test_splunkhecReceiver_Ack(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	config.Ack.Enabled = true
+	require.NoError(t, config.initialize())
+
+	sink := new(consumertest.LogsSink)
+	rcv, err := NewLogsReceiver(zap.NewNop(), *config, sink)
+	require.NoError(t, err)
+	r := rcv.(*splunkReceiver)
+
+	msgBytes, err := json.Marshal(buildSplunkHecMsg(float64(time.Now().UnixNano())/1e6, 3))
+	require.NoError(t, err)
+
+	// The channel is required
+	w := httptest.NewRecorder()
+	r.handleReq(w, httptest.NewRequest("POST", "http://localhost/services/collector", bytes.NewReader(msgBytes)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, errDataChannelMissing, w.Body.Bytes())
+
+	// Each request of a channel gets its own ack ID
+	for i, handle := range []func(http.ResponseWriter, *http.Request){r.handleReq, r.handleRawReq} {
+		w = httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "http://localhost/services/collector", bytes.NewReader(msgBytes))
+		req.Header.Set(httpRequestChannelHeader, "channel-1")
+		handle(w, req)
+		require.Equal(t, http.StatusAccepted, w.Code)
+
+		var hecResp hecResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &hecResp))
+		assert.Equal(t, responseSuccess, hecResp.Text)
+		require.NotNil(t, hecResp.AckID)
+		assert.Equal(t, uint64(i), *hecResp.AckID)
+	}
+
+	queryAcks := func(channel string, acks ...uint64) map[string]bool {
+		body, err := json.Marshal(ackRequest{Acks: acks})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.handleAckReq(w, httptest.NewRequest("POST", "http://localhost/services/collector/ack?channel="+channel, bytes.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp ackResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Acks
+	}
+
+	assert.Equal(t, map[string]bool{"0": true, "1": true, "2": false}, queryAcks("channel-1", 0, 1, 2))
+	// Acknowledged IDs are only reported once
+	assert.Equal(t, map[string]bool{"0": false}, queryAcks("channel-1", 0))
+	// Acks are tracked per channel
+	assert.Equal(t, map[string]bool{"1": false}, queryAcks("channel-2", 1))
+}
+
+func Test_splunkhecReceiver_Ack_consumer_err(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	config.Ack.Enabled = true
+	require.NoError(t, config.initialize())
+
+	rcv, err := NewLogsReceiver(zap.NewNop(), *config, consumertest.NewErr(errors.New("bad consumer")))
+	require.NoError(t, err)
+	r := rcv.(*splunkReceiver)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "http://localhost/services/collector/raw?channel=channel-1", bytes.NewReader([]byte("line")))
+	r.handleRawReq(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// The events were not accepted so they are never acknowledged
+	assert.Equal(t, map[string]bool{"0": false}, r.acks.query("channel-1", []uint64{0}))
+}
+
+func Test_splunkhecReceiver_Ack_too_many_channels(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	config.Ack.Enabled = true
+	config.Ack.MaxChannels = 1
+	require.NoError(t, config.initialize())
+
+	sink := new(consumertest.LogsSink)
+	rcv, err := NewLogsReceiver(zap.NewNop(), *config, sink)
+	require.NoError(t, err)
+	r := rcv.(*splunkReceiver)
+
+	w := httptest.NewRecorder()
+	r.handleRawReq(w, httptest.NewRequest("POST", "http://localhost/services/collector/raw?channel=channel-1", bytes.NewReader([]byte("line"))))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	// The channel in use is not idle yet so the new one is rejected
+	w = httptest.NewRecorder()
+	r.handleRawReq(w, httptest.NewRequest("POST", "http://localhost/services/collector/raw?channel=channel-2", bytes.NewReader([]byte("line"))))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, errServerBusyRespBody, w.Body.Bytes())
+	assert.Equal(t, 1, sink.LogRecordsCount())
+}
+
+func Test_splunkhecReceiver_Ack_disabled(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	config.initialize()
+
+	rcv, err := NewLogsReceiver(zap.NewNop(), *config, new(consumertest.LogsSink))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "http://localhost/services/collector/ack?channel=channel-1", bytes.NewReader([]byte(`{"acks":[0]}`)))
+	rcv.(*splunkReceiver).handleAckReq(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, errAckDisabled, w.Body.Bytes())
+}
+
+func Test_splunkhecReceiver_Routes(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = addr
+	require.NoError(t, cfg.initialize())
+	sink := new(consumertest.LogsSink)
+	r, err := NewLogsReceiver(zap.NewNop(), *cfg, sink)
+	require.NoError(t, err)
+	defer r.Shutdown(context.Background())
+	require.NoError(t, r.Start(context.Background(), newAssertNoErrorHost(t)))
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/services/collector/health", addr))
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"text":"HEC is healthy","code":17}`, string(body))
+
+	resp, err = http.Post(fmt.Sprintf("http://%s/services/collector/raw", addr), "text/plain", bytes.NewReader([]byte("a\nb\n")))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, 2, sink.LogRecordsCount())
+
+	msgBytes, err := json.Marshal(buildSplunkHecMsg(float64(time.Now().UnixNano())/1e6, 0))
+	require.NoError(t, err)
+	resp, err = http.Post(fmt.Sprintf("http://%s/services/collector", addr), "application/json", bytes.NewReader(msgBytes))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, 3, sink.LogRecordsCount())
+}
+
 func buildSplunkHecMetricsMsg(time float64, value int64, dimensions uint) *splunk.Event {
 	ev := &splunk.Event{
 		Time:  &time,
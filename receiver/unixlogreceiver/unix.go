@@ -0,0 +1,113 @@
+// Copyright 2021 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unixlogreceiver
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/socketinput"
+)
+
+const (
+	typeStr = "unixlog"
+
+	socketTypeStream   = "stream"
+	socketTypeDatagram = "datagram"
+)
+
+// NewFactory creates a factory for unix socket receiver
+func NewFactory() component.ReceiverFactory {
+	return stanza.NewFactory(ReceiverType{})
+}
+
+// ReceiverType implements stanza.LogReceiverType
+// to create a unix socket receiver
+type ReceiverType struct{}
+
+// Type is the receiver type
+func (f ReceiverType) Type() config.Type {
+	return config.Type(typeStr)
+}
+
+// CreateDefaultConfig creates a config with type and version
+func (f ReceiverType) CreateDefaultConfig() config.Receiver {
+	return &UnixLogConfig{
+		BaseConfig: stanza.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+			Operators:        stanza.OperatorConfigs{},
+		},
+		Input: stanza.InputConfig{},
+	}
+}
+
+// BaseConfig gets the base config from config, for now
+func (f ReceiverType) BaseConfig(cfg config.Receiver) stanza.BaseConfig {
+	return cfg.(*UnixLogConfig).BaseConfig
+}
+
+// UnixLogConfig defines configuration for the unix socket receiver
+type UnixLogConfig struct {
+	stanza.BaseConfig `mapstructure:",squash"`
+	Input             stanza.InputConfig `mapstructure:",remain"`
+}
+
+// DecodeInputConfig unmarshals the input operator
+func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
+	logConfig := cfg.(*UnixLogConfig)
+	yamlBytes, _ := yaml.Marshal(logConfig.Input)
+	inputCfg := newUnixInputConfig("unix_input")
+
+	if err := yaml.Unmarshal(yamlBytes, &inputCfg); err != nil {
+		return nil, err
+	}
+
+	return &operator.Config{Builder: inputCfg}, nil
+}
+
+// unixInputConfig is the configuration of the operator reading logs from a unix socket
+type unixInputConfig struct {
+	socketinput.Config `yaml:",inline"`
+
+	SocketPath string `mapstructure:"socket_path,omitempty" json:"socket_path,omitempty" yaml:"socket_path,omitempty"`
+	SocketType string `mapstructure:"socket_type,omitempty" json:"socket_type,omitempty" yaml:"socket_type,omitempty"`
+}
+
+func newUnixInputConfig(operatorID string) *unixInputConfig {
+	return &unixInputConfig{
+		Config:     socketinput.NewConfig(operatorID, "unix_input"),
+		SocketType: socketTypeStream,
+	}
+}
+
+// Build will build a unix socket input operator
+func (c unixInputConfig) Build(context operator.BuildContext) ([]operator.Operator, error) {
+	var network string
+	switch c.SocketType {
+	case socketTypeStream:
+		network = socketinput.NetworkUnix
+	case socketTypeDatagram:
+		network = socketinput.NetworkUnixgram
+	default:
+		return nil, fmt.Errorf("invalid socket_type '%s', must be '%s' or '%s'", c.SocketType, socketTypeStream, socketTypeDatagram)
+	}
+
+	return c.Config.Build(context, network, c.SocketPath)
+}
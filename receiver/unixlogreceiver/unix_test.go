@@ -0,0 +1,171 @@
+// Copyright 2021 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unixlogreceiver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza"
+)
+
+func TestUnixStream(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "stream.sock")
+	cfg := testdataConfigYamlAsMap()
+	cfg.Input = stanza.InputConfig{
+		"socket_path": socketPath,
+	}
+	testUnix(t, cfg, "unix", socketPath)
+}
+
+func TestUnixDatagram(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "datagram.sock")
+	cfg := testdataConfigYamlAsMap()
+	cfg.Input = stanza.InputConfig{
+		"socket_path": socketPath,
+		"socket_type": "datagram",
+	}
+	testUnix(t, cfg, "unixgram", socketPath)
+}
+
+func testUnix(t *testing.T, cfg *UnixLogConfig, network, socketPath string) {
+	numLogs := 5
+
+	f := NewFactory()
+	params := component.ReceiverCreateParams{Logger: zaptest.NewLogger(t)}
+	sink := new(consumertest.LogsSink)
+	rcvr, err := f.CreateLogsReceiver(context.Background(), params, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+
+	var conn net.Conn
+	conn, err = net.Dial(network, socketPath)
+	require.NoError(t, err)
+
+	for i := 0; i < numLogs; i++ {
+		msg := fmt.Sprintf("<86>1 2021-02-28T00:0%d:02.003Z test msg %d\n", i, i)
+		_, err = conn.Write([]byte(msg))
+		require.NoError(t, err)
+	}
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, expectNLogs(sink, numLogs), 2*time.Second, time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+
+	resourceLogs := sink.AllLogs()[0].ResourceLogs().At(0)
+	logs := resourceLogs.InstrumentationLibraryLogs().At(0).Logs()
+
+	for i := 0; i < numLogs; i++ {
+		log := logs.At(i)
+
+		msg := log.Body()
+		require.Equal(t, msg.StringVal(), fmt.Sprintf("<86>1 2021-02-28T00:0%d:02.003Z test msg %d", i, i))
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[config.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 1)
+	assert.Equal(t, testdataConfigYamlAsMap(), cfg.Receivers[config.NewID(typeStr)])
+}
+
+func testdataConfigYamlAsMap() *UnixLogConfig {
+	return &UnixLogConfig{
+		BaseConfig: stanza.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+			Operators:        stanza.OperatorConfigs{},
+			Converter: stanza.ConverterConfig{
+				WorkerCount: 1,
+			},
+		},
+		Input: stanza.InputConfig{
+			"socket_path": "/tmp/otelcol-unixlog.sock",
+			"socket_type": "datagram",
+		},
+	}
+}
+
+func TestDecodeInputConfigFailure(t *testing.T) {
+	params := component.ReceiverCreateParams{
+		Logger: zap.NewNop(),
+	}
+	sink := new(consumertest.LogsSink)
+	factory := NewFactory()
+	badCfg := &UnixLogConfig{
+		BaseConfig: stanza.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+			Operators:        stanza.OperatorConfigs{},
+		},
+		Input: stanza.InputConfig{
+			"max_log_size": "0.1.0.1-",
+		},
+	}
+	receiver, err := factory.CreateLogsReceiver(context.Background(), params, badCfg, sink)
+	require.Error(t, err, "receiver creation should fail if input config isn't valid")
+	require.Nil(t, receiver, "receiver creation should fail if input config isn't valid")
+}
+
+func TestInvalidSocketType(t *testing.T) {
+	params := component.ReceiverCreateParams{
+		Logger: zap.NewNop(),
+	}
+	sink := new(consumertest.LogsSink)
+	factory := NewFactory()
+	badCfg := &UnixLogConfig{
+		BaseConfig: stanza.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+			Operators:        stanza.OperatorConfigs{},
+		},
+		Input: stanza.InputConfig{
+			"socket_path": filepath.Join(t.TempDir(), "invalid.sock"),
+			"socket_type": "seqpacket",
+		},
+	}
+	receiver, err := factory.CreateLogsReceiver(context.Background(), params, badCfg, sink)
+	require.Error(t, err)
+	require.Nil(t, receiver)
+}
+
+func expectNLogs(sink *consumertest.LogsSink, expected int) func() bool {
+	return func() bool {
+		return sink.LogRecordsCount() == expected
+	}
+}
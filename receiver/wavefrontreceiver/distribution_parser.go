@@ -0,0 +1,154 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// granularities maps the prefix of a Wavefront distribution to the interval
+// covered by the distribution.
+var granularities = map[string]time.Duration{
+	"!M": time.Minute,
+	"!H": time.Hour,
+	"!D": 24 * time.Hour,
+}
+
+type centroid struct {
+	value float64
+	count uint64
+}
+
+// isDistribution returns true if the line is in the Wavefront histogram
+// distribution format.
+func isDistribution(line string) bool {
+	if len(line) < 3 || line[2] != ' ' {
+		return false
+	}
+	_, ok := granularities[line[:2]]
+	return ok
+}
+
+// parseDistribution converts a histogram distribution in the Wavefront format, see
+// https://docs.wavefront.com/proxies_histograms.html#histogram-data-format-syntax,
+// into an OTLP delta histogram. Each line has the following format:
+//
+// 	"{!M | !H | !D} [<timestamp>] #<count> <centroid> [#<count> <centroid> ...] <metricName> source=<source> [pointTags]"
+//
+// The centroids are used as the explicit bounds of the histogram so each of them
+// falls in its own bucket.
+func parseDistribution(line string) (pdata.Metrics, error) {
+	granularity := granularities[line[:2]]
+	rest := strings.TrimLeft(line[3:], " ")
+
+	// The timestamp, in seconds, is optional and defaults to the start of the
+	// current interval.
+	timestamp := time.Now().Truncate(granularity)
+	if !strings.HasPrefix(rest, "#") {
+		var tsStr string
+		tsStr, rest = nextField(rest)
+		ts, err := strconv.ParseInt(tsStr, 10, 64)
+		if err != nil {
+			return pdata.Metrics{}, fmt.Errorf("invalid timestamp for wavefront distribution [%s]: %v", line, err)
+		}
+		timestamp = time.Unix(ts, 0)
+	}
+
+	var centroids []centroid
+	for strings.HasPrefix(rest, "#") {
+		var countStr, valueStr string
+		countStr, rest = nextField(rest)
+		valueStr, rest = nextField(rest)
+		count, err := strconv.ParseUint(countStr[1:], 10, 64)
+		if err != nil {
+			return pdata.Metrics{}, fmt.Errorf("invalid centroid count for wavefront distribution [%s]: %v", line, err)
+		}
+		value, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return pdata.Metrics{}, fmt.Errorf("invalid centroid value for wavefront distribution [%s]: %v", line, err)
+		}
+		centroids = append(centroids, centroid{value: value, count: count})
+	}
+	if len(centroids) == 0 {
+		return pdata.Metrics{}, fmt.Errorf("wavefront distribution without centroids [%s]", line)
+	}
+
+	name, tags := splitName(rest)
+	if name == "" {
+		return pdata.Metrics{}, fmt.Errorf("empty name for wavefront distribution [%s]", line)
+	}
+	keys, values, err := buildLabels(tags)
+	if err != nil {
+		return pdata.Metrics{}, fmt.Errorf("invalid wavefront distribution [%s]: %v", line, err)
+	}
+
+	md := pdata.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(name)
+	metric.SetDataType(pdata.MetricDataTypeHistogram)
+	metric.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+
+	dp := metric.Histogram().DataPoints().AppendEmpty()
+	for i, key := range keys {
+		dp.LabelsMap().Upsert(key.Key, values[i].Value)
+	}
+	dp.SetStartTimestamp(pdata.TimestampFromTime(timestamp))
+	dp.SetTimestamp(pdata.TimestampFromTime(timestamp.Add(granularity)))
+
+	bounds, counts, count, sum := centroidsToBuckets(centroids)
+	dp.SetExplicitBounds(bounds)
+	dp.SetBucketCounts(counts)
+	dp.SetCount(count)
+	dp.SetSum(sum)
+
+	return md, nil
+}
+
+// centroidsToBuckets merges centroids with the same value and returns the sorted
+// values as explicit bounds along with the bucket counts, the last bucket
+// (greater than the last bound) being always empty.
+func centroidsToBuckets(centroids []centroid) (bounds []float64, counts []uint64, count uint64, sum float64) {
+	sort.Slice(centroids, func(i, j int) bool {
+		return centroids[i].value < centroids[j].value
+	})
+
+	for i, c := range centroids {
+		if i > 0 && c.value == centroids[i-1].value {
+			counts[len(counts)-1] += c.count
+		} else {
+			bounds = append(bounds, c.value)
+			counts = append(counts, c.count)
+		}
+		count += c.count
+		sum += c.value * float64(c.count)
+	}
+	counts = append(counts, 0)
+	return
+}
+
+// nextField splits the first space separated field from the given string.
+func nextField(s string) (string, string) {
+	parts := strings.SplitN(s, " ", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], strings.TrimLeft(parts[1], " ")
+}
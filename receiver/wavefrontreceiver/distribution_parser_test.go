@@ -0,0 +1,105 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func Test_isDistribution(t *testing.T) {
+	assert.True(t, isDistribution("!M 1533529977 #20 30.0 request.latency source=appServer15"))
+	assert.True(t, isDistribution("!H #20 30.0 request.latency source=appServer15"))
+	assert.True(t, isDistribution("!D #20 30.0 request.latency source=appServer15"))
+	assert.False(t, isDistribution("!X #20 30.0 request.latency source=appServer15"))
+	assert.False(t, isDistribution("!Mrequest.latency 1 source=appServer15"))
+	assert.False(t, isDistribution("request.latency 1 source=appServer15"))
+}
+
+func Test_parseDistribution(t *testing.T) {
+	md, err := parseDistribution("!M 1533529977 #20 30.0 #10 5.1 #2 30.0 request.latency source=appServer15 region=us-west")
+	require.NoError(t, err)
+	require.Equal(t, 1, md.MetricCount())
+
+	metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "request.latency", metric.Name())
+	require.Equal(t, pdata.MetricDataTypeHistogram, metric.DataType())
+	assert.Equal(t, pdata.AggregationTemporalityDelta, metric.Histogram().AggregationTemporality())
+
+	dp := metric.Histogram().DataPoints().At(0)
+	assert.Equal(t, pdata.TimestampFromTime(time.Unix(1533529977, 0)), dp.StartTimestamp())
+	assert.Equal(t, pdata.TimestampFromTime(time.Unix(1533529977, 0).Add(time.Minute)), dp.Timestamp())
+	assert.Equal(t, []float64{5.1, 30.0}, dp.ExplicitBounds())
+	assert.Equal(t, []uint64{10, 22, 0}, dp.BucketCounts())
+	assert.Equal(t, uint64(32), dp.Count())
+	assert.InDelta(t, 10*5.1+22*30.0, dp.Sum(), 1e-9)
+
+	assert.Equal(t, 2, dp.LabelsMap().Len())
+	source, _ := dp.LabelsMap().Get("source")
+	assert.Equal(t, "appServer15", source)
+	region, _ := dp.LabelsMap().Get("region")
+	assert.Equal(t, "us-west", region)
+}
+
+func Test_parseDistributionNoTimestamp(t *testing.T) {
+	md, err := parseDistribution(`!H #1 2 "quoted name" source=appServer15`)
+	require.NoError(t, err)
+
+	metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "quoted name", metric.Name())
+
+	dp := metric.Histogram().DataPoints().At(0)
+	start := dp.StartTimestamp().AsTime()
+	assert.Equal(t, start.Truncate(time.Hour), start)
+	assert.Equal(t, start.Add(time.Hour), dp.Timestamp().AsTime())
+}
+
+func Test_parseDistributionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{
+			name: "invalid_timestamp",
+			line: "!M abc #20 30.0 request.latency source=appServer15",
+		},
+		{
+			name: "no_centroids",
+			line: "!M 1533529977 request.latency source=appServer15",
+		},
+		{
+			name: "invalid_count",
+			line: "!M 1533529977 #x 30.0 request.latency source=appServer15",
+		},
+		{
+			name: "invalid_value",
+			line: "!M 1533529977 #20 y request.latency source=appServer15",
+		},
+		{
+			name: "invalid_tags",
+			line: "!M 1533529977 #20 30.0 request.latency source",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDistribution(tt.line)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -16,6 +16,7 @@ package wavefrontreceiver
 
 import (
 	"context"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -23,8 +24,6 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/protocol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/transport"
 )
 
@@ -40,7 +39,8 @@ func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithTraces(createTracesReceiver))
 }
 
 func createDefaultConfig() config.Receiver {
@@ -54,33 +54,53 @@ func createDefaultConfig() config.Receiver {
 }
 
 func createMetricsReceiver(
-	ctx context.Context,
+	_ context.Context,
 	params component.ReceiverCreateParams,
 	cfg config.Receiver,
 	consumer consumer.Metrics,
 ) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+
+	// Metric, distribution and span lines can all be sent to the same port,
+	// so a single receiver is shared by the metrics and traces pipelines.
+	r, err := getOrCreateReceiver(params, rCfg)
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterMetricsConsumer(consumer)
+	return r, nil
+}
 
+func createTracesReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg config.Receiver,
+	consumer consumer.Traces,
+) (component.TracesReceiver, error) {
 	rCfg := cfg.(*Config)
 
-	// Wavefront is very similar to Carbon: it is TCP based in which each received
-	// text line represents a single metric data point. They differ on the format
-	// of their textual representation.
-	//
-	// The Wavefront receiver leverages the Carbon receiver code by implementing
-	// a dedicated parser for its format.
-	carbonCfg := carbonreceiver.Config{
-		ReceiverSettings: rCfg.ReceiverSettings,
-		NetAddr: confignet.NetAddr{
-			Endpoint:  rCfg.Endpoint,
-			Transport: "tcp",
-		},
-		TCPIdleTimeout: rCfg.TCPIdleTimeout,
-		Parser: &protocol.Config{
-			Type: "plaintext", // TODO: update after other parsers are implemented for Carbon receiver.
-			Config: &WavefrontParser{
-				ExtractCollectdTags: rCfg.ExtractCollectdTags,
-			},
-		},
+	r, err := getOrCreateReceiver(params, rCfg)
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterTracesConsumer(consumer)
+	return r, nil
+}
+
+func getOrCreateReceiver(params component.ReceiverCreateParams, rCfg *Config) (*wavefrontReceiver, error) {
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+
+	r := receivers[rCfg]
+	if r == nil {
+		var err error
+		if r, err = newReceiver(params.Logger, rCfg); err != nil {
+			return nil, err
+		}
+		receivers[rCfg] = r
 	}
-	return carbonreceiver.New(params.Logger, carbonCfg, consumer)
+	return r, nil
 }
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*wavefrontReceiver{}
@@ -41,3 +41,28 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
+
+func TestCreateTracesReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0" // Endpoint is required, not going to be used here.
+
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	tReceiver, err := createTracesReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, tReceiver, "receiver creation failed")
+
+	// The metrics receiver created from the same config is the same instance.
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.Same(t, tReceiver, mReceiver)
+}
+
+func TestCreateReceiverEmptyEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ""
+
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	tReceiver, err := createTracesReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.Equal(t, errEmptyEndpoint, err)
+	assert.Nil(t, tReceiver)
+}
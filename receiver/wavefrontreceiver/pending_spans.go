@@ -0,0 +1,75 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"container/list"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// pendingSpans holds the spans of a connection waiting for their span logs,
+// in the order they were received.
+type pendingSpans struct {
+	max   int
+	order *list.List               // *pendingSpan, oldest first
+	spans map[string]*list.Element // by spanKey
+}
+
+type pendingSpan struct {
+	key string
+	td  pdata.Traces
+}
+
+func newPendingSpans(max int) *pendingSpans {
+	return &pendingSpans{
+		max:   max,
+		order: list.New(),
+		spans: make(map[string]*list.Element),
+	}
+}
+
+// add holds the given span until take is called with its key. It returns the
+// span that had to be evicted to make room for it, if any: either a span with
+// the same key or, when the maximum number of spans is reached, the oldest one.
+func (p *pendingSpans) add(key string, td pdata.Traces) (pdata.Traces, bool) {
+	evicted, ok := p.take(key)
+	if !ok && p.order.Len() >= p.max {
+		evicted, ok = p.take(p.order.Front().Value.(*pendingSpan).key)
+	}
+	p.spans[key] = p.order.PushBack(&pendingSpan{key: key, td: td})
+	return evicted, ok
+}
+
+// take removes and returns the span with the given key.
+func (p *pendingSpans) take(key string) (pdata.Traces, bool) {
+	e, ok := p.spans[key]
+	if !ok {
+		return pdata.Traces{}, false
+	}
+	delete(p.spans, key)
+	return p.order.Remove(e).(*pendingSpan).td, true
+}
+
+// drain removes and returns all the spans, oldest first.
+func (p *pendingSpans) drain() []pdata.Traces {
+	result := make([]pdata.Traces, 0, p.order.Len())
+	for e := p.order.Front(); e != nil; e = e.Next() {
+		result = append(result, e.Value.(*pendingSpan).td)
+	}
+	p.order.Init()
+	p.spans = make(map[string]*list.Element)
+	return result
+}
@@ -0,0 +1,64 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newPendingTestSpan(name string) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	return td
+}
+
+func Test_pendingSpans(t *testing.T) {
+	pending := newPendingSpans(2)
+
+	_, evicted := pending.add("a", newPendingTestSpan("a"))
+	assert.False(t, evicted)
+	_, evicted = pending.add("b", newPendingTestSpan("b"))
+	assert.False(t, evicted)
+
+	// the oldest span is evicted once the maximum is reached
+	td, evicted := pending.add("c", newPendingTestSpan("c"))
+	require.True(t, evicted)
+	assert.Equal(t, "a", firstSpan(td).Name())
+
+	// a span with the same key evicts the previous one
+	td, evicted = pending.add("b", newPendingTestSpan("b2"))
+	require.True(t, evicted)
+	assert.Equal(t, "b", firstSpan(td).Name())
+
+	td, ok := pending.take("c")
+	require.True(t, ok)
+	assert.Equal(t, "c", firstSpan(td).Name())
+	_, ok = pending.take("c")
+	assert.False(t, ok)
+
+	_, evicted = pending.add("d", newPendingTestSpan("d"))
+	assert.False(t, evicted)
+
+	var names []string
+	for _, td := range pending.drain() {
+		names = append(names, firstSpan(td).Name())
+	}
+	assert.Equal(t, []string{"b2", "d"}, names)
+	assert.Empty(t, pending.drain())
+}
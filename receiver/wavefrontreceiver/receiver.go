@@ -0,0 +1,319 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/translator/internaldata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/transport"
+)
+
+const (
+	transportTCP = "tcp"
+	format       = "wavefront"
+
+	// maxPendingSpans is the maximum number of spans, per connection, waiting
+	// for their span logs. Past it, the oldest span is sent without them.
+	maxPendingSpans = 1000
+)
+
+var (
+	errEmptyEndpoint = errors.New("empty endpoint")
+)
+
+// wavefrontReceiver implements component.MetricsReceiver and component.TracesReceiver
+// for the Wavefront proxy protocol. Each text line received over TCP is either a
+// metric, a histogram distribution, a span or the span logs of a span.
+type wavefrontReceiver struct {
+	logger *zap.Logger
+	config *Config
+	parser *WavefrontParser
+
+	metricsConsumer consumer.Metrics
+	tracesConsumer  consumer.Traces
+
+	ln          net.Listener
+	wg          sync.WaitGroup
+	connsMtx    sync.Mutex
+	conns       map[net.Conn]struct{}
+	idleTimeout time.Duration
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopping  chan struct{}
+}
+
+var _ component.MetricsReceiver = (*wavefrontReceiver)(nil)
+var _ component.TracesReceiver = (*wavefrontReceiver)(nil)
+
+func newReceiver(logger *zap.Logger, config *Config) (*wavefrontReceiver, error) {
+	if config.Endpoint == "" {
+		return nil, errEmptyEndpoint
+	}
+	if config.TCPIdleTimeout < 0 {
+		return nil, fmt.Errorf("invalid idle timeout: %v", config.TCPIdleTimeout)
+	}
+
+	idleTimeout := config.TCPIdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = transport.TCPIdleTimeoutDefault
+	}
+
+	return &wavefrontReceiver{
+		logger: logger,
+		config: config,
+		parser: &WavefrontParser{
+			ExtractCollectdTags: config.ExtractCollectdTags,
+		},
+		conns:       make(map[net.Conn]struct{}),
+		idleTimeout: idleTimeout,
+		stopping:    make(chan struct{}),
+	}, nil
+}
+
+// RegisterMetricsConsumer sets the consumer of the metrics and distributions.
+func (r *wavefrontReceiver) RegisterMetricsConsumer(mc consumer.Metrics) {
+	r.metricsConsumer = mc
+}
+
+// RegisterTracesConsumer sets the consumer of the spans.
+func (r *wavefrontReceiver) RegisterTracesConsumer(tc consumer.Traces) {
+	r.tracesConsumer = tc
+}
+
+// Start starts listening on the configured endpoint. The receiver is shared by
+// the metrics and traces pipelines so only the first call has any effect.
+func (r *wavefrontReceiver) Start(_ context.Context, host component.Host) error {
+	var err error
+	r.startOnce.Do(func() {
+		r.ln, err = net.Listen(transportTCP, r.config.Endpoint)
+		if err != nil {
+			return
+		}
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			if acceptErr := r.acceptConnections(); acceptErr != nil {
+				host.ReportFatalError(acceptErr)
+			}
+		}()
+	})
+	return err
+}
+
+// Shutdown stops accepting connections, closes the open ones and waits for
+// the data already received to be sent to the next consumers.
+func (r *wavefrontReceiver) Shutdown(context.Context) error {
+	var err error
+	r.stopOnce.Do(func() {
+		if r.ln == nil {
+			return
+		}
+		close(r.stopping)
+		err = r.ln.Close()
+
+		r.connsMtx.Lock()
+		for conn := range r.conns {
+			conn.Close()
+		}
+		r.connsMtx.Unlock()
+
+		r.wg.Wait()
+	})
+	return err
+}
+
+func (r *wavefrontReceiver) acceptConnections() error {
+	for {
+		conn, err := r.ln.Accept()
+		if err != nil {
+			select {
+			case <-r.stopping:
+				// The listener was closed by Shutdown.
+				return nil
+			default:
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				r.logger.Debug("Temporary error accepting connection", zap.Error(err))
+				continue
+			}
+			return err
+		}
+
+		r.connsMtx.Lock()
+		r.conns[conn] = struct{}{}
+		r.connsMtx.Unlock()
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.handleConnection(conn)
+
+			r.connsMtx.Lock()
+			delete(r.conns, conn)
+			r.connsMtx.Unlock()
+		}()
+	}
+}
+
+func (r *wavefrontReceiver) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	// Spans flagged with span logs are held until their span logs, which are
+	// sent right after the span on the same connection, are received.
+	pending := newPendingSpans(maxPendingSpans)
+	defer func() {
+		for _, td := range pending.drain() {
+			r.consumeTraces(td)
+		}
+	}()
+
+	reader := bufio.NewReader(conn)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(r.idleTimeout)); err != nil {
+			r.logger.Debug("Failed to set connection deadline", zap.Error(err))
+			return
+		}
+
+		// It is possible to have new data in bytes and err to be io.EOF.
+		bytes, err := reader.ReadBytes('\n')
+		line := strings.TrimSpace(string(bytes))
+		if line != "" {
+			if consumeErr := r.processLine(line, pending); consumeErr != nil {
+				// The protocol doesn't account for returning errors, close the
+				// connection to minimize the effect of a client constantly
+				// submitting data that can't be consumed.
+				return
+			}
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// processLine parses a single line and sends it to the next consumer. Only the
+// errors returned by the next consumer are returned, translation errors are
+// just logged.
+func (r *wavefrontReceiver) processLine(line string, pending *pendingSpans) error {
+	switch {
+	case isDistribution(line):
+		md, err := parseDistribution(line)
+		if err != nil {
+			r.logger.Debug("Wavefront translation error", zap.Error(err))
+			return nil
+		}
+		return r.consumeMetrics(md, 1)
+
+	case isSpanLogs(line):
+		return r.processSpanLogs(line, pending)
+
+	case isSpan(line):
+		td, hasSpanLogs, err := parseSpan(line)
+		if err != nil {
+			r.logger.Debug("Wavefront translation error", zap.Error(err))
+			return nil
+		}
+		if hasSpanLogs {
+			// The evicted span is sent without its span logs.
+			if evicted, ok := pending.add(spanKey(td), td); ok {
+				return r.consumeTraces(evicted)
+			}
+			return nil
+		}
+		return r.consumeTraces(td)
+
+	default:
+		metric, err := r.parser.Parse(line)
+		if err != nil {
+			r.logger.Debug("Wavefront translation error", zap.Error(err))
+			return nil
+		}
+		return r.consumeMetrics(internaldata.OCToMetrics(nil, nil, []*metricspb.Metric{metric}), 1)
+	}
+}
+
+func (r *wavefrontReceiver) processSpanLogs(line string, pending *pendingSpans) error {
+	sl, err := parseSpanLogs(line)
+	if err != nil {
+		r.logger.Debug("Wavefront translation error", zap.Error(err))
+		return nil
+	}
+
+	td, ok := pending.take(sl.key())
+	if !ok {
+		// The span logs may embed the span they belong to.
+		if sl.Span == "" {
+			r.logger.Debug("Dropping span logs of an unknown span", zap.String("spanId", sl.SpanID))
+			return nil
+		}
+		if td, _, err = parseSpan(sl.Span); err != nil {
+			r.logger.Debug("Wavefront translation error", zap.Error(err))
+			return nil
+		}
+	}
+
+	sl.appendEvents(firstSpan(td))
+	return r.consumeTraces(td)
+}
+
+func (r *wavefrontReceiver) consumeMetrics(md pdata.Metrics, numPoints int) error {
+	if r.metricsConsumer == nil {
+		r.logger.Debug("Dropping metric, no metrics pipeline is configured for the receiver")
+		return nil
+	}
+
+	ctx := obsreport.ReceiverContext(context.Background(), r.config.ID(), transportTCP)
+	ctx = obsreport.StartMetricsReceiveOp(ctx, r.config.ID(), transportTCP)
+	err := r.metricsConsumer.ConsumeMetrics(ctx, md)
+	obsreport.EndMetricsReceiveOp(ctx, format, numPoints, err)
+	if err != nil {
+		r.logger.Debug("Wavefront receiver failed to push metrics into pipeline", zap.Error(err))
+	}
+	return err
+}
+
+func (r *wavefrontReceiver) consumeTraces(td pdata.Traces) error {
+	if r.tracesConsumer == nil {
+		r.logger.Debug("Dropping span, no traces pipeline is configured for the receiver")
+		return nil
+	}
+
+	ctx := obsreport.ReceiverContext(context.Background(), r.config.ID(), transportTCP)
+	ctx = obsreport.StartTraceDataReceiveOp(ctx, r.config.ID(), transportTCP)
+	err := r.tracesConsumer.ConsumeTraces(ctx, td)
+	obsreport.EndTraceDataReceiveOp(ctx, format, td.SpanCount(), err)
+	if err != nil {
+		r.logger.Debug("Wavefront receiver failed to push spans into pipeline", zap.Error(err))
+	}
+	return err
+}
@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/testutil"
 	"go.opentelemetry.io/collector/translator/internaldata"
 	"go.uber.org/zap"
@@ -152,3 +153,81 @@ func Test_wavefrontreceiver_EndToEnd(t *testing.T) {
 		sink.Reset()
 	}
 }
+
+func Test_wavefrontreceiver_SpansAndDistributions(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.TCPIdleTimeout = time.Second
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	rCfg.Endpoint = addr
+	metricsSink := new(consumertest.MetricsSink)
+	tracesSink := new(consumertest.TracesSink)
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	mr, err := createMetricsReceiver(context.Background(), params, rCfg, metricsSink)
+	require.NoError(t, err)
+	tr, err := createTracesReceiver(context.Background(), params, rCfg, tracesSink)
+	require.NoError(t, err)
+	assert.Same(t, mr, tr)
+
+	require.NoError(t, mr.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, tr.Start(context.Background(), componenttest.NewNopHost()))
+	defer tr.Shutdown(context.Background())
+	defer mr.Shutdown(context.Background())
+
+	msg := "single.metric 1 1582231120 source=e2e\n" +
+		"!M 1533529977 #20 30.0 #10 5.1 request.latency source=e2e\n" +
+		"op source=e2e traceId=" + testTraceID + " spanId=0000000000000001 1552949776000 343\n" +
+		"op source=e2e traceId=" + testTraceID + " spanId=" + testSpanID + " _spanLogs=true 1552949776000 343\n" +
+		`{"traceId":"` + testTraceID + `","spanId":"` + testSpanID + `","logs":[{"timestamp":1552949776000123,"fields":{"event":"error"}}]}` + "\n" +
+		"invalid line\n"
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	_, err = fmt.Fprint(conn, msg)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	assert.Eventually(t, func() bool {
+		return metricsSink.MetricsCount() == 2 && tracesSink.SpansCount() == 2
+	}, 10*time.Second, 5*time.Millisecond)
+
+	metrics := metricsSink.AllMetrics()
+	require.Len(t, metrics, 2)
+	histogram := metrics[1].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "request.latency", histogram.Name())
+	assert.Equal(t, pdata.MetricDataTypeHistogram, histogram.DataType())
+
+	traces := tracesSink.AllTraces()
+	require.Len(t, traces, 2)
+	assert.Equal(t, "0000000000000001", firstSpan(traces[0]).SpanID().HexString())
+	withLogs := firstSpan(traces[1])
+	assert.Equal(t, "9eb6529269fb1459", withLogs.SpanID().HexString())
+	require.Equal(t, 1, withLogs.Events().Len())
+	assert.Equal(t, "error", withLogs.Events().At(0).Name())
+}
+
+func Test_wavefrontreceiver_PendingSpansFlushedOnClose(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.TCPIdleTimeout = time.Second
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	rCfg.Endpoint = addr
+	sink := new(consumertest.TracesSink)
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	rcvr, err := createTracesReceiver(context.Background(), params, rCfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	defer rcvr.Shutdown(context.Background())
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	_, err = fmt.Fprint(conn, "op source=e2e traceId="+testTraceID+" spanId="+testSpanID+" _spanLogs=true 1552949776000 343\n")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	assert.Eventually(t, func() bool {
+		return sink.SpansCount() == 1
+	}, 10*time.Second, 5*time.Millisecond)
+	assert.Equal(t, 0, firstSpan(sink.AllTraces()[0]).Events().Len())
+}
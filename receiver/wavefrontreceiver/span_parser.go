@@ -0,0 +1,298 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// Tags of a Wavefront span with a special meaning, see
+// https://docs.wavefront.com/trace_data_details.html#span-tags.
+const (
+	tagTraceID     = "traceId"
+	tagSpanID      = "spanId"
+	tagParent      = "parent"
+	tagFollowsFrom = "followsFrom"
+	tagSource      = "source"
+	tagService     = "service"
+	tagSpanKind    = "span.kind"
+	tagError       = "error"
+	tagSpanLogs    = "_spanLogs"
+
+	// Start times below this value are assumed to be in seconds rather than
+	// in milliseconds, as sent by some older clients.
+	maxStartSeconds = 1e11
+)
+
+// isSpan returns true if the line is in the Wavefront span format:
+//
+// 	"<operationName> source=<source> traceId=<id> spanId=<id> [parent=<id>] [spanTags] <startMillis> <durationMillis>"
+//
+// Unlike a metric, the name of a span isn't followed by a numeric value and the
+// line ends with the start time and the duration of the span.
+func isSpan(line string) bool {
+	_, rest := splitName(line)
+	if value := strings.SplitN(rest, " ", 2)[0]; value == "" || isNumber(value) {
+		return false
+	}
+	rest, duration := splitLastField(rest)
+	_, start := splitLastField(rest)
+	return isInteger(start) && isInteger(duration)
+}
+
+// isSpanLogs returns true if the line holds the JSON encoded span logs of a span.
+func isSpanLogs(line string) bool {
+	return strings.HasPrefix(line, "{")
+}
+
+// parseSpan converts a line in the Wavefront span format, see
+// https://docs.wavefront.com/trace_data_details.html#wavefront-span-format,
+// into a pdata.Traces holding a single span. The returned bool reports whether
+// the span is followed by span logs.
+func parseSpan(line string) (pdata.Traces, bool, error) {
+	name, rest := splitName(line)
+	if name == "" {
+		return pdata.Traces{}, false, fmt.Errorf("empty name for wavefront span [%s]", line)
+	}
+
+	// The start time and the duration are the last two fields of the line.
+	rest, durationStr := splitLastField(rest)
+	tags, startStr := splitLastField(rest)
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil {
+		return pdata.Traces{}, false, fmt.Errorf("invalid start time for wavefront span [%s]: %v", line, err)
+	}
+	duration, err := strconv.ParseInt(durationStr, 10, 64)
+	if err != nil {
+		return pdata.Traces{}, false, fmt.Errorf("invalid duration for wavefront span [%s]: %v", line, err)
+	}
+
+	keys, values, err := buildLabels(tags)
+	if err != nil {
+		return pdata.Traces{}, false, fmt.Errorf("invalid wavefront span [%s]: %v", line, err)
+	}
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName(name)
+	span.SetKind(pdata.SpanKindUNSPECIFIED)
+
+	startTime := time.Unix(0, start*int64(time.Millisecond))
+	if start < maxStartSeconds {
+		startTime = time.Unix(start, 0)
+	}
+	span.SetStartTimestamp(pdata.TimestampFromTime(startTime))
+	span.SetEndTimestamp(pdata.TimestampFromTime(startTime.Add(time.Duration(duration) * time.Millisecond)))
+
+	var hasTraceID, hasSpanID, hasParent, hasSpanLogs bool
+	for i, key := range keys {
+		value := values[i].Value
+		switch key.Key {
+		case tagTraceID:
+			traceID, err := parseTraceID(value)
+			if err != nil {
+				return pdata.Traces{}, false, fmt.Errorf("invalid wavefront span [%s]: %v", line, err)
+			}
+			span.SetTraceID(traceID)
+			hasTraceID = true
+		case tagSpanID:
+			spanID, err := parseSpanID(value)
+			if err != nil {
+				return pdata.Traces{}, false, fmt.Errorf("invalid wavefront span [%s]: %v", line, err)
+			}
+			span.SetSpanID(spanID)
+			hasSpanID = true
+		case tagParent, tagFollowsFrom:
+			spanID, err := parseSpanID(value)
+			if err != nil {
+				return pdata.Traces{}, false, fmt.Errorf("invalid wavefront span [%s]: %v", line, err)
+			}
+			if key.Key == tagParent && !hasParent {
+				span.SetParentSpanID(spanID)
+				hasParent = true
+				continue
+			}
+			// Additional parents and followsFrom references become links
+			// within the same trace, the trace id is set once it is known.
+			span.Links().AppendEmpty().SetSpanID(spanID)
+		case tagSource:
+			rs.Resource().Attributes().UpsertString(conventions.AttributeHostName, value)
+		case tagService:
+			rs.Resource().Attributes().UpsertString(conventions.AttributeServiceName, value)
+		case tagSpanKind:
+			span.SetKind(spanKind(value))
+		case tagError:
+			if value == "true" {
+				span.Status().SetCode(pdata.StatusCodeError)
+			} else {
+				span.Attributes().UpsertString(key.Key, value)
+			}
+		case tagSpanLogs:
+			hasSpanLogs = value == "true"
+		default:
+			span.Attributes().UpsertString(key.Key, value)
+		}
+	}
+
+	if !hasTraceID || !hasSpanID {
+		return pdata.Traces{}, false, fmt.Errorf("wavefront span without traceId or spanId [%s]", line)
+	}
+	for i := 0; i < span.Links().Len(); i++ {
+		span.Links().At(i).SetTraceID(span.TraceID())
+	}
+
+	return td, hasSpanLogs, nil
+}
+
+// spanLogs is the JSON representation of the span logs of a span, see
+// https://docs.wavefront.com/trace_data_details.html#span-logs.
+type spanLogs struct {
+	TraceID string    `json:"traceId"`
+	SpanID  string    `json:"spanId"`
+	Logs    []spanLog `json:"logs"`
+	// Span optionally holds the span the logs belong to, in the Wavefront span format.
+	Span string `json:"span"`
+}
+
+type spanLog struct {
+	// Timestamp is in microseconds.
+	Timestamp int64             `json:"timestamp"`
+	Fields    map[string]string `json:"fields"`
+}
+
+func parseSpanLogs(line string) (*spanLogs, error) {
+	var sl spanLogs
+	if err := json.Unmarshal([]byte(line), &sl); err != nil {
+		return nil, fmt.Errorf("invalid wavefront span logs [%s]: %v", line, err)
+	}
+	if _, err := parseSpanID(sl.SpanID); err != nil {
+		return nil, fmt.Errorf("invalid wavefront span logs [%s]: %v", line, err)
+	}
+	return &sl, nil
+}
+
+// key returns the key of the span the logs belong to, see spanKey.
+func (sl *spanLogs) key() string {
+	spanID, _ := parseSpanID(sl.SpanID)
+	return spanID.HexString()
+}
+
+// appendEvents adds each log as an event of the span. The "event" field, if any,
+// is used as the name of the event.
+func (sl *spanLogs) appendEvents(span pdata.Span) {
+	for _, log := range sl.Logs {
+		event := span.Events().AppendEmpty()
+		event.SetTimestamp(pdata.TimestampFromTime(time.Unix(0, log.Timestamp*int64(time.Microsecond))))
+		for k, v := range log.Fields {
+			if k == "event" {
+				event.SetName(v)
+				continue
+			}
+			event.Attributes().UpsertString(k, v)
+		}
+	}
+}
+
+// spanKey returns the key used to match a span with its span logs.
+func spanKey(td pdata.Traces) string {
+	return firstSpan(td).SpanID().HexString()
+}
+
+func firstSpan(td pdata.Traces) pdata.Span {
+	return td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+}
+
+// splitName returns the, optionally double-quoted, name at the start of the
+// line and the rest of the line.
+func splitName(line string) (string, string) {
+	if strings.HasPrefix(line, `"`) {
+		for i := 1; i < len(line); i++ {
+			if line[i] == '"' && line[i-1] != '\\' {
+				return escapedCharReplacer.Replace(line[1:i]), strings.TrimLeft(line[i+1:], " ")
+			}
+		}
+	}
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], strings.TrimLeft(parts[1], " ")
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+func isInteger(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
+
+// splitLastField splits the last space separated field from the given string.
+func splitLastField(s string) (string, string) {
+	s = strings.TrimRight(s, " ")
+	i := strings.LastIndexByte(s, ' ')
+	return s[:i+1], s[i+1:]
+}
+
+// parseTraceID parses a trace id in the UUID format used by Wavefront.
+func parseTraceID(s string) (pdata.TraceID, error) {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(b) != 16 {
+		return pdata.InvalidTraceID(), fmt.Errorf("invalid trace id %q", s)
+	}
+	var id [16]byte
+	copy(id[:], b)
+	return pdata.NewTraceID(id), nil
+}
+
+// parseSpanID parses a span id in the UUID format used by Wavefront, of which
+// only the lower 8 bytes are kept. Plain 8 bytes hex encoded ids are accepted too.
+func parseSpanID(s string) (pdata.SpanID, error) {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || (len(b) != 16 && len(b) != 8) {
+		return pdata.NewSpanID([8]byte{}), fmt.Errorf("invalid span id %q", s)
+	}
+	var id [8]byte
+	copy(id[:], b[len(b)-8:])
+	return pdata.NewSpanID(id), nil
+}
+
+func spanKind(kind string) pdata.SpanKind {
+	switch tracetranslator.OpenTracingSpanKind(strings.ToLower(kind)) {
+	case tracetranslator.OpenTracingSpanKindClient:
+		return pdata.SpanKindCLIENT
+	case tracetranslator.OpenTracingSpanKindServer:
+		return pdata.SpanKindSERVER
+	case tracetranslator.OpenTracingSpanKindProducer:
+		return pdata.SpanKindPRODUCER
+	case tracetranslator.OpenTracingSpanKindConsumer:
+		return pdata.SpanKindCONSUMER
+	case tracetranslator.OpenTracingSpanKindInternal:
+		return pdata.SpanKindINTERNAL
+	}
+	return pdata.SpanKindUNSPECIFIED
+}
@@ -0,0 +1,161 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wavefrontreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	testTraceID = "7b3bf470-9456-11e8-9eb6-529269fb1459"
+	testSpanID  = "0313bafe-9457-11e8-9eb6-529269fb1459"
+	testParent  = "2f64e538-9457-11e8-9eb6-529269fb1459"
+)
+
+func Test_isSpan(t *testing.T) {
+	assert.True(t, isSpan("getAllUsers source=localhost traceId="+testTraceID+" spanId="+testSpanID+" 1552949776000 343"))
+	assert.False(t, isSpan("metric 1 1582231120 source=localhost"))
+	assert.False(t, isSpan("metric 1 1582231120 source=localhost traceId="+testTraceID))
+	assert.False(t, isSpan("metric 1 1582231120 source=localhost traceId="+testTraceID+" spanId="+testSpanID))
+	assert.False(t, isSpan("metric 1552949776000 343"))
+	assert.False(t, isSpan("getAllUsers source=localhost traceId="+testTraceID+" spanId="+testSpanID))
+	assert.True(t, isSpan(`"get all users" source=localhost http.url="/users?a b" 1552949776000 343`))
+}
+
+func Test_parseSpan(t *testing.T) {
+	line := `"get all users" source=localhost traceId=` + testTraceID + ` spanId=` + testSpanID +
+		` parent=` + testParent + ` followsFrom=0000000000000000000000000000abcd service=users application=Wavefront` +
+		` span.kind=server error=true http.method=GET http.url="/users?a b" 1552949776000 343`
+
+	td, hasSpanLogs, err := parseSpan(line)
+	require.NoError(t, err)
+	assert.False(t, hasSpanLogs)
+	require.Equal(t, 1, td.SpanCount())
+
+	resource := td.ResourceSpans().At(0).Resource()
+	hostName, _ := resource.Attributes().Get(conventions.AttributeHostName)
+	assert.Equal(t, "localhost", hostName.StringVal())
+	serviceName, _ := resource.Attributes().Get(conventions.AttributeServiceName)
+	assert.Equal(t, "users", serviceName.StringVal())
+
+	span := firstSpan(td)
+	assert.Equal(t, "get all users", span.Name())
+	assert.Equal(t, "7b3bf470945611e89eb6529269fb1459", span.TraceID().HexString())
+	assert.Equal(t, "9eb6529269fb1459", span.SpanID().HexString())
+	assert.Equal(t, "9eb6529269fb1459", span.ParentSpanID().HexString())
+	assert.Equal(t, pdata.SpanKindSERVER, span.Kind())
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+
+	start := time.Unix(0, 1552949776000*int64(time.Millisecond))
+	assert.Equal(t, pdata.TimestampFromTime(start), span.StartTimestamp())
+	assert.Equal(t, pdata.TimestampFromTime(start.Add(343*time.Millisecond)), span.EndTimestamp())
+
+	require.Equal(t, 1, span.Links().Len())
+	assert.Equal(t, span.TraceID(), span.Links().At(0).TraceID())
+	assert.Equal(t, "000000000000abcd", span.Links().At(0).SpanID().HexString())
+
+	assert.Equal(t, 3, span.Attributes().Len())
+	application, _ := span.Attributes().Get("application")
+	assert.Equal(t, "Wavefront", application.StringVal())
+	method, _ := span.Attributes().Get("http.method")
+	assert.Equal(t, "GET", method.StringVal())
+	url, _ := span.Attributes().Get("http.url")
+	assert.Equal(t, "/users?a b", url.StringVal())
+}
+
+func Test_parseSpanStartInSeconds(t *testing.T) {
+	td, hasSpanLogs, err := parseSpan("op source=localhost traceId=" + testTraceID + " spanId=" + testSpanID + " _spanLogs=true 1533529977 343500")
+	require.NoError(t, err)
+	assert.True(t, hasSpanLogs)
+
+	span := firstSpan(td)
+	assert.Equal(t, pdata.TimestampFromTime(time.Unix(1533529977, 0)), span.StartTimestamp())
+	assert.Equal(t, pdata.TimestampFromTime(time.Unix(1533529977, 0).Add(343500*time.Millisecond)), span.EndTimestamp())
+	assert.Equal(t, pdata.SpanKindUNSPECIFIED, span.Kind())
+	assert.Equal(t, 0, span.Attributes().Len())
+}
+
+func Test_parseSpanErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{
+			name: "missing_duration",
+			line: "op source=localhost traceId=" + testTraceID + " spanId=" + testSpanID + " 1552949776000",
+		},
+		{
+			name: "invalid_start",
+			line: "op source=localhost traceId=" + testTraceID + " spanId=" + testSpanID + " abc 343",
+		},
+		{
+			name: "invalid_trace_id",
+			line: "op source=localhost traceId=xyz spanId=" + testSpanID + " 1552949776000 343",
+		},
+		{
+			name: "invalid_span_id",
+			line: "op source=localhost traceId=" + testTraceID + " spanId=123 1552949776000 343",
+		},
+		{
+			name: "missing_span_id",
+			line: "op source=localhost traceId=" + testTraceID + " tag=spanId 1552949776000 343",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseSpan(tt.line)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_parseSpanLogs(t *testing.T) {
+	line := `{"traceId":"` + testTraceID + `","spanId":"` + testSpanID + `","logs":[` +
+		`{"timestamp":1552949776000123,"fields":{"event":"error","error.kind":"exception"}},` +
+		`{"timestamp":1552949776000456,"fields":{"message":"retrying"}}]}`
+	sl, err := parseSpanLogs(line)
+	require.NoError(t, err)
+	assert.Equal(t, "9eb6529269fb1459", sl.key())
+
+	td, _, err := parseSpan("op source=localhost traceId=" + testTraceID + " spanId=" + testSpanID + " 1552949776000 343")
+	require.NoError(t, err)
+	assert.Equal(t, spanKey(td), sl.key())
+
+	span := firstSpan(td)
+	sl.appendEvents(span)
+	require.Equal(t, 2, span.Events().Len())
+
+	event := span.Events().At(0)
+	assert.Equal(t, "error", event.Name())
+	assert.Equal(t, pdata.TimestampFromTime(time.Unix(0, 1552949776000123*int64(time.Microsecond))), event.Timestamp())
+	kind, _ := event.Attributes().Get("error.kind")
+	assert.Equal(t, "exception", kind.StringVal())
+
+	event = span.Events().At(1)
+	assert.Equal(t, "", event.Name())
+	message, _ := event.Attributes().Get("message")
+	assert.Equal(t, "retrying", message.StringVal())
+
+	_, err = parseSpanLogs(`{"traceId":"` + testTraceID + `"}`)
+	assert.Error(t, err)
+	_, err = parseSpanLogs(`{"traceId":`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"flag"
+	"fmt"
+	"time"
+)
+
+// Config describes the settings shared by the metrics and logs generators.
+type Config struct {
+	WorkerCount   int
+	NumBatches    int
+	BatchSize     int
+	Rate          float64
+	RampUp        time.Duration
+	TotalDuration time.Duration
+	ServiceName   string
+
+	// OTLP config
+	Endpoint string
+	Insecure bool
+	UseHTTP  bool
+	Timeout  time.Duration
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *flag.FlagSet) {
+	fs.IntVar(&c.WorkerCount, "workers", 1, "Number of workers (goroutines) to run")
+	fs.IntVar(&c.NumBatches, "batches", 1, "Number of batches to send in each worker (ignored if duration is provided)")
+	fs.IntVar(&c.BatchSize, "batch-size", 100, "Number of items (data points or log records) in each batch")
+	fs.Float64Var(&c.Rate, "rate", 0, "Approximately how many items per second each worker should generate. Zero means no throttling.")
+	fs.DurationVar(&c.RampUp, "ramp-up", 0, "Time over which the rate increases linearly from zero to its target. Zero means a steady rate.")
+	fs.DurationVar(&c.TotalDuration, "duration", 0, "For how long to run the test")
+	fs.StringVar(&c.ServiceName, "service", "tracegen", "Service name to use")
+
+	fs.StringVar(&c.Endpoint, "otlp-endpoint", "localhost:55680", "Target to which the exporter is going to send metrics or logs")
+	fs.BoolVar(&c.Insecure, "otlp-insecure", false, "Whether to enable client transport security for the exporter's grpc or http connection")
+	fs.BoolVar(&c.UseHTTP, "otlp-http", false, "Whether to use OTLP over HTTP instead of gRPC")
+	fs.DurationVar(&c.Timeout, "otlp-timeout", 10*time.Second, "Timeout of the connection to the endpoint and of each export request. Zero means no timeout.")
+}
+
+// Validate checks the config and resets the number of batches when a duration is set.
+func (c *Config) Validate() error {
+	if c.TotalDuration > 0 {
+		c.NumBatches = 0
+	} else if c.NumBatches <= 0 {
+		return fmt.Errorf("either `batches` or `duration` must be greater than 0")
+	}
+	if c.WorkerCount <= 0 {
+		return fmt.Errorf("`workers` must be greater than 0")
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("`batch-size` must be greater than 0")
+	}
+	if c.Rate < 0 {
+		return fmt.Errorf("`rate` cannot be negative")
+	}
+	if c.RampUp < 0 {
+		return fmt.Errorf("`ramp-up` cannot be negative")
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("`otlp-timeout` cannot be negative")
+	}
+	return nil
+}
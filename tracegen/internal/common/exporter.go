@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/proto"
+)
+
+// Exporter sends OTLP requests to the configured endpoint.
+type Exporter interface {
+	ExportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error
+	ExportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error
+	Shutdown() error
+}
+
+// NewExporter creates an OTLP gRPC or HTTP exporter from the config.
+func NewExporter(c *Config) (Exporter, error) {
+	if c.UseHTTP {
+		return newHTTPExporter(c), nil
+	}
+	return newGRPCExporter(c)
+}
+
+// withTimeout returns a context cancelled after the timeout, if any.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+type grpcExporter struct {
+	conn    *grpc.ClientConn
+	metrics colmetricspb.MetricsServiceClient
+	logs    collogspb.LogsServiceClient
+	timeout time.Duration
+}
+
+func newGRPCExporter(c *Config) (*grpcExporter, error) {
+	opts := []grpc.DialOption{grpc.WithBlock()}
+	if c.Insecure {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+	}
+
+	ctx, cancel := withTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, c.Endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", c.Endpoint, err)
+	}
+	return &grpcExporter{
+		conn:    conn,
+		metrics: colmetricspb.NewMetricsServiceClient(conn),
+		logs:    collogspb.NewLogsServiceClient(conn),
+		timeout: c.Timeout,
+	}, nil
+}
+
+func (e *grpcExporter) ExportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	ctx, cancel := withTimeout(ctx, e.timeout)
+	defer cancel()
+	_, err := e.metrics.Export(ctx, req)
+	return err
+}
+
+func (e *grpcExporter) ExportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	ctx, cancel := withTimeout(ctx, e.timeout)
+	defer cancel()
+	_, err := e.logs.Export(ctx, req)
+	return err
+}
+
+func (e *grpcExporter) Shutdown() error {
+	return e.conn.Close()
+}
+
+type httpExporter struct {
+	client  *http.Client
+	baseURL string
+}
+
+func newHTTPExporter(c *Config) *httpExporter {
+	scheme := "https"
+	if c.Insecure {
+		scheme = "http"
+	}
+	return &httpExporter{
+		// The client timeout bounds each request, including reading the response.
+		client:  &http.Client{Timeout: c.Timeout},
+		baseURL: fmt.Sprintf("%s://%s", scheme, c.Endpoint),
+	}
+}
+
+func (e *httpExporter) ExportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	return e.post(ctx, "/v1/metrics", req)
+}
+
+func (e *httpExporter) ExportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	return e.post(ctx, "/v1/logs", req)
+}
+
+func (e *httpExporter) post(ctx context.Context, path string, msg proto.Message) error {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s%s responded with HTTP status %d", e.baseURL, path, resp.StatusCode)
+	}
+	return nil
+}
+
+func (e *httpExporter) Shutdown() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
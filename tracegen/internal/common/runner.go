@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// pollInterval is how often a throttled worker checks whether it can send its next batch.
+const pollInterval = 10 * time.Millisecond
+
+// SendBatch generates a batch of the given number of items and exports it.
+type SendBatch func(ctx context.Context, size int) error
+
+// Summary counts what was sent by a run.
+type Summary struct {
+	Batches       int64
+	Items         int64
+	FailedBatches int64
+	FailedItems   int64
+}
+
+func (s *Summary) String() string {
+	return fmt.Sprintf("batches: %d (failed: %d), items: %d (failed: %d)",
+		s.Batches, s.FailedBatches, s.Items, s.FailedItems)
+}
+
+// Run starts the configured number of workers, each sending batches created by its own
+// SendBatch, until the number of batches or the duration is reached.
+func Run(c *Config, logger *zap.Logger, newSendBatch func(worker int) SendBatch) (*Summary, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.Rate == 0 {
+		logger.Info("generation isn't being throttled")
+	} else {
+		logger.Info("generation is limited", zap.Float64("per-second", c.Rate), zap.Duration("ramp-up", c.RampUp))
+	}
+
+	summary := &Summary{}
+	wg := sync.WaitGroup{}
+	var running uint32 = 1
+	start := time.Now()
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := worker{
+			numBatches: c.NumBatches,
+			batchSize:  c.BatchSize,
+			pacer:      newPacer(c.Rate, c.RampUp, start),
+			sendBatch:  newSendBatch(i),
+			running:    &running,
+			summary:    summary,
+			wg:         &wg,
+			logger:     logger.With(zap.Int("worker", i)),
+		}
+
+		go w.run()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		atomic.StoreUint32(&running, 0)
+	}
+	wg.Wait()
+	return summary, nil
+}
+
+type worker struct {
+	numBatches int    // how many batches the worker has to send (only when duration==0)
+	batchSize  int    // number of items in each batch
+	pacer      *pacer // throttles the items sent
+	sendBatch  SendBatch
+	running    *uint32 // pointer to shared flag that indicates it's time to stop the test
+	summary    *Summary
+	wg         *sync.WaitGroup // notify when done
+	logger     *zap.Logger
+}
+
+func (w worker) run() {
+	defer w.wg.Done()
+	isRunning := func() bool { return atomic.LoadUint32(w.running) == 1 }
+
+	var i int
+	for isRunning() {
+		if !w.pacer.wait(w.batchSize, isRunning) {
+			break
+		}
+
+		atomic.AddInt64(&w.summary.Batches, 1)
+		atomic.AddInt64(&w.summary.Items, int64(w.batchSize))
+		if err := w.sendBatch(context.Background(), w.batchSize); err != nil {
+			atomic.AddInt64(&w.summary.FailedBatches, 1)
+			atomic.AddInt64(&w.summary.FailedItems, int64(w.batchSize))
+			w.logger.Debug("failed to send batch", zap.Error(err))
+		}
+
+		i++
+		if w.numBatches != 0 && i >= w.numBatches {
+			break
+		}
+	}
+	w.logger.Info("batches sent", zap.Int("batches", i))
+}
+
+// pacer throttles a worker to a target rate of items per second, increasing
+// linearly from zero to the target over the ramp-up duration.
+type pacer struct {
+	target float64
+	rampUp time.Duration
+	start  time.Time
+	last   time.Time
+	tokens float64
+}
+
+func newPacer(target float64, rampUp time.Duration, start time.Time) *pacer {
+	return &pacer{
+		target: target,
+		rampUp: rampUp,
+		start:  start,
+		last:   start,
+	}
+}
+
+// rateAt returns the rate of items per second at the given time.
+func (p *pacer) rateAt(t time.Time) float64 {
+	elapsed := t.Sub(p.start)
+	if p.rampUp <= 0 || elapsed >= p.rampUp {
+		return p.target
+	}
+	return p.target * math.Max(float64(elapsed), 0) / float64(p.rampUp)
+}
+
+// wait blocks until n items can be sent, returning false if the run stopped meanwhile.
+func (p *pacer) wait(n int, running func() bool) bool {
+	if p.target == 0 {
+		return true
+	}
+	for {
+		now := time.Now()
+		// Integrate the (possibly ramping) rate since the last update.
+		p.tokens += (p.rateAt(p.last) + p.rateAt(now)) / 2 * now.Sub(p.last).Seconds()
+		p.last = now
+		if p.tokens >= float64(n) {
+			p.tokens -= float64(n)
+			return true
+		}
+		if !running() {
+			return false
+		}
+		time.Sleep(pollInterval)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFixedNumberOfBatches(t *testing.T) {
+	var items int64
+	cfg := &Config{
+		NumBatches:  3,
+		BatchSize:   10,
+		WorkerCount: 2,
+	}
+
+	summary, err := Run(cfg, zap.NewNop(), func(int) SendBatch {
+		return func(_ context.Context, size int) error {
+			atomic.AddInt64(&items, int64(size))
+			return nil
+		}
+	})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 60, items)
+	assert.Equal(t, &Summary{Batches: 6, Items: 60}, summary)
+}
+
+func TestFailedBatches(t *testing.T) {
+	cfg := &Config{
+		NumBatches:  2,
+		BatchSize:   5,
+		WorkerCount: 1,
+	}
+
+	summary, err := Run(cfg, zap.NewNop(), func(int) SendBatch {
+		return func(context.Context, int) error {
+			return errors.New("export failed")
+		}
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &Summary{Batches: 2, Items: 10, FailedBatches: 2, FailedItems: 10}, summary)
+}
+
+func TestRateOfItems(t *testing.T) {
+	cfg := &Config{
+		Rate:          100,
+		BatchSize:     10,
+		TotalDuration: time.Second / 2,
+		WorkerCount:   1,
+	}
+
+	summary, err := Run(cfg, zap.NewNop(), func(int) SendBatch {
+		return func(context.Context, int) error { return nil }
+	})
+	require.NoError(t, err)
+
+	// the acceptable number of items for the rate of 100/sec for half a second
+	assert.True(t, summary.Items >= 30, "there should have been at least 30 items, had %d", summary.Items)
+	assert.True(t, summary.Items <= 70, "there should have been at most 70 items, had %d", summary.Items)
+}
+
+func TestPacerRampUp(t *testing.T) {
+	start := time.Now()
+	p := newPacer(100, 10*time.Second, start)
+
+	assert.Equal(t, 0.0, p.rateAt(start))
+	assert.InDelta(t, 50, p.rateAt(start.Add(5*time.Second)), 0.001)
+	assert.Equal(t, 100.0, p.rateAt(start.Add(10*time.Second)))
+	assert.Equal(t, 100.0, p.rateAt(start.Add(time.Minute)))
+
+	steady := newPacer(100, 0, start)
+	assert.Equal(t, 100.0, steady.rateAt(start))
+}
+
+func TestPacerStops(t *testing.T) {
+	p := newPacer(1, 0, time.Now())
+	assert.False(t, p.wait(1000, func() bool { return false }))
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := &Config{WorkerCount: 1, BatchSize: 1}
+	assert.EqualError(t, cfg.Validate(), "either `batches` or `duration` must be greater than 0")
+
+	cfg = &Config{WorkerCount: 1, BatchSize: 1, NumBatches: 1, TotalDuration: time.Second}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, 0, cfg.NumBatches)
+
+	cfg = &Config{WorkerCount: 1, NumBatches: 1}
+	assert.EqualError(t, cfg.Validate(), "`batch-size` must be greater than 0")
+
+	cfg = &Config{WorkerCount: 1, BatchSize: 1, NumBatches: 1, Timeout: -time.Second}
+	assert.EqualError(t, cfg.Validate(), "`otlp-timeout` cannot be negative")
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsgen
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/common"
+)
+
+// Config describes the logs to generate.
+type Config struct {
+	common.Config
+	BodySize   int
+	Severities string
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *flag.FlagSet) {
+	c.Config.Flags(fs)
+	fs.IntVar(&c.BodySize, "body-size", 100, "Size in bytes of the body of each log record")
+	fs.StringVar(&c.Severities, "severities", "INFO=1", "Comma separated severity=weight pairs, e.g. INFO=80,WARN=15,ERROR=5")
+}
+
+// severityWeight is the relative frequency of a severity.
+type severityWeight struct {
+	text   string
+	number logspb.SeverityNumber
+	weight int
+}
+
+var severityNumbers = map[string]logspb.SeverityNumber{
+	"TRACE": logspb.SeverityNumber_SEVERITY_NUMBER_TRACE,
+	"DEBUG": logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG,
+	"INFO":  logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+	"WARN":  logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+	"ERROR": logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+	"FATAL": logspb.SeverityNumber_SEVERITY_NUMBER_FATAL,
+}
+
+// parseSeverities parses the severity mix flag.
+func parseSeverities(s string) ([]severityWeight, error) {
+	var weights []severityWeight
+	for _, pair := range strings.Split(s, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid severity %q, expected <severity>=<weight>", pair)
+		}
+		text := strings.ToUpper(parts[0])
+		number, ok := severityNumbers[text]
+		if !ok {
+			return nil, fmt.Errorf("unknown severity %q", parts[0])
+		}
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q of severity %q", parts[1], parts[0])
+		}
+		weights = append(weights, severityWeight{text: text, number: number, weight: weight})
+	}
+
+	total := 0
+	for _, w := range weights {
+		total += w.weight
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("at least one severity must have a weight greater than 0")
+	}
+	return weights, nil
+}
+
+// Run generates logs and exports them with the given exporter.
+func Run(c *Config, exp common.Exporter, logger *zap.Logger) (*common.Summary, error) {
+	if c.BodySize < 0 {
+		return nil, fmt.Errorf("`body-size` cannot be negative")
+	}
+	severities, err := parseSeverities(c.Severities)
+	if err != nil {
+		return nil, err
+	}
+	return common.Run(&c.Config, logger, func(worker int) common.SendBatch {
+		return newGenerator(c, severities, exp, worker).sendBatch
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsgen
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/common"
+)
+
+const bodyAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 "
+
+// generator creates the batches of a single worker.
+type generator struct {
+	exp         common.Exporter
+	rand        *rand.Rand
+	resource    *resourcepb.Resource
+	workerAttr  *commonpb.KeyValue
+	bodySize    int
+	severities  []severityWeight
+	totalWeight int
+	sequence    int64
+}
+
+func newGenerator(c *Config, severities []severityWeight, exp common.Exporter, worker int) *generator {
+	g := &generator{
+		exp:  exp,
+		rand: rand.New(rand.NewSource(time.Now().UnixNano() + int64(worker))),
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{stringKeyValue("service.name", c.ServiceName)},
+		},
+		workerAttr: stringKeyValue("worker", strconv.Itoa(worker)),
+		bodySize:   c.BodySize,
+		severities: severities,
+	}
+	for _, s := range severities {
+		g.totalWeight += s.weight
+	}
+	return g
+}
+
+func (g *generator) sendBatch(ctx context.Context, size int) error {
+	return g.exp.ExportLogs(ctx, g.batch(time.Now(), size))
+}
+
+// batch returns a request with size log records.
+func (g *generator) batch(now time.Time, size int) *collogspb.ExportLogsServiceRequest {
+	records := make([]*logspb.LogRecord, size)
+	for i := range records {
+		severity := g.severity()
+		g.sequence++
+		records[i] = &logspb.LogRecord{
+			TimeUnixNano:   uint64(now.UnixNano()),
+			SeverityNumber: severity.number,
+			SeverityText:   severity.text,
+			Body:           &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: g.body()}},
+			Attributes: []*commonpb.KeyValue{
+				g.workerAttr,
+				{Key: "sequence", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: g.sequence}}},
+			},
+		}
+	}
+
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: g.resource,
+			InstrumentationLibraryLogs: []*logspb.InstrumentationLibraryLogs{{
+				InstrumentationLibrary: &commonpb.InstrumentationLibrary{Name: "tracegen"},
+				Logs:                   records,
+			}},
+		}},
+	}
+}
+
+// severity picks a severity according to the configured weights.
+func (g *generator) severity() severityWeight {
+	n := g.rand.Intn(g.totalWeight)
+	for _, s := range g.severities {
+		if n < s.weight {
+			return s
+		}
+		n -= s.weight
+	}
+	return g.severities[len(g.severities)-1]
+}
+
+// body returns a random body of the configured size.
+func (g *generator) body() string {
+	b := make([]byte, g.bodySize)
+	for i := range b {
+		b[i] = bodyAlphabet[g.rand.Intn(len(bodyAlphabet))]
+	}
+	return string(b)
+}
+
+func stringKeyValue(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
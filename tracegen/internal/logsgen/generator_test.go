@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsgen
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/common"
+)
+
+type mockExporter struct {
+	mu   sync.Mutex
+	logs []*collogspb.ExportLogsServiceRequest
+}
+
+var _ common.Exporter = (*mockExporter)(nil)
+
+func (m *mockExporter) ExportMetrics(context.Context, *colmetricspb.ExportMetricsServiceRequest) error {
+	panic("implement me")
+}
+
+func (m *mockExporter) ExportLogs(_ context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, req)
+	return nil
+}
+
+func (m *mockExporter) Shutdown() error {
+	return nil
+}
+
+func TestParseSeverities(t *testing.T) {
+	severities, err := parseSeverities("info=3, WARN=1,error=0")
+	require.NoError(t, err)
+	assert.Equal(t, []severityWeight{
+		{text: "INFO", number: logspb.SeverityNumber_SEVERITY_NUMBER_INFO, weight: 3},
+		{text: "WARN", number: logspb.SeverityNumber_SEVERITY_NUMBER_WARN, weight: 1},
+		{text: "ERROR", number: logspb.SeverityNumber_SEVERITY_NUMBER_ERROR, weight: 0},
+	}, severities)
+
+	_, err = parseSeverities("INFO")
+	assert.EqualError(t, err, `invalid severity "INFO", expected <severity>=<weight>`)
+	_, err = parseSeverities("NOTICE=1")
+	assert.EqualError(t, err, `unknown severity "NOTICE"`)
+	_, err = parseSeverities("INFO=-1")
+	assert.EqualError(t, err, `invalid weight "-1" of severity "INFO"`)
+	_, err = parseSeverities("INFO=0")
+	assert.EqualError(t, err, "at least one severity must have a weight greater than 0")
+}
+
+func TestBatch(t *testing.T) {
+	severities, err := parseSeverities("WARN=1,ERROR=0")
+	require.NoError(t, err)
+	cfg := &Config{Config: common.Config{ServiceName: "tracegen"}, BodySize: 42}
+	g := newGenerator(cfg, severities, &mockExporter{}, 0)
+
+	req := g.batch(time.Now(), 5)
+	records := req.ResourceLogs[0].InstrumentationLibraryLogs[0].Logs
+	require.Len(t, records, 5)
+	for i, r := range records {
+		assert.Len(t, r.Body.GetStringValue(), 42)
+		assert.Equal(t, "WARN", r.SeverityText)
+		assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_WARN, r.SeverityNumber)
+		assert.EqualValues(t, i+1, r.Attributes[1].Value.GetIntValue())
+	}
+}
+
+func TestSeverityMix(t *testing.T) {
+	severities, err := parseSeverities("INFO=3,ERROR=1")
+	require.NoError(t, err)
+	g := newGenerator(&Config{}, severities, &mockExporter{}, 0)
+
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		counts[g.severity().text]++
+	}
+	assert.InDelta(t, 7500, counts["INFO"], 500)
+	assert.InDelta(t, 2500, counts["ERROR"], 500)
+}
+
+func TestRun(t *testing.T) {
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 2,
+			NumBatches:  2,
+			BatchSize:   10,
+		},
+		BodySize:   10,
+		Severities: "INFO=1",
+	}
+
+	summary, err := Run(cfg, exp, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.Len(t, exp.logs, 4)
+	assert.Equal(t, &common.Summary{Batches: 4, Items: 40}, summary)
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsgen
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/common"
+)
+
+// Config describes the metrics to generate.
+type Config struct {
+	common.Config
+	NumGauges     int
+	NumSums       int
+	NumHistograms int
+	Series        int
+	Churn         float64
+	ChurnInterval time.Duration
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *flag.FlagSet) {
+	c.Config.Flags(fs)
+	fs.IntVar(&c.NumGauges, "gauges", 1, "Number of gauge metrics to generate")
+	fs.IntVar(&c.NumSums, "sums", 1, "Number of cumulative monotonic sum metrics to generate")
+	fs.IntVar(&c.NumHistograms, "histograms", 1, "Number of delta histogram metrics to generate")
+	fs.IntVar(&c.Series, "series", 10, "Number of series (distinct label sets) of each metric")
+	fs.Float64Var(&c.Churn, "churn", 0, "Fraction of the series, from 0 to 1, replaced by new ones every churn interval")
+	fs.DurationVar(&c.ChurnInterval, "churn-interval", time.Minute, "How often series are churned")
+}
+
+// Validate checks the metrics specific settings and caps the batch size to one
+// data point per series of every metric, so a batch never reports a series twice.
+func (c *Config) Validate() error {
+	if c.NumGauges < 0 || c.NumSums < 0 || c.NumHistograms < 0 {
+		return fmt.Errorf("the number of metrics cannot be negative")
+	}
+	if c.NumGauges+c.NumSums+c.NumHistograms == 0 {
+		return fmt.Errorf("at least one metric must be generated")
+	}
+	if c.Series <= 0 {
+		return fmt.Errorf("`series` must be greater than 0")
+	}
+	if c.Churn < 0 || c.Churn > 1 {
+		return fmt.Errorf("`churn` must be between 0 and 1")
+	}
+	if c.Churn > 0 && c.ChurnInterval <= 0 {
+		return fmt.Errorf("`churn-interval` must be greater than 0")
+	}
+	if points := c.pointsPerTick(); c.BatchSize > points {
+		c.BatchSize = points
+	}
+	return nil
+}
+
+// pointsPerTick is the number of data points reporting every series of every metric once.
+func (c *Config) pointsPerTick() int {
+	return (c.NumGauges + c.NumSums + c.NumHistograms) * c.Series
+}
+
+// Run generates metrics and exports them with the given exporter.
+func Run(c *Config, exp common.Exporter, logger *zap.Logger) (*common.Summary, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return common.Run(&c.Config, logger, func(worker int) common.SendBatch {
+		return newGenerator(c, exp, worker).sendBatch
+	})
+}
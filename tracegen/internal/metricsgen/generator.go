@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsgen
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/common"
+)
+
+type metricType int
+
+const (
+	gauge metricType = iota
+	sum
+	histogram
+)
+
+// histogramBounds are the explicit bounds of the generated histograms.
+var histogramBounds = []float64{5, 10, 25, 50, 100, 250, 500, 1000}
+
+type metricDesc struct {
+	name string
+	typ  metricType
+}
+
+// seriesKey identifies a series of a metric.
+type seriesKey struct {
+	metric int
+	series int
+}
+
+// generator creates the batches of a single worker. Batches cycle through every
+// series of every metric so all series are reported at the same frequency, and
+// a batch holds at most one data point per series.
+type generator struct {
+	exp         common.Exporter
+	rand        *rand.Rand
+	resource    *resourcepb.Resource
+	workerLabel string
+	metrics     []metricDesc
+
+	churn         float64
+	churnInterval time.Duration
+	lastChurn     time.Time
+	lastBatch     time.Time // time of the previous batch, when churned series appeared
+	series        []int     // ids of the active series
+	nextSeriesID  int
+	cursor        int // position of the next point in metrics x series
+
+	sums       map[seriesKey]float64   // cumulative value of each sum series
+	startTimes map[seriesKey]time.Time // start time of the next point of each series
+}
+
+func newGenerator(c *Config, exp common.Exporter, worker int) *generator {
+	now := time.Now()
+	g := &generator{
+		exp:  exp,
+		rand: rand.New(rand.NewSource(time.Now().UnixNano() + int64(worker))),
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{stringKeyValue("service.name", c.ServiceName)},
+		},
+		workerLabel:   strconv.Itoa(worker),
+		churn:         c.Churn,
+		churnInterval: c.ChurnInterval,
+		lastChurn:     now,
+		lastBatch:     now,
+		series:        make([]int, c.Series),
+		nextSeriesID:  c.Series,
+		sums:          map[seriesKey]float64{},
+		startTimes:    map[seriesKey]time.Time{},
+	}
+	for i := range g.series {
+		g.series[i] = i
+	}
+	for i := 0; i < c.NumGauges; i++ {
+		g.metrics = append(g.metrics, metricDesc{name: fmt.Sprintf("tracegen.gauge.%d", i), typ: gauge})
+	}
+	for i := 0; i < c.NumSums; i++ {
+		g.metrics = append(g.metrics, metricDesc{name: fmt.Sprintf("tracegen.sum.%d", i), typ: sum})
+	}
+	for i := 0; i < c.NumHistograms; i++ {
+		g.metrics = append(g.metrics, metricDesc{name: fmt.Sprintf("tracegen.histogram.%d", i), typ: histogram})
+	}
+	for _, series := range g.series {
+		g.startSeries(series, now)
+	}
+	return g
+}
+
+// startSeries sets the time the series of every metric starts from.
+func (g *generator) startSeries(series int, start time.Time) {
+	for metric := range g.metrics {
+		g.startTimes[seriesKey{metric: metric, series: series}] = start
+	}
+}
+
+func (g *generator) sendBatch(ctx context.Context, size int) error {
+	return g.exp.ExportMetrics(ctx, g.batch(time.Now(), size))
+}
+
+// batch returns a request with size data points, churning the series first if due.
+// The size is capped to one data point per series of every metric.
+func (g *generator) batch(now time.Time, size int) *colmetricspb.ExportMetricsServiceRequest {
+	if g.churn > 0 && now.Sub(g.lastChurn) >= g.churnInterval {
+		g.churnSeries()
+		g.lastChurn = now
+	}
+	if points := len(g.metrics) * len(g.series); size > points {
+		size = points
+	}
+	g.lastBatch = now
+
+	byMetric := map[int]*metricspb.Metric{}
+	var ordered []*metricspb.Metric
+	for i := 0; i < size; i++ {
+		metricIdx := g.cursor / len(g.series)
+		seriesIdx := g.cursor % len(g.series)
+		g.cursor = (g.cursor + 1) % (len(g.metrics) * len(g.series))
+
+		m, ok := byMetric[metricIdx]
+		if !ok {
+			m = g.newMetric(g.metrics[metricIdx])
+			byMetric[metricIdx] = m
+			ordered = append(ordered, m)
+		}
+		g.addPoint(m, seriesKey{metric: metricIdx, series: g.series[seriesIdx]}, now)
+	}
+
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: g.resource,
+			InstrumentationLibraryMetrics: []*metricspb.InstrumentationLibraryMetrics{{
+				InstrumentationLibrary: &commonpb.InstrumentationLibrary{Name: "tracegen"},
+				Metrics:                ordered,
+			}},
+		}},
+	}
+}
+
+// churnSeries replaces a fraction of the active series by new ones, which start
+// from the previous batch.
+func (g *generator) churnSeries() {
+	n := int(math.Ceil(g.churn * float64(len(g.series))))
+	for _, idx := range g.rand.Perm(len(g.series))[:n] {
+		old := g.series[idx]
+		for metric := range g.metrics {
+			delete(g.sums, seriesKey{metric: metric, series: old})
+			delete(g.startTimes, seriesKey{metric: metric, series: old})
+		}
+		g.series[idx] = g.nextSeriesID
+		g.startSeries(g.nextSeriesID, g.lastBatch)
+		g.nextSeriesID++
+	}
+}
+
+func (g *generator) newMetric(desc metricDesc) *metricspb.Metric {
+	m := &metricspb.Metric{Name: desc.name}
+	switch desc.typ {
+	case gauge:
+		m.Data = &metricspb.Metric_DoubleGauge{DoubleGauge: &metricspb.DoubleGauge{}}
+	case sum:
+		m.Data = &metricspb.Metric_DoubleSum{DoubleSum: &metricspb.DoubleSum{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}}
+	case histogram:
+		m.Data = &metricspb.Metric_DoubleHistogram{DoubleHistogram: &metricspb.DoubleHistogram{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+		}}
+	}
+	return m
+}
+
+func (g *generator) addPoint(m *metricspb.Metric, key seriesKey, now time.Time) {
+	labels := []*commonpb.StringKeyValue{
+		{Key: "series", Value: strconv.Itoa(key.series)},
+		{Key: "worker", Value: g.workerLabel},
+	}
+	nowNano := uint64(now.UnixNano())
+
+	switch data := m.Data.(type) {
+	case *metricspb.Metric_DoubleGauge:
+		data.DoubleGauge.DataPoints = append(data.DoubleGauge.DataPoints, &metricspb.DoubleDataPoint{
+			Labels:       labels,
+			TimeUnixNano: nowNano,
+			Value:        g.rand.Float64() * 100,
+		})
+	case *metricspb.Metric_DoubleSum:
+		g.sums[key] += float64(g.rand.Intn(10) + 1)
+		data.DoubleSum.DataPoints = append(data.DoubleSum.DataPoints, &metricspb.DoubleDataPoint{
+			Labels:            labels,
+			StartTimeUnixNano: uint64(g.startTimes[key].UnixNano()),
+			TimeUnixNano:      nowNano,
+			Value:             g.sums[key],
+		})
+	case *metricspb.Metric_DoubleHistogram:
+		// Delta points start where the previous point of the series ended.
+		dp := &metricspb.DoubleHistogramDataPoint{
+			Labels:            labels,
+			StartTimeUnixNano: uint64(g.startTimes[key].UnixNano()),
+			TimeUnixNano:      nowNano,
+			BucketCounts:      make([]uint64, len(histogramBounds)+1),
+			ExplicitBounds:    histogramBounds,
+		}
+		g.startTimes[key] = now
+		observations := g.rand.Intn(10) + 1
+		for i := 0; i < observations; i++ {
+			v := g.rand.ExpFloat64() * 100
+			dp.Count++
+			dp.Sum += v
+			dp.BucketCounts[bucketIndex(v)]++
+		}
+		data.DoubleHistogram.DataPoints = append(data.DoubleHistogram.DataPoints, dp)
+	}
+}
+
+// bucketIndex returns the index of the bucket the value falls into.
+func bucketIndex(v float64) int {
+	for i, bound := range histogramBounds {
+		if v <= bound {
+			return i
+		}
+	}
+	return len(histogramBounds)
+}
+
+func stringKeyValue(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsgen
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/common"
+)
+
+type mockExporter struct {
+	mu      sync.Mutex
+	metrics []*colmetricspb.ExportMetricsServiceRequest
+}
+
+var _ common.Exporter = (*mockExporter)(nil)
+
+func (m *mockExporter) ExportMetrics(_ context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = append(m.metrics, req)
+	return nil
+}
+
+func (m *mockExporter) ExportLogs(context.Context, *collogspb.ExportLogsServiceRequest) error {
+	panic("implement me")
+}
+
+func (m *mockExporter) Shutdown() error {
+	return nil
+}
+
+func newTestConfig() *Config {
+	return &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+			NumBatches:  1,
+			BatchSize:   6,
+			ServiceName: "tracegen",
+		},
+		NumGauges:     1,
+		NumSums:       1,
+		NumHistograms: 1,
+		Series:        2,
+	}
+}
+
+// points returns the series label of the data points of each metric.
+func points(req *colmetricspb.ExportMetricsServiceRequest) map[string][]string {
+	out := map[string][]string{}
+	for _, m := range req.ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case *metricspb.Metric_DoubleGauge:
+			for _, dp := range data.DoubleGauge.DataPoints {
+				out[m.Name] = append(out[m.Name], dp.Labels[0].Value)
+			}
+		case *metricspb.Metric_DoubleSum:
+			for _, dp := range data.DoubleSum.DataPoints {
+				out[m.Name] = append(out[m.Name], dp.Labels[0].Value)
+			}
+		case *metricspb.Metric_DoubleHistogram:
+			for _, dp := range data.DoubleHistogram.DataPoints {
+				out[m.Name] = append(out[m.Name], dp.Labels[0].Value)
+			}
+		}
+	}
+	return out
+}
+
+func TestBatchCyclesThroughSeries(t *testing.T) {
+	g := newGenerator(newTestConfig(), &mockExporter{}, 0)
+
+	req := g.batch(time.Now(), 6)
+	assert.Equal(t, map[string][]string{
+		"tracegen.gauge.0":     {"0", "1"},
+		"tracegen.sum.0":       {"0", "1"},
+		"tracegen.histogram.0": {"0", "1"},
+	}, points(req))
+
+	req = g.batch(time.Now(), 3)
+	assert.Equal(t, map[string][]string{
+		"tracegen.gauge.0": {"0", "1"},
+		"tracegen.sum.0":   {"0"},
+	}, points(req))
+}
+
+func TestBatchHasOnePointPerSeries(t *testing.T) {
+	g := newGenerator(newTestConfig(), &mockExporter{}, 0)
+
+	// Batches larger than the number of series don't report a series twice.
+	req := g.batch(time.Now(), 10)
+	assert.Equal(t, map[string][]string{
+		"tracegen.gauge.0":     {"0", "1"},
+		"tracegen.sum.0":       {"0", "1"},
+		"tracegen.histogram.0": {"0", "1"},
+	}, points(req))
+}
+
+func TestCumulativeSums(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.NumGauges, cfg.NumHistograms, cfg.Series = 0, 0, 1
+	g := newGenerator(cfg, &mockExporter{}, 0)
+
+	first := g.batch(time.Now(), 1).ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[0].GetDoubleSum()
+	second := g.batch(time.Now(), 1).ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[0].GetDoubleSum()
+
+	assert.True(t, first.IsMonotonic)
+	assert.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, first.AggregationTemporality)
+	assert.Equal(t, first.DataPoints[0].StartTimeUnixNano, second.DataPoints[0].StartTimeUnixNano)
+	assert.Greater(t, second.DataPoints[0].Value, first.DataPoints[0].Value)
+}
+
+func TestHistogram(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.NumGauges, cfg.NumSums, cfg.Series = 0, 0, 1
+	g := newGenerator(cfg, &mockExporter{}, 0)
+
+	now := time.Now().Add(time.Second)
+	dp := g.batch(now, 1).ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[0].GetDoubleHistogram().DataPoints[0]
+	require.Len(t, dp.BucketCounts, len(histogramBounds)+1)
+	var count uint64
+	for _, c := range dp.BucketCounts {
+		count += c
+	}
+	assert.Equal(t, dp.Count, count)
+	assert.Greater(t, dp.Count, uint64(0))
+	// The first point starts when the series was created.
+	assert.NotZero(t, dp.StartTimeUnixNano)
+	assert.Less(t, dp.StartTimeUnixNano, dp.TimeUnixNano)
+
+	// The next point starts where the previous one ended.
+	next := g.batch(now.Add(time.Second), 1).ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[0].GetDoubleHistogram().DataPoints[0]
+	assert.Equal(t, dp.TimeUnixNano, next.StartTimeUnixNano)
+	assert.Equal(t, uint64(now.Add(time.Second).UnixNano()), next.TimeUnixNano)
+}
+
+func TestChurn(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.NumSums, cfg.NumHistograms, cfg.Series = 0, 0, 4
+	cfg.Churn = 0.5
+	cfg.ChurnInterval = time.Minute
+	g := newGenerator(cfg, &mockExporter{}, 0)
+
+	now := time.Now()
+	assert.ElementsMatch(t, []string{"0", "1", "2", "3"}, points(g.batch(now, 4))["tracegen.gauge.0"])
+	// Not churned before the interval elapsed.
+	assert.ElementsMatch(t, []string{"0", "1", "2", "3"}, points(g.batch(now.Add(time.Second), 4))["tracegen.gauge.0"])
+
+	churned := points(g.batch(now.Add(2*time.Minute), 4))["tracegen.gauge.0"]
+	assert.Len(t, churned, 4)
+	newSeries := 0
+	for _, s := range churned {
+		if s == "4" || s == "5" {
+			newSeries++
+		}
+	}
+	assert.Equal(t, 2, newSeries)
+}
+
+func TestRun(t *testing.T) {
+	exp := &mockExporter{}
+	cfg := newTestConfig()
+	cfg.NumBatches = 3
+
+	summary, err := Run(cfg, exp, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.Len(t, exp.metrics, 3)
+	assert.Equal(t, &common.Summary{Batches: 3, Items: 18}, summary)
+}
+
+func TestValidate(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.NumGauges, cfg.NumSums, cfg.NumHistograms = 0, 0, 0
+	assert.EqualError(t, cfg.Validate(), "at least one metric must be generated")
+
+	cfg = newTestConfig()
+	cfg.Churn = 2
+	assert.EqualError(t, cfg.Validate(), "`churn` must be between 0 and 1")
+
+	cfg = newTestConfig()
+	cfg.BatchSize = 100
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, 6, cfg.BatchSize)
+}
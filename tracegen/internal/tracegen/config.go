@@ -34,10 +34,12 @@ type Config struct {
 	Rate             int64
 	TotalDuration    time.Duration
 	ServiceName      string
+	ScenarioFile     string
 
 	// OTLP config
 	Endpoint string
 	Insecure bool
+	UseHTTP  bool
 }
 
 // Flags registers config flags.
@@ -48,18 +50,20 @@ func (c *Config) Flags(fs *flag.FlagSet) {
 	fs.Int64Var(&c.Rate, "rate", 0, "Approximately how many traces per second each worker should generate. Zero means no throttling.")
 	fs.DurationVar(&c.TotalDuration, "duration", 0, "For how long to run the test")
 	fs.StringVar(&c.ServiceName, "service", "tracegen", "Service name to use")
+	fs.StringVar(&c.ScenarioFile, "scenario", "", "Path to a YAML scenario file describing the services and call graph of the generated traces")
 
 	// unfortunately, at this moment, the otel-go client doesn't support configuring OTLP via env vars
 	fs.StringVar(&c.Endpoint, "otlp-endpoint", "localhost:55680", "Target to which the exporter is going to send spans or metrics. This MAY be configured to include a path (e.g. example.com/v1/traces)")
 	fs.BoolVar(&c.Insecure, "otlp-insecure", false, "Whether to enable client transport security for the exporter's grpc or http connection")
+	fs.BoolVar(&c.UseHTTP, "otlp-http", false, "Whether to use OTLP over HTTP instead of gRPC")
 }
 
-// Run executes the test scenario.
-func Run(c *Config, logger *zap.Logger) error {
+// prepare validates the number of traces and duration and returns the rate limit of each worker.
+func (c *Config) prepare(logger *zap.Logger) (rate.Limit, error) {
 	if c.TotalDuration > 0 {
 		c.NumTraces = 0
 	} else if c.NumTraces <= 0 {
-		return fmt.Errorf("either `traces` or `duration` must be greater than 0")
+		return 0, fmt.Errorf("either `traces` or `duration` must be greater than 0")
 	}
 
 	limit := rate.Limit(c.Rate)
@@ -69,6 +73,15 @@ func Run(c *Config, logger *zap.Logger) error {
 	} else {
 		logger.Info("generation of traces is limited", zap.Float64("per-second", float64(limit)))
 	}
+	return limit, nil
+}
+
+// Run executes the test scenario.
+func Run(c *Config, logger *zap.Logger) error {
+	limit, err := c.prepare(logger)
+	if err != nil {
+		return err
+	}
 
 	wg := sync.WaitGroup{}
 	var running uint32 = 1
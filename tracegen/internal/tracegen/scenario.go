@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracegen
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v2"
+)
+
+// Scenario describes the call graph of the traces to generate.
+type Scenario struct {
+	// Services maps each service name to the resource attributes it reports.
+	Services map[string]ServiceSpec `yaml:"services"`
+	// Root is the operation that starts every trace.
+	Root Operation `yaml:"root"`
+}
+
+// ServiceSpec describes a service of the scenario.
+type ServiceSpec struct {
+	Attributes map[string]string `yaml:"attributes"`
+}
+
+// Operation is a span of a service and the operations it calls.
+type Operation struct {
+	// Service emitting the span.
+	Service string `yaml:"service"`
+	// Name of the span.
+	Name string `yaml:"name"`
+	// Kind is the span kind: server, client, producer, consumer or internal.
+	// Defaults to server.
+	Kind string `yaml:"kind"`
+	// Latency is the time spent in the operation itself, excluding its calls.
+	Latency Distribution `yaml:"latency"`
+	// NetworkLatency is the round trip time of the call from another service
+	// to this operation. The span of the operation starts half of it after the
+	// calling span starts and ends half of it before the calling span ends.
+	// It is ignored for the root operation and for calls within a service.
+	NetworkLatency Distribution `yaml:"network_latency"`
+	// ErrorRate is the probability, from 0 to 1, for the span to have an error status.
+	ErrorRate float64 `yaml:"error_rate"`
+	// Attributes set on the span.
+	Attributes map[string]AttributeSpec `yaml:"attributes"`
+	// Calls are the operations called, in order, by this operation.
+	Calls []Operation `yaml:"calls"`
+
+	kind trace.SpanKind
+}
+
+// Distribution generates random durations. In a scenario file it is either
+// a duration (e.g. 10ms) for a constant value or a map with a `distribution`
+// of constant, uniform, normal or exponential and its parameters.
+type Distribution struct {
+	Type   string        `yaml:"distribution"`
+	Value  time.Duration `yaml:"value"`
+	Min    time.Duration `yaml:"min"`
+	Max    time.Duration `yaml:"max"`
+	Mean   time.Duration `yaml:"mean"`
+	StdDev time.Duration `yaml:"stddev"`
+}
+
+// UnmarshalYAML accepts both a plain duration and the full distribution map.
+func (d *Distribution) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var constant time.Duration
+	if err := unmarshal(&constant); err == nil {
+		*d = Distribution{Type: "constant", Value: constant}
+		return nil
+	}
+	type plain Distribution
+	return unmarshal((*plain)(d))
+}
+
+func (d Distribution) validate() error {
+	switch d.Type {
+	case "", "constant":
+		if d.Value < 0 {
+			return errors.New("constant latency cannot be negative")
+		}
+	case "uniform":
+		if d.Min < 0 || d.Max < d.Min {
+			return errors.New("uniform latency requires 0 <= min <= max")
+		}
+	case "normal":
+		if d.Mean < 0 || d.StdDev < 0 {
+			return errors.New("normal latency requires a positive mean and stddev")
+		}
+	case "exponential":
+		if d.Mean <= 0 {
+			return errors.New("exponential latency requires a mean greater than 0")
+		}
+	default:
+		return fmt.Errorf("unknown latency distribution %q", d.Type)
+	}
+	return nil
+}
+
+// sample returns a random duration from the distribution, never negative.
+func (d Distribution) sample(r *rand.Rand) time.Duration {
+	var v float64
+	switch d.Type {
+	case "uniform":
+		v = float64(d.Min) + r.Float64()*float64(d.Max-d.Min)
+	case "normal":
+		v = float64(d.Mean) + r.NormFloat64()*float64(d.StdDev)
+	case "exponential":
+		v = r.ExpFloat64() * float64(d.Mean)
+	default:
+		v = float64(d.Value)
+	}
+	return time.Duration(math.Max(v, 0))
+}
+
+// AttributeSpec generates the values of a span attribute. In a scenario file it is
+// either a static value, a map with a list of `values` to pick from, or a map with a
+// `cardinality` generating that many distinct values prefixed by `prefix`.
+type AttributeSpec struct {
+	Values      []string `yaml:"values"`
+	Cardinality int      `yaml:"cardinality"`
+	Prefix      string   `yaml:"prefix"`
+}
+
+// UnmarshalYAML accepts both a static value and the full attribute map.
+func (a *AttributeSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var static string
+	if err := unmarshal(&static); err == nil {
+		*a = AttributeSpec{Values: []string{static}}
+		return nil
+	}
+	type plain AttributeSpec
+	return unmarshal((*plain)(a))
+}
+
+func (a AttributeSpec) validate() error {
+	if a.Cardinality < 0 {
+		return errors.New("cardinality cannot be negative")
+	}
+	if len(a.Values) == 0 && a.Cardinality == 0 {
+		return errors.New("either values or cardinality must be set")
+	}
+	return nil
+}
+
+// sample returns a random value of the attribute.
+func (a AttributeSpec) sample(r *rand.Rand) string {
+	if a.Cardinality > 0 {
+		return fmt.Sprintf("%s%d", a.Prefix, r.Intn(a.Cardinality))
+	}
+	return a.Values[r.Intn(len(a.Values))]
+}
+
+var spanKinds = map[string]trace.SpanKind{
+	"":         trace.SpanKindServer,
+	"server":   trace.SpanKindServer,
+	"client":   trace.SpanKindClient,
+	"producer": trace.SpanKindProducer,
+	"consumer": trace.SpanKindConsumer,
+	"internal": trace.SpanKindInternal,
+}
+
+// LoadScenario reads and validates the scenario file at the given path.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+	s := &Scenario{}
+	if err := yaml.UnmarshalStrict(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario file: %w", err)
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario file: %w", err)
+	}
+	return s, nil
+}
+
+func (s *Scenario) validate() error {
+	if s.Services == nil {
+		s.Services = map[string]ServiceSpec{}
+	}
+	return s.Root.validate(s, "root")
+}
+
+// validate checks the operation and its calls, registering services used but not
+// declared so every operation has a tracer.
+func (op *Operation) validate(s *Scenario, path string) error {
+	if op.Service == "" {
+		return fmt.Errorf("%s: service must be set", path)
+	}
+	if op.Name == "" {
+		return fmt.Errorf("%s: name must be set", path)
+	}
+	path = fmt.Sprintf("%s/%s", op.Service, op.Name)
+
+	kind, ok := spanKinds[op.Kind]
+	if !ok {
+		return fmt.Errorf("%s: unknown span kind %q", path, op.Kind)
+	}
+	op.kind = kind
+
+	if err := op.Latency.validate(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := op.NetworkLatency.validate(); err != nil {
+		return fmt.Errorf("%s: network_latency: %w", path, err)
+	}
+	if op.ErrorRate < 0 || op.ErrorRate > 1 {
+		return fmt.Errorf("%s: error_rate must be between 0 and 1", path)
+	}
+	for key, attr := range op.Attributes {
+		if err := attr.validate(); err != nil {
+			return fmt.Errorf("%s: attribute %q: %w", path, key, err)
+		}
+	}
+	if _, ok := s.Services[op.Service]; !ok {
+		s.Services[op.Service] = ServiceSpec{}
+	}
+
+	for i := range op.Calls {
+		if err := op.Calls[i].validate(s, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServiceNames returns the sorted names of all services in the scenario.
+func (s *Scenario) ServiceNames() []string {
+	names := make([]string, 0, len(s.Services))
+	for name := range s.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// attributes returns the sampled attributes of the operation.
+func (op *Operation) attributes(r *rand.Rand) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(op.Attributes))
+	for key, spec := range op.Attributes {
+		attrs = append(attrs, attribute.String(key, spec.sample(r)))
+	}
+	return attrs
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracegen
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func TestLoadScenario(t *testing.T) {
+	s, err := LoadScenario(path.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"cart", "checkout", "frontend"}, s.ServiceNames())
+	assert.Equal(t, map[string]string{"deployment.environment": "test"}, s.Services["frontend"].Attributes)
+	assert.Equal(t, Distribution{Type: "constant", Value: 5 * time.Millisecond}, s.Root.Latency)
+	assert.Equal(t, AttributeSpec{Values: []string{"GET"}}, s.Root.Attributes["http.method"])
+	assert.Equal(t, AttributeSpec{Cardinality: 100, Prefix: "user-"}, s.Root.Attributes["user.id"])
+	require.Len(t, s.Root.Calls, 2)
+	assert.Equal(t, trace.SpanKindInternal, s.Root.Calls[0].Calls[0].kind)
+	assert.Equal(t, trace.SpanKindConsumer, s.Root.Calls[1].kind)
+}
+
+func TestScenarioValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      Operation
+		wantErr string
+	}{
+		{
+			name:    "missing service",
+			op:      Operation{Name: "op"},
+			wantErr: "root: service must be set",
+		},
+		{
+			name:    "unknown kind",
+			op:      Operation{Service: "svc", Name: "op", Kind: "remote"},
+			wantErr: `svc/op: unknown span kind "remote"`,
+		},
+		{
+			name:    "invalid error rate",
+			op:      Operation{Service: "svc", Name: "op", ErrorRate: 2},
+			wantErr: "svc/op: error_rate must be between 0 and 1",
+		},
+		{
+			name:    "invalid distribution",
+			op:      Operation{Service: "svc", Name: "op", Latency: Distribution{Type: "uniform", Min: 2, Max: 1}},
+			wantErr: "svc/op: uniform latency requires 0 <= min <= max",
+		},
+		{
+			name:    "invalid network latency",
+			op:      Operation{Service: "svc", Name: "op", NetworkLatency: Distribution{Type: "exponential"}},
+			wantErr: "svc/op: network_latency: exponential latency requires a mean greater than 0",
+		},
+		{
+			name:    "empty attribute",
+			op:      Operation{Service: "svc", Name: "op", Attributes: map[string]AttributeSpec{"key": {}}},
+			wantErr: `svc/op: attribute "key": either values or cardinality must be set`,
+		},
+		{
+			name:    "invalid call",
+			op:      Operation{Service: "svc", Name: "op", Calls: []Operation{{Service: "other"}}},
+			wantErr: "svc/op: name must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scenario{Root: tt.op}
+			assert.EqualError(t, s.validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunScenario(t *testing.T) {
+	s, err := LoadScenario(path.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+
+	syncer := &mockSyncer{}
+	sp := sdktrace.NewSimpleSpanProcessor(syncer)
+	tracers := map[string]trace.Tracer{}
+	for _, name := range s.ServiceNames() {
+		tp := sdktrace.NewTracerProvider(sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(name))))
+		tp.RegisterSpanProcessor(sp)
+		tracers[name] = tp.Tracer("tracegen")
+	}
+
+	cfg := &Config{
+		NumTraces:   2,
+		WorkerCount: 1,
+	}
+	summary, err := RunScenario(cfg, s, tracers, zap.NewNop())
+	require.NoError(t, err)
+
+	// Each trace has the root, a client and server span per remote call and the internal span.
+	require.Len(t, syncer.spans, 2*6)
+	assert.Equal(t, 2, summary.Traces)
+	assert.Equal(t, map[string]int{
+		"frontend GET /cart":    2,
+		"frontend GetCart":      2,
+		"cart GetCart":          2,
+		"cart redis GET":        2,
+		"frontend cart.updated": 2,
+		"checkout cart.updated": 2,
+	}, summary.Spans)
+	// The consumer always fails, failing its producer and the root span too.
+	assert.Equal(t, map[string]int{
+		"frontend GET /cart":    2,
+		"frontend cart.updated": 2,
+		"checkout cart.updated": 2,
+	}, summary.Errors)
+
+	spansByID := map[trace.SpanID]*sdktrace.SpanSnapshot{}
+	for _, span := range syncer.spans {
+		spansByID[span.SpanContext.SpanID()] = span
+	}
+	for _, span := range syncer.spans {
+		service, _ := span.Resource.Set().Value(semconv.ServiceNameKey)
+		switch {
+		case span.Name == "cart.updated" && service.AsString() == "frontend":
+			assert.Equal(t, trace.SpanKindProducer, span.SpanKind)
+			assert.Equal(t, codes.Error, span.StatusCode)
+		case span.Name == "GetCart" && service.AsString() == "frontend":
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+			assert.Equal(t, codes.Unset, span.StatusCode)
+		case span.Name == "GetCart" && service.AsString() == "cart":
+			// The server span is offset from its client span by half of the
+			// network latency of the call on each side.
+			client := spansByID[span.Parent.SpanID()]
+			require.NotNil(t, client)
+			assert.Equal(t, 2*time.Millisecond, span.StartTime.Sub(client.StartTime))
+			assert.Equal(t, 2*time.Millisecond, client.EndTime.Sub(span.EndTime))
+		case span.Name == "GET /cart":
+			assert.False(t, span.Parent.IsValid())
+			assert.True(t, !span.EndTime.Before(span.StartTime.Add(5*time.Millisecond)))
+		default:
+			assert.True(t, span.Parent.IsValid())
+		}
+	}
+}
+
+func TestRunScenarioMissingTracer(t *testing.T) {
+	s, err := LoadScenario(path.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+
+	_, err = RunScenario(&Config{NumTraces: 1, WorkerCount: 1}, s, map[string]trace.Tracer{}, zap.NewNop())
+	assert.EqualError(t, err, `no tracer for service "cart"`)
+}
@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracegen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// errSimulated is recorded on spans failing according to their error rate.
+var errSimulated = errors.New("simulated error")
+
+// Summary counts what was generated by a scenario run.
+type Summary struct {
+	mu     sync.Mutex
+	Traces int
+	// Spans and Errors are counted per "<service> <span name>".
+	Spans  map[string]int
+	Errors map[string]int
+}
+
+func newSummary() *Summary {
+	return &Summary{
+		Spans:  map[string]int{},
+		Errors: map[string]int{},
+	}
+}
+
+func (s *Summary) add(other *Summary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Traces += other.Traces
+	for k, v := range other.Spans {
+		s.Spans[k] += v
+	}
+	for k, v := range other.Errors {
+		s.Errors[k] += v
+	}
+}
+
+// String renders the summary as a table with one line per operation.
+func (s *Summary) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.Spans))
+	total := 0
+	for k, v := range s.Spans {
+		keys = append(keys, k)
+		total += v
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "traces: %d, spans: %d\n", s.Traces, total)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "  %-50s spans: %-10d errors: %d\n", k, s.Spans[k], s.Errors[k])
+	}
+	return b.String()
+}
+
+type scenarioWorker struct {
+	scenario         *Scenario
+	tracers          map[string]trace.Tracer // tracer of each service of the scenario
+	running          *uint32                 // pointer to shared flag that indicates it's time to stop the test
+	numTraces        int                     // how many traces the worker has to generate (only when duration==0)
+	propagateContext bool                    // whether the worker needs to propagate the trace context via HTTP headers
+	limitPerSecond   rate.Limit              // how many traces per second to generate
+	rand             *rand.Rand
+	summary          *Summary // totals of all workers, updated when the worker is done
+	wg               *sync.WaitGroup
+	logger           *zap.Logger
+}
+
+func (w scenarioWorker) simulateTraces() {
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	local := newSummary()
+	for atomic.LoadUint32(w.running) == 1 {
+		limiter.Wait(context.Background())
+
+		w.simulateOperation(context.Background(), &w.scenario.Root, "", time.Now(), local)
+		local.Traces++
+
+		if w.numTraces != 0 && local.Traces >= w.numTraces {
+			break
+		}
+	}
+	w.summary.add(local)
+	w.logger.Info("traces generated", zap.Int("traces", local.Traces))
+	w.wg.Done()
+}
+
+// simulateOperation emits the span of the operation starting at the given time and the
+// spans of its calls, returning when the operation ends and whether it failed.
+// When the operation is called from another service the caller side of the call is
+// emitted as a client (or producer) span in the parent service, and the operation's
+// span is offset from it by the network latency of the call.
+func (w scenarioWorker) simulateOperation(ctx context.Context, op *Operation, parentService string, start time.Time, summary *Summary) (time.Time, bool) {
+	remote := parentService != "" && parentService != op.Service
+	var (
+		callerSpan     trace.Span
+		networkLatency time.Duration
+	)
+	if remote {
+		callerKind := trace.SpanKindClient
+		if op.kind == trace.SpanKindConsumer {
+			callerKind = trace.SpanKindProducer
+		}
+		ctx, callerSpan = w.tracers[parentService].Start(ctx, op.Name,
+			trace.WithSpanKind(callerKind),
+			trace.WithTimestamp(start),
+			trace.WithAttributes(semconv.PeerServiceKey.String(op.Service)),
+		)
+		summary.Spans[parentService+" "+op.Name]++
+
+		if w.propagateContext {
+			header := propagation.HeaderCarrier{}
+			// simulates going remote
+			otel.GetTextMapPropagator().Inject(ctx, header)
+
+			// simulates getting a request from a client
+			ctx = otel.GetTextMapPropagator().Extract(context.Background(), header)
+		}
+
+		networkLatency = op.NetworkLatency.sample(w.rand)
+		start = start.Add(networkLatency / 2)
+	}
+
+	ctx, span := w.tracers[op.Service].Start(ctx, op.Name,
+		trace.WithSpanKind(op.kind),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(op.attributes(w.rand)...),
+	)
+	key := op.Service + " " + op.Name
+	summary.Spans[key]++
+
+	// The operation's own latency is split around the calls it makes.
+	latency := op.Latency.sample(w.rand)
+	end := start.Add(latency / 2)
+	failed := false
+	for i := range op.Calls {
+		var callFailed bool
+		end, callFailed = w.simulateOperation(ctx, &op.Calls[i], op.Service, end, summary)
+		failed = failed || callFailed
+	}
+	end = end.Add(latency - latency/2)
+
+	if w.rand.Float64() < op.ErrorRate {
+		failed = true
+	}
+	if failed {
+		span.RecordError(errSimulated, trace.WithTimestamp(end))
+		span.SetStatus(codes.Error, errSimulated.Error())
+		summary.Errors[key]++
+	}
+	span.End(trace.WithTimestamp(end))
+
+	if callerSpan != nil {
+		if failed {
+			callerSpan.SetStatus(codes.Error, errSimulated.Error())
+			summary.Errors[parentService+" "+op.Name]++
+		}
+		end = end.Add(networkLatency - networkLatency/2)
+		callerSpan.End(trace.WithTimestamp(end))
+	}
+	return end, failed
+}
+
+// RunScenario executes the scenario with the given tracer for each service and returns
+// a summary of what was generated.
+func RunScenario(c *Config, s *Scenario, tracers map[string]trace.Tracer, logger *zap.Logger) (*Summary, error) {
+	for _, name := range s.ServiceNames() {
+		if tracers[name] == nil {
+			return nil, fmt.Errorf("no tracer for service %q", name)
+		}
+	}
+
+	limit, err := c.prepare(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := newSummary()
+	wg := sync.WaitGroup{}
+	var running uint32 = 1
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := scenarioWorker{
+			scenario:         s,
+			tracers:          tracers,
+			running:          &running,
+			numTraces:        c.NumTraces,
+			propagateContext: c.PropagateContext,
+			limitPerSecond:   limit,
+			rand:             rand.New(rand.NewSource(time.Now().UnixNano() + int64(i))),
+			summary:          summary,
+			wg:               &wg,
+			logger:           logger.With(zap.Int("worker", i)),
+		}
+
+		go w.simulateTraces()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		atomic.StoreUint32(&running, 0)
+	}
+	wg.Wait()
+	return summary, nil
+}
@@ -19,27 +19,50 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	grpcZap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlphttp"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/logsgen"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/metricsgen"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/tracegen/internal/tracegen"
 )
 
 func main() {
-	fs := flag.CommandLine
-	cfg := new(tracegen.Config)
-	cfg.Flags(fs)
-	flag.Parse()
+	args := os.Args[1:]
+	command := "traces"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "traces":
+		runTraces(args)
+	case "metrics":
+		runMetrics(args)
+	case "logs":
+		runLogs(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q, expected one of traces, metrics or logs\n", command)
+		os.Exit(2)
+	}
+}
 
+func newLogger() *zap.Logger {
 	logger, err := zap.NewDevelopment()
 	if err != nil {
 		panic(fmt.Sprintf("failed to obtain logger: %v", err))
@@ -47,21 +70,79 @@ func main() {
 	grpcZap.ReplaceGrpcLoggerV2(logger.WithOptions(
 		zap.AddCallerSkip(3),
 	))
+	return logger
+}
 
-	expOptions := []otlpgrpc.Option{
-		otlpgrpc.WithEndpoint(cfg.Endpoint),
-		otlpgrpc.WithDialOption(
-			grpc.WithBlock(),
-		),
+func runMetrics(args []string) {
+	fs := flag.NewFlagSet("metrics", flag.ExitOnError)
+	cfg := new(metricsgen.Config)
+	cfg.Flags(fs)
+	_ = fs.Parse(args)
+
+	logger := newLogger()
+	exp, err := common.NewExporter(&cfg.Config)
+	if err != nil {
+		logger.Error("failed to obtain OTLP exporter", zap.Error(err))
+		return
 	}
+	defer func() {
+		if err = exp.Shutdown(); err != nil {
+			logger.Error("failed to stop the exporter", zap.Error(err))
+		}
+	}()
 
-	if cfg.Insecure {
-		expOptions = append(expOptions, otlpgrpc.WithInsecure())
+	summary, err := metricsgen.Run(cfg, exp, logger)
+	if err != nil {
+		logger.Error("failed to generate metrics", zap.Error(err))
+		return
 	}
+	fmt.Println(summary)
+}
 
-	driver := otlpgrpc.NewDriver(expOptions...)
+func runLogs(args []string) {
+	fs := flag.NewFlagSet("logs", flag.ExitOnError)
+	cfg := new(logsgen.Config)
+	cfg.Flags(fs)
+	_ = fs.Parse(args)
 
-	exp, err := otlp.NewExporter(context.Background(), driver)
+	logger := newLogger()
+	exp, err := common.NewExporter(&cfg.Config)
+	if err != nil {
+		logger.Error("failed to obtain OTLP exporter", zap.Error(err))
+		return
+	}
+	defer func() {
+		if err = exp.Shutdown(); err != nil {
+			logger.Error("failed to stop the exporter", zap.Error(err))
+		}
+	}()
+
+	summary, err := logsgen.Run(cfg, exp, logger)
+	if err != nil {
+		logger.Error("failed to generate logs", zap.Error(err))
+		return
+	}
+	fmt.Println(summary)
+}
+
+func runTraces(args []string) {
+	fs := flag.NewFlagSet("traces", flag.ExitOnError)
+	cfg := new(tracegen.Config)
+	cfg.Flags(fs)
+	_ = fs.Parse(args)
+
+	logger := newLogger()
+
+	var err error
+	var scenario *tracegen.Scenario
+	if cfg.ScenarioFile != "" {
+		if scenario, err = tracegen.LoadScenario(cfg.ScenarioFile); err != nil {
+			logger.Error("failed to load the scenario", zap.Error(err))
+			return
+		}
+	}
+
+	exp, err := otlp.NewExporter(context.Background(), newDriver(cfg))
 	if err != nil {
 		logger.Error("failed to obtain OTLP exporter", zap.Error(err))
 		return
@@ -77,6 +158,29 @@ func main() {
 	ssp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithBatchTimeout(time.Second))
 	defer ssp.Shutdown(context.Background())
 
+	if scenario != nil {
+		// Each service reports its own resource so it needs its own provider. The
+		// providers share the span processor and the exporter.
+		tracers := map[string]trace.Tracer{}
+		for _, name := range scenario.ServiceNames() {
+			attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(name)}
+			for k, v := range scenario.Services[name].Attributes {
+				attrs = append(attrs, attribute.String(k, v))
+			}
+			tp := sdktrace.NewTracerProvider(sdktrace.WithResource(resource.NewWithAttributes(attrs...)))
+			tp.RegisterSpanProcessor(ssp)
+			tracers[name] = tp.Tracer("tracegen")
+		}
+
+		summary, err := tracegen.RunScenario(cfg, scenario, tracers, logger)
+		if err != nil {
+			logger.Error("failed to run the scenario", zap.Error(err))
+			return
+		}
+		fmt.Print(summary)
+		return
+	}
+
 	tracerProvider := sdktrace.NewTracerProvider(
 		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(cfg.ServiceName))),
 	)
@@ -88,3 +192,29 @@ func main() {
 		logger.Error("failed to stop the exporter", zap.Error(err))
 	}
 }
+
+// newDriver creates the OTLP gRPC or HTTP driver from the config.
+func newDriver(cfg *tracegen.Config) otlp.ProtocolDriver {
+	if cfg.UseHTTP {
+		httpOptions := []otlphttp.Option{
+			otlphttp.WithEndpoint(cfg.Endpoint),
+		}
+		if cfg.Insecure {
+			httpOptions = append(httpOptions, otlphttp.WithInsecure())
+		}
+		return otlphttp.NewDriver(httpOptions...)
+	}
+
+	expOptions := []otlpgrpc.Option{
+		otlpgrpc.WithEndpoint(cfg.Endpoint),
+		otlpgrpc.WithDialOption(
+			grpc.WithBlock(),
+		),
+	}
+
+	if cfg.Insecure {
+		expOptions = append(expOptions, otlpgrpc.WithInsecure())
+	}
+
+	return otlpgrpc.NewDriver(expOptions...)
+}